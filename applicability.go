@@ -0,0 +1,100 @@
+package gpp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/prebid/go-gpp/constants"
+)
+
+// ApplicabilityReport compares the sections of a GPP string with the section IDs which apply to a request,
+// as given by gpp_sid in OpenRTB.
+type ApplicabilityReport struct {
+	// Applicable holds the sections of the container whose IDs apply, in the order of the container.
+	Applicable []Section
+	// Missing holds the applicable IDs the container has no section for, in the order of gpp_sid.
+	Missing []constants.SectionID
+	// NotApplicable holds the IDs of the sections of the container which do not apply.
+	NotApplicable []constants.SectionID
+	// Duplicates holds the IDs listed more than once in gpp_sid.
+	Duplicates []constants.SectionID
+}
+
+// CheckApplicability sorts the sections of a container by whether their IDs are in gppSID. Sections are
+// matched by the SectionTypes of the container, so a section which failed to decode still counts as
+// present.
+func CheckApplicability(container GppContainer, gppSID []constants.SectionID) ApplicabilityReport {
+	var report ApplicabilityReport
+
+	applicable := make(map[constants.SectionID]bool, len(gppSID))
+	for _, id := range gppSID {
+		if applicable[id] {
+			report.Duplicates = append(report.Duplicates, id)
+		}
+		applicable[id] = true
+	}
+
+	present := make(map[constants.SectionID]bool, len(container.SectionTypes))
+	for i, id := range container.SectionTypes {
+		present[id] = true
+		if !applicable[id] {
+			report.NotApplicable = append(report.NotApplicable, id)
+		} else if i < len(container.Sections) {
+			report.Applicable = append(report.Applicable, container.Sections[i])
+		}
+	}
+
+	for _, id := range gppSID {
+		if !present[id] {
+			report.Missing = append(report.Missing, id)
+			present[id] = true
+		}
+	}
+
+	return report
+}
+
+// Consistent reports whether gpp_sid lists exactly the sections of the GPP string, once each.
+func (r ApplicabilityReport) Consistent() bool {
+	return len(r.Missing) == 0 && len(r.NotApplicable) == 0 && len(r.Duplicates) == 0
+}
+
+// String describes the inconsistencies found, e.g. "missing sections: uspca (8); sections not applicable:
+// tcfeu2 (2)", or returns "consistent".
+func (r ApplicabilityReport) String() string {
+	var parts []string
+	if len(r.Missing) > 0 {
+		parts = append(parts, "missing sections: "+describeSectionIDs(r.Missing))
+	}
+	if len(r.NotApplicable) > 0 {
+		parts = append(parts, "sections not applicable: "+describeSectionIDs(r.NotApplicable))
+	}
+	if len(r.Duplicates) > 0 {
+		parts = append(parts, "duplicated gpp_sid: "+describeSectionIDs(r.Duplicates))
+	}
+	if len(parts) == 0 {
+		return "consistent"
+	}
+	return strings.Join(parts, "; ")
+}
+
+func describeSectionIDs(ids []constants.SectionID) string {
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		if name, ok := constants.SectionNamesByID[int(id)]; ok {
+			names[i] = fmt.Sprintf("%s (%d)", name, id)
+		} else {
+			names[i] = fmt.Sprintf("%d", id)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// SectionIDsFromInt8 converts gpp_sid as held by OpenRTB libraries, which use int8, to section IDs.
+func SectionIDsFromInt8(gppSID []int8) []constants.SectionID {
+	ids := make([]constants.SectionID, len(gppSID))
+	for i, id := range gppSID {
+		ids[i] = constants.SectionID(id)
+	}
+	return ids
+}
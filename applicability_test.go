@@ -0,0 +1,71 @@
+package gpp
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckApplicability(t *testing.T) {
+	container, errs := Parse("DBACNYA~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YNN")
+	assert.Empty(t, errs)
+
+	testData := []struct {
+		description           string
+		gppSID                []constants.SectionID
+		expectedApplicable    []constants.SectionID
+		expectedMissing       []constants.SectionID
+		expectedNotApplicable []constants.SectionID
+		expectedDuplicates    []constants.SectionID
+		expectedString        string
+	}{
+		{
+			description:        "should accept a gpp_sid listing every section",
+			gppSID:             []constants.SectionID{6, 2},
+			expectedApplicable: []constants.SectionID{2, 6},
+			expectedString:     "consistent",
+		},
+		{
+			description:           "should report sections which do not apply",
+			gppSID:                []constants.SectionID{6},
+			expectedApplicable:    []constants.SectionID{6},
+			expectedNotApplicable: []constants.SectionID{2},
+			expectedString:        "sections not applicable: tcfeu2 (2)",
+		},
+		{
+			description:           "should report applicable sections missing from the string",
+			gppSID:                []constants.SectionID{8, 2, 99, 8},
+			expectedApplicable:    []constants.SectionID{2},
+			expectedMissing:       []constants.SectionID{8, 99},
+			expectedNotApplicable: []constants.SectionID{6},
+			expectedDuplicates:    []constants.SectionID{8},
+			expectedString:        "missing sections: uspca (8), 99; sections not applicable: uspv1 (6); duplicated gpp_sid: uspca (8)",
+		},
+		{
+			description:           "should treat an empty gpp_sid as nothing applying",
+			expectedNotApplicable: []constants.SectionID{2, 6},
+			expectedString:        "sections not applicable: tcfeu2 (2), uspv1 (6)",
+		},
+	}
+
+	for _, test := range testData {
+		report := CheckApplicability(container, test.gppSID)
+
+		var applicable []constants.SectionID
+		for _, section := range report.Applicable {
+			applicable = append(applicable, section.GetID())
+		}
+		assert.Equal(t, test.expectedApplicable, applicable, test.description)
+		assert.Equal(t, test.expectedMissing, report.Missing, test.description)
+		assert.Equal(t, test.expectedNotApplicable, report.NotApplicable, test.description)
+		assert.Equal(t, test.expectedDuplicates, report.Duplicates, test.description)
+		assert.Equal(t, test.expectedString == "consistent", report.Consistent(), test.description)
+		assert.Equal(t, test.expectedString, report.String(), test.description)
+	}
+}
+
+func TestSectionIDsFromInt8(t *testing.T) {
+	assert.Equal(t, []constants.SectionID{2, 6}, SectionIDsFromInt8([]int8{2, 6}))
+	assert.Equal(t, []constants.SectionID{}, SectionIDsFromInt8(nil))
+}
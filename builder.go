@@ -0,0 +1,42 @@
+package gpp
+
+// Builder collects sections into a GPP string. Sections are usually made with the builders of the
+// section packages, whose Build results can be passed to With directly:
+//
+//	gppString, err := gpp.NewBuilder().
+//		With(uspnat.Builder().SaleOptOut(sections.OptedOut).SensitiveData(3, sections.Consent).Build()).
+//		With(uspca.Builder().SaleOptOut(sections.DidNotOptOut).Build()).
+//		Build()
+type Builder struct {
+	sections []Section
+	err      error
+}
+
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// Add adds a section to the string.
+func (b *Builder) Add(section Section) *Builder {
+	b.sections = append(b.sections, section)
+	return b
+}
+
+// With adds a section returned by a section builder, or records its error, which Build then returns.
+func (b *Builder) With(section Section, err error) *Builder {
+	if err != nil {
+		if b.err == nil {
+			b.err = err
+		}
+		return b
+	}
+	return b.Add(section)
+}
+
+// Build encodes the sections added to the builder, see EncodeStrict.
+func (b *Builder) Build() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+	return EncodeStrict(b.sections)
+}
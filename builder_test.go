@@ -0,0 +1,113 @@
+package gpp
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/sections/uspca"
+	"github.com/prebid/go-gpp/sections/uspco"
+	"github.com/prebid/go-gpp/sections/uspct"
+	"github.com/prebid/go-gpp/sections/uspde"
+	"github.com/prebid/go-gpp/sections/uspfl"
+	"github.com/prebid/go-gpp/sections/uspia"
+	"github.com/prebid/go-gpp/sections/uspin"
+	"github.com/prebid/go-gpp/sections/uspky"
+	"github.com/prebid/go-gpp/sections/uspmd"
+	"github.com/prebid/go-gpp/sections/uspmn"
+	"github.com/prebid/go-gpp/sections/uspmt"
+	"github.com/prebid/go-gpp/sections/uspnat"
+	"github.com/prebid/go-gpp/sections/uspne"
+	"github.com/prebid/go-gpp/sections/uspnh"
+	"github.com/prebid/go-gpp/sections/uspnj"
+	"github.com/prebid/go-gpp/sections/uspor"
+	"github.com/prebid/go-gpp/sections/uspri"
+	"github.com/prebid/go-gpp/sections/usptn"
+	"github.com/prebid/go-gpp/sections/usptx"
+	"github.com/prebid/go-gpp/sections/usput"
+	"github.com/prebid/go-gpp/sections/uspv1"
+	"github.com/prebid/go-gpp/sections/uspva"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	usp, err := uspv1.NewUSPV1("1YNN")
+	assert.Nil(t, err)
+
+	gppString, err := NewBuilder().
+		With(uspca.Builder().SaleOptOut(sections.DidNotOptOut).Build()).
+		With(uspnat.Builder().SaleOptOut(sections.OptedOut).SensitiveData(3, sections.Consent).GPC(true).Build()).
+		Add(usp).
+		Build()
+	assert.Nil(t, err)
+
+	container, errs := Parse(gppString)
+	assert.Empty(t, errs)
+	assert.Len(t, container.Sections, 3)
+
+	nat := container.Sections[1].(uspnat.USPNAT)
+	assert.Equal(t, sections.OptedOut, nat.SaleOptOut())
+	assert.Equal(t, sections.Consent, nat.SensitiveDataConsent(3))
+	assert.True(t, nat.GPC())
+	assert.Equal(t, sections.DidNotOptOut, container.Sections[2].(uspca.USPCA).SaleOptOut())
+}
+
+func TestBuilderUSSections(t *testing.T) {
+	builder := NewBuilder().
+		With(uspnat.Builder().Build()).
+		With(uspca.Builder().Build()).
+		With(uspva.Builder().Build()).
+		With(uspco.Builder().Build()).
+		With(usput.Builder().Build()).
+		With(uspct.Builder().Build()).
+		With(uspfl.Builder().Build()).
+		With(uspmt.Builder().Build()).
+		With(uspor.Builder().Build()).
+		With(usptx.Builder().Build()).
+		With(uspde.Builder().Build()).
+		With(uspia.Builder().Build()).
+		With(uspne.Builder().Build()).
+		With(uspnh.Builder().Build()).
+		With(uspnj.Builder().Build()).
+		With(usptn.Builder().Build()).
+		With(uspmn.Builder().Build()).
+		With(uspmd.Builder().Build()).
+		With(uspin.Builder().Build()).
+		With(uspky.Builder().Build()).
+		With(uspri.Builder().Build())
+
+	gppString, err := builder.Build()
+	assert.Nil(t, err)
+
+	container, errs := Parse(gppString)
+	assert.Empty(t, errs)
+	assert.Len(t, container.Sections, 21)
+	for _, section := range container.Sections {
+		assert.Equal(t, string(section.Encode(true)), section.GetValue(), "section %d", section.GetID())
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	testData := []struct {
+		description string
+		builder     *Builder
+		expectedErr string
+	}{
+		{
+			description: "should return the error of a section builder",
+			builder: NewBuilder().
+				With(uspnat.Builder().Build()).
+				With(uspca.Builder().SensitiveData(10, sections.Consent).Build()),
+			expectedErr: "invalid index 10 for field CoreSegment.SensitiveDataProcessing, should be 1 to 9: out of range",
+		},
+		{
+			description: "should return the error of Encode",
+			builder:     NewBuilder().With(uspnat.Builder().Build()).With(uspnat.Builder().Build()),
+			expectedErr: "duplicated sections",
+		},
+	}
+
+	for _, test := range testData {
+		_, err := test.builder.Build()
+		assert.EqualError(t, err, test.expectedErr, test.description)
+	}
+}
@@ -0,0 +1,404 @@
+// Command gpp decodes, encodes, validates and explains GPP strings.
+//
+// Usage:
+//
+//	gpp decode [-json] [gpp-string ...]
+//	gpp encode [-strict] [json ...]
+//	gpp validate [-strict] [-fields] [gpp-string ...]
+//	gpp explain [gpp-string ...]
+//	gpp stats [-field path] [file ...]
+//
+// Each command reads its inputs from the arguments, or one per line from standard input when there are
+// none, so it can be used in shell pipelines. encode instead reads a stream of JSON documents from
+// standard input, so the indented output of decode can be piped into it; it does not read YAML, which
+// would need a parser outside the standard library. stats reads the GPP strings from files rather than
+// the arguments.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	gpp "github.com/prebid/go-gpp"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/sections/uspv1"
+)
+
+// Exit codes. validate exits with the highest code of its inputs.
+const (
+	exitOK           = 0
+	exitError        = 1
+	exitUsage        = 2
+	exitHeaderError  = 3
+	exitSectionError = 4
+)
+
+const usage = `usage: gpp <command> [flags] [input ...]
+
+commands:
+  decode    print the sections and fields of GPP strings, as indented JSON or with -json one line each
+  encode    build GPP strings from the JSON printed by decode, read from the arguments or as a stream of
+            documents from standard input; YAML is not supported. With -strict, reject values which do
+            not fit their section
+  validate  check GPP strings, exiting with 3 for header errors and 4 for section errors; with -fields
+            also check the values of the US sections against the GPP specification
+  explain   summarize the opt-outs of GPP strings in plain English
+  stats     count the sections, field values and errors of GPP strings, read from files or standard
+            input as plain lines or, with -field, JSON lines holding the string at a dot-separated path
+
+Inputs are read from the arguments, or one per line from standard input when there are none.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	flags := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	var command func(input string, stdout io.Writer) (int, error)
+	read := readInputs
+	switch args[0] {
+	case "decode":
+		compact := flags.Bool("json", false, "print each decoded string as one line of JSON")
+		command = func(input string, stdout io.Writer) (int, error) { return decode(input, *compact, stdout) }
+	case "encode":
+		strict := flags.Bool("strict", false, "reject field values which do not fit their section instead of truncating them")
+		command = func(input string, stdout io.Writer) (int, error) { return encode(input, *strict, stdout) }
+		read = readJSONInputs
+	case "validate":
+		strict := flags.Bool("strict", false, "also reject non-zero padding and stop at the first section error")
+		fields := flags.Bool("fields", false, "also report invalid and inconsistent values of the US sections")
+		command = func(input string, stdout io.Writer) (int, error) { return validate(input, *strict, *fields, stdout) }
+	case "explain":
+		command = explain
+	case "stats":
+		field := flags.String("field", "", "read JSON lines and take the GPP string from this dot-separated field path")
+		command = func(string, io.Writer) (int, error) { return runStats(flags.Args(), *field, stdin, stdout) }
+	default:
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+	if err := flags.Parse(args[1:]); err != nil {
+		return exitUsage
+	}
+
+	if args[0] == "stats" {
+		code, err := command("", stdout)
+		if err != nil {
+			fmt.Fprintf(stderr, "gpp: %s\n", err)
+		}
+		return code
+	}
+
+	exitCode := exitOK
+	handle := func(input string, err error) {
+		code := exitError
+		if err == nil {
+			code, err = command(input, stdout)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "gpp: %s\n", err)
+		}
+		if code > exitCode {
+			exitCode = code
+		}
+	}
+	if err := read(flags.Args(), stdin, handle); err != nil {
+		fmt.Fprintf(stderr, "gpp: %s\n", err)
+		if exitCode < exitError {
+			exitCode = exitError
+		}
+	}
+	return exitCode
+}
+
+// maxLineSize is the longest line read from standard input.
+const maxLineSize = 1024 * 1024
+
+// readInputs calls handle with each argument, or with each non-empty line of stdin as it is read if there
+// are none. A line longer than maxLineSize is passed to handle as an error wrapping bufio.ErrTooLong, and
+// reading goes on with the next line. The error returned is the first one reading stdin.
+func readInputs(args []string, stdin io.Reader, handle func(input string, err error)) error {
+	if len(args) > 0 {
+		for _, arg := range args {
+			handle(arg, nil)
+		}
+		return nil
+	}
+
+	reader := bufio.NewReader(stdin)
+	var line []byte
+	tooLong := false
+	lineNumber := 0
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		if len(line)+len(chunk) > maxLineSize+1 {
+			line, tooLong = line[:0], true
+		} else if !tooLong {
+			line = append(line, chunk...)
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+
+		lineNumber++
+		if len(line) > maxLineSize && line[len(line)-1] != '\n' {
+			tooLong = true
+		}
+		if tooLong {
+			handle("", fmt.Errorf("line %d is longer than %d bytes: %w", lineNumber, maxLineSize, bufio.ErrTooLong))
+		} else if text := strings.TrimSpace(string(line)); text != "" {
+			handle(text, nil)
+		}
+		line, tooLong = line[:0], false
+
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readJSONInputs calls handle with each argument, or with each JSON document of stdin as it is read if
+// there are none. The documents may span several lines and need not be separated by newlines. A document
+// which is not valid JSON is passed to handle as an error, and reading goes on from the next line starting
+// with '{', the start of the next document as printed by decode. The error returned is the first one
+// reading stdin.
+func readJSONInputs(args []string, stdin io.Reader, handle func(input string, err error)) error {
+	if len(args) > 0 {
+		for _, arg := range args {
+			handle(arg, nil)
+		}
+		return nil
+	}
+
+	reader := bufio.NewReader(stdin)
+	decoder := json.NewDecoder(reader)
+	for documentNumber := 1; ; documentNumber++ {
+		var document json.RawMessage
+		err := decoder.Decode(&document)
+		if err == io.EOF {
+			return nil
+		}
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) || err == io.ErrUnexpectedEOF {
+			handle("", fmt.Errorf("document %d: %w", documentNumber, err))
+			reader = bufio.NewReader(io.MultiReader(decoder.Buffered(), reader))
+			if err := skipToNextDocument(reader); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			decoder = json.NewDecoder(reader)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		handle(string(document), nil)
+	}
+}
+
+// skipToNextDocument discards the rest of the line holding the next non-space byte, which starts the
+// invalid document, then the lines up to the next one starting with '{'.
+func skipToNextDocument(reader *bufio.Reader) error {
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b != ' ' && b != '\t' && b != '\r' && b != '\n' {
+			break
+		}
+	}
+	for {
+		if err := skipLine(reader); err != nil {
+			return err
+		}
+		next, err := reader.Peek(1)
+		if err != nil {
+			return err
+		}
+		if next[0] == '{' {
+			return nil
+		}
+	}
+}
+
+// skipLine discards the input up to and including the next newline.
+func skipLine(reader *bufio.Reader) error {
+	for {
+		_, err := reader.ReadSlice('\n')
+		if err != bufio.ErrBufferFull {
+			return err
+		}
+	}
+}
+
+func decode(input string, compact bool, stdout io.Writer) (int, error) {
+	container, errs := gpp.Parse(input)
+
+	var data []byte
+	var err error
+	if compact {
+		data, err = json.Marshal(container)
+	} else {
+		data, err = json.MarshalIndent(container, "", "  ")
+	}
+	if err != nil {
+		return exitError, err
+	}
+	fmt.Fprintln(stdout, string(data))
+
+	return errorsExitCode(errs), joinErrors(input, errs)
+}
+
+func encode(input string, strict bool, stdout io.Writer) (int, error) {
+	var container gpp.GppContainer
+	if err := json.Unmarshal([]byte(input), &container); err != nil {
+		return exitError, err
+	}
+
+	encodeSections := gpp.Encode
+	if strict {
+		encodeSections = gpp.EncodeStrict
+	}
+	gppString, err := encodeSections(container.Sections)
+	if err != nil {
+		return exitError, err
+	}
+	fmt.Fprintln(stdout, gppString)
+	return exitOK, nil
+}
+
+func validate(input string, strict, fields bool, stdout io.Writer) (int, error) {
+	container, errs := gpp.ParseWithOptions(input, gpp.Options{FailFast: strict, RejectNonZeroPadding: strict})
+	for _, err := range errs {
+		fmt.Fprintf(stdout, "%s: %s\n", input, err)
+	}
+	exitCode := errorsExitCode(errs)
+
+	var issues int
+	if fields {
+		for _, section := range container.Sections {
+			validator, ok := section.(sections.Validator)
+			if !ok {
+				continue
+			}
+			for _, issue := range validator.Validate() {
+				issues++
+				fmt.Fprintf(stdout, "%s: %s %s\n", input, gpp.SectionName(section.GetID()), issue)
+				if issue.Severity == sections.SeverityError && exitCode == exitOK {
+					exitCode = exitSectionError
+				}
+			}
+		}
+	}
+
+	if len(errs) == 0 && issues == 0 {
+		fmt.Fprintf(stdout, "%s: ok\n", input)
+	}
+	return exitCode, nil
+}
+
+func explain(input string, stdout io.Writer) (int, error) {
+	container, errs := gpp.Parse(input)
+
+	fmt.Fprintf(stdout, "%s\n", input)
+	for _, section := range container.Sections {
+		if section == nil {
+			continue
+		}
+		fmt.Fprintf(stdout, "  %s\n", explainSection(section))
+	}
+	return errorsExitCode(errs), joinErrors(input, errs)
+}
+
+func explainSection(section gpp.Section) string {
+	name := gpp.SectionName(section.GetID())
+
+	switch s := section.(type) {
+	case sections.USPrivacySection:
+		parts := []string{
+			"sale " + describe(optOutText, s.SaleOptOut()),
+			"targeted advertising " + describe(optOutText, s.TargetedAdvertisingOptOut()),
+		}
+		for i, consent := range s.KnownChildSensitiveDataConsents() {
+			if consent != sections.ConsentNotApplicable {
+				parts = append(parts, fmt.Sprintf("known child consent %d: %s", i+1, describe(consentText, consent)))
+			}
+		}
+		if s.GPC() {
+			parts = append(parts, "Global Privacy Control set")
+		}
+		return fmt.Sprintf("%s: %s", name, strings.Join(parts, "; "))
+	case uspv1.USPV1:
+		return fmt.Sprintf("%s: notice given: %s; sale opted out: %s; LSPA covered: %s",
+			name, describe(flagText, s.Notice), describe(flagText, s.OptOutSale), describe(flagText, s.LSPACoveredTransaction))
+	}
+	return fmt.Sprintf("%s: no opt-out summary available", name)
+}
+
+var optOutText = map[interface{}]string{
+	sections.OptOutNotApplicable: "opt-out not applicable",
+	sections.OptedOut:            "opted out",
+	sections.DidNotOptOut:        "not opted out",
+}
+
+var consentText = map[interface{}]string{
+	sections.ConsentNotApplicable: "not applicable",
+	sections.NoConsent:            "no consent",
+	sections.Consent:              "consent",
+}
+
+var flagText = map[interface{}]string{
+	uspv1.Yes:           "yes",
+	uspv1.No:            "no",
+	uspv1.NotApplicable: "not applicable",
+}
+
+// describe returns the text for a field value, or the value itself if it has none.
+func describe(texts map[interface{}]string, value interface{}) string {
+	if text, ok := texts[value]; ok {
+		return text
+	}
+	return fmt.Sprintf("unknown value %v", value)
+}
+
+func errorsExitCode(errs []error) int {
+	exitCode := exitOK
+	for _, err := range errs {
+		var headerErr *gpp.HeaderError
+		if errors.As(err, &headerErr) {
+			return exitHeaderError
+		}
+		exitCode = exitSectionError
+	}
+	return exitCode
+}
+
+func joinErrors(input string, errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return fmt.Errorf("%s: %s", input, strings.Join(messages, "; "))
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func runCommand(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestDecode(t *testing.T) {
+	code, stdout, stderr := runCommand("", "decode", "-json", "DBABTA~1YNN")
+
+	assert.Equal(t, exitOK, code)
+	assert.Empty(t, stderr)
+	assert.JSONEq(t, `{"version":1,"header":"DBABTA","section_types":[6],"sections":[{"section_id":6,"section":"uspv1","value":"1YNN",`+
+		`"version":1,"notice":"Y","opt_out_sale":"N","lspa_covered_transaction":"N"}]}`, stdout)
+}
+
+func TestDecodeStdin(t *testing.T) {
+	code, stdout, _ := runCommand("DBABTA~1YNN\n\nDBABTA~1NYN\n", "decode", "-json")
+
+	assert.Equal(t, exitOK, code)
+	assert.Len(t, strings.Split(strings.TrimSpace(stdout), "\n"), 2)
+}
+
+func TestEncode(t *testing.T) {
+	_, decoded, _ := runCommand("", "decode", "DBABBgA~xlgWEYCY.YA")
+
+	code, stdout, stderr := runCommand("", "encode", strings.Replace(decoded, "\n", "", -1))
+
+	assert.Equal(t, exitOK, code)
+	assert.Empty(t, stderr)
+	assert.Equal(t, "DBABBg~xlgWEYCY.YA\n", stdout)
+}
+
+func TestEncodeStdin(t *testing.T) {
+	_, decoded, _ := runCommand("DBABBgA~xlgWEYCY.YA\nDBABTA~1YNN\n", "decode")
+
+	code, stdout, stderr := runCommand(decoded, "encode")
+
+	assert.Equal(t, exitOK, code)
+	assert.Empty(t, stderr)
+	assert.Equal(t, "DBABBg~xlgWEYCY.YA\nDBABTA~1YNN\n", stdout)
+}
+
+func TestEncodeStrict(t *testing.T) {
+	input := `{"version":1,"section_types":[9],"sections":[{"section_id":9,"core_segment":{"version":1,` +
+		`"sale_opt_out":7,"sensitive_data_processing":[0,0,0,0,0,0,0,0],"known_child_sensitive_data_consents":[0],` +
+		`"mspa_covered_transaction":"no"}}]}`
+
+	code, stdout, _ := runCommand("", "encode", input)
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "DBABRg~BAwAACA\n", stdout)
+
+	code, stdout, stderr := runCommand("", "encode", "-strict", input)
+	assert.Equal(t, exitError, code)
+	assert.Empty(t, stdout)
+	assert.Equal(t, "gpp: error encoding uspva section: unable to encode field CoreSegment.SaleOptOut: value 7 does not fit in 2 bits\n", stderr)
+}
+
+func TestEncodeInvalidJSON(t *testing.T) {
+	code, _, stderr := runCommand("", "encode", "{")
+
+	assert.Equal(t, exitError, code)
+	assert.NotEmpty(t, stderr)
+
+	code, _, stderr = runCommand("{\n", "encode")
+
+	assert.Equal(t, exitError, code)
+	assert.Equal(t, "gpp: document 1: unexpected EOF\n", stderr)
+}
+
+func TestEncodeStdinInvalidDocument(t *testing.T) {
+	_, first, _ := runCommand("DBABBgA~xlgWEYCY.YA\n", "decode")
+	_, last, _ := runCommand("DBABTA~1YNN\n", "decode")
+
+	code, stdout, stderr := runCommand(first+"{\"version\": 1,\n  bad\n}\n"+last, "encode")
+
+	assert.Equal(t, exitError, code)
+	assert.Equal(t, "DBABBg~xlgWEYCY.YA\nDBABTA~1YNN\n", stdout)
+	assert.Equal(t, "gpp: document 2: invalid character 'b' looking for beginning of object key string\n", stderr)
+}
+
+func TestDecodeStdinLongLine(t *testing.T) {
+	input := "DBABTA~1YNN\n" + strings.Repeat("A", maxLineSize+1) + "\nDBABTA~1NYN"
+
+	code, stdout, stderr := runCommand(input, "decode", "-json")
+
+	assert.Equal(t, exitError, code)
+	assert.Len(t, strings.Split(strings.TrimSpace(stdout), "\n"), 2)
+	assert.Equal(t, "gpp: line 2 is longer than 1048576 bytes: bufio.Scanner: token too long\n", stderr)
+}
+
+func TestDecodeStdinReadError(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	stdin := io.MultiReader(strings.NewReader("DBABTA~1YNN\n"), iotest.ErrReader(errors.New("read failed")))
+
+	code := run([]string{"decode", "-json"}, stdin, &stdout, &stderr)
+
+	assert.Equal(t, exitError, code)
+	assert.Len(t, strings.Split(strings.TrimSpace(stdout.String()), "\n"), 1)
+	assert.Equal(t, "gpp: read failed\n", stderr.String())
+}
+
+func TestValidate(t *testing.T) {
+	testData := map[string]struct {
+		args     []string
+		exitCode int
+		stdout   string
+	}{
+		"valid": {
+			args:     []string{"validate", "DBABTA~1YNN"},
+			exitCode: exitOK,
+			stdout:   "DBABTA~1YNN: ok\n",
+		},
+		"header-error": {
+			args:     []string{"validate", "DBABTA~1YNN~1YNN"},
+			exitCode: exitHeaderError,
+			stdout:   "DBABTA~1YNN~1YNN: error parsing GPP header, section IDs do not match the number of sections: found 1 IDs, have 2 sections\n",
+		},
+		"section-error": {
+			args:     []string{"validate", "DBABTA~1YXN"},
+			exitCode: exitSectionError,
+			stdout:   "DBABTA~1YXN: error parsing uspv1 consent string: unable to set field OptOutSale due to parse error: invalid character 'X', should be one of 'Y', 'N' or '-'\n",
+		},
+		"strict-padding": {
+			args:     []string{"validate", "-strict", "DBABRgA~bSFgmiV"},
+			exitCode: exitSectionError,
+			stdout:   "DBABRgA~bSFgmiV: error parsing uspva consent string: non-zero padding bits\n",
+		},
+		"fields-notices": {
+			args:     []string{"validate", "-fields", "DBABBgA~xlgWEYCY.YA"},
+			exitCode: exitSectionError,
+			stdout: "DBABBgA~xlgWEYCY.YA: uspca error: CoreSegment.SaleOptOut: was declined although SaleOptOutNotice was not provided\n" +
+				"DBABBgA~xlgWEYCY.YA: uspca warning: CoreSegment.SharingOptOut: is not applicable although SharingOptOutNotice is set\n" +
+				"DBABBgA~xlgWEYCY.YA: uspca warning: CoreSegment.MspaOptOutOptionMode: is set although the transaction is not covered by the MSPA\n" +
+				"DBABBgA~xlgWEYCY.YA: uspca warning: CoreSegment.MspaServiceProviderMode: is set although the transaction is not covered by the MSPA\n",
+		},
+		"fields-errors": {
+			args:     []string{"validate", "-fields", "DBABRgA~bSFgmiU", "DBABTA~1YNN"},
+			exitCode: exitSectionError,
+			stdout: "DBABRgA~bSFgmiU: uspva error: CoreSegment.TargetedAdvertisingOptOut: must be not applicable in MSPA service provider mode\n" +
+				"DBABRgA~bSFgmiU: uspva error: CoreSegment.MspaOptOutOptionMode: must not be yes in MSPA service provider mode\n" +
+				"DBABRgA~bSFgmiU: uspva warning: CoreSegment.MspaOptOutOptionMode: is set although the transaction is not covered by the MSPA\n" +
+				"DBABRgA~bSFgmiU: uspva warning: CoreSegment.MspaServiceProviderMode: is set although the transaction is not covered by the MSPA\n" +
+				"DBABTA~1YNN: ok\n",
+		},
+		"highest-exit-code": {
+			args:     []string{"validate", "DBABTA~1YXN", "DBABTA~1YNN"},
+			exitCode: exitSectionError,
+			stdout:   "DBABTA~1YXN: error parsing uspv1 consent string: unable to set field OptOutSale due to parse error: invalid character 'X', should be one of 'Y', 'N' or '-'\nDBABTA~1YNN: ok\n",
+		},
+	}
+
+	for name, test := range testData {
+		t.Run(name, func(t *testing.T) {
+			code, stdout, _ := runCommand("", test.args...)
+
+			assert.Equal(t, test.exitCode, code)
+			assert.Equal(t, test.stdout, stdout)
+		})
+	}
+}
+
+func TestExplain(t *testing.T) {
+	code, stdout, _ := runCommand("", "explain", "DBABBgA~xlgWEYCY.YA", "DBACNY~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YNN")
+
+	assert.Equal(t, exitOK, code)
+	assert.Equal(t, "DBABBgA~xlgWEYCY.YA\n"+
+		"  uspca: sale not opted out; targeted advertising opt-out not applicable; Global Privacy Control set\n"+
+		"DBACNY~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YNN\n"+
+		"  tcfeu2: no opt-out summary available\n"+
+		"  uspv1: notice given: yes; sale opted out: no; LSPA covered: no\n", stdout)
+}
+
+func TestUsage(t *testing.T) {
+	code, _, stderr := runCommand("")
+	assert.Equal(t, exitUsage, code)
+	assert.Contains(t, stderr, "usage: gpp")
+
+	code, _, _ = runCommand("", "unknown")
+	assert.Equal(t, exitUsage, code)
+
+	code, _, _ = runCommand("", "validate", "-json")
+	assert.Equal(t, exitUsage, code)
+}
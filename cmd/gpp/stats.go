@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	gpp "github.com/prebid/go-gpp"
+	"github.com/prebid/go-gpp/util"
+)
+
+// stats aggregates the strings read by the stats command.
+type stats struct {
+	Strings  int                       `json:"strings"`
+	Failed   int                       `json:"failed"`
+	Sections map[string]int            `json:"sections"`
+	Fields   map[string]map[string]int `json:"fields"`
+	Errors   map[string]int            `json:"errors"`
+}
+
+// skippedFields are left out of the field counts, as nearly every string has a different value.
+var skippedFields = map[string]bool{
+	"section_id":   true,
+	"section":      true,
+	"value":        true,
+	"created":      true,
+	"last_updated": true,
+}
+
+func newStats() *stats {
+	return &stats{
+		Sections: make(map[string]int),
+		Fields:   make(map[string]map[string]int),
+		Errors:   make(map[string]int),
+	}
+}
+
+// runStats reads GPP strings from the files, or stdin if there are none, and prints their statistics.
+func runStats(files []string, field string, stdin io.Reader, stdout io.Writer) (int, error) {
+	readers := []io.Reader{stdin}
+	if len(files) > 0 {
+		readers = readers[:0]
+		for _, name := range files {
+			file, err := os.Open(name)
+			if err != nil {
+				return exitError, err
+			}
+			defer file.Close()
+			readers = append(readers, file)
+		}
+	}
+
+	scanner := gpp.NewScanner(io.MultiReader(readers...))
+	if field != "" {
+		scanner.SetJSONField(field)
+	}
+
+	s := newStats()
+	for scanner.Scan() {
+		if err := s.add(scanner.Container(), scanner.Errors()); err != nil {
+			return exitError, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return exitError, err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return exitError, err
+	}
+	fmt.Fprintln(stdout, string(data))
+	return exitOK, nil
+}
+
+func (s *stats) add(container gpp.GppContainer, errs []error) error {
+	s.Strings++
+	if len(errs) > 0 {
+		s.Failed++
+	}
+	failed := make(map[int]bool)
+	for _, err := range errs {
+		s.Errors[errorClass(err)]++
+
+		var sectionErr *gpp.SectionError
+		if errors.As(err, &sectionErr) {
+			failed[sectionErr.Index] = true
+		}
+	}
+
+	for _, id := range container.SectionTypes {
+		s.Sections[gpp.SectionName(id)]++
+	}
+
+	// Sections which failed to decode hold whatever the decoder returned, so only the others are counted.
+	for i, section := range container.Sections {
+		if section == nil || failed[i] {
+			continue
+		}
+		name := gpp.SectionName(section.GetID())
+
+		data, err := json.Marshal(section)
+		if err != nil {
+			return err
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		var fields interface{}
+		if err := decoder.Decode(&fields); err != nil {
+			return err
+		}
+		s.addFields(name, fields)
+	}
+	return nil
+}
+
+// addFields counts the values of scalar fields and of the elements of number arrays. Arrays of flags,
+// such as TCF vendor consents, and of objects are left out.
+func (s *stats) addFields(path string, value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if !skippedFields[key] {
+				s.addFields(path+"."+key, field)
+			}
+		}
+	case []interface{}:
+		for _, elem := range v {
+			if _, ok := elem.(json.Number); !ok {
+				return
+			}
+		}
+		for i, elem := range v {
+			s.count(path+"["+strconv.Itoa(i+1)+"]", fmt.Sprint(elem))
+		}
+	case nil:
+	default:
+		s.count(path, fmt.Sprint(v))
+	}
+}
+
+func (s *stats) count(path, value string) {
+	values, ok := s.Fields[path]
+	if !ok {
+		values = make(map[string]int)
+		s.Fields[path] = values
+	}
+	values[value]++
+}
+
+// errorClass buckets an error by where it happened and its cause, e.g.
+// "uspca CoreSegment.SaleOptOut: unexpected EOF".
+func errorClass(err error) string {
+	var inputErr *gpp.InputError
+	if errors.As(err, &inputErr) {
+		return "input"
+	}
+
+	cause := "invalid value"
+	switch {
+	case errors.Is(err, util.ErrUnexpectedEOF):
+		cause = "unexpected EOF"
+	case errors.Is(err, util.ErrInvalidBase64):
+		cause = "invalid base64"
+	case errors.Is(err, gpp.ErrNonZeroPadding):
+		cause = "non-zero padding"
+	}
+
+	var headerErr *gpp.HeaderError
+	if errors.As(err, &headerErr) {
+		return "header: " + cause
+	}
+	var sectionErr *gpp.SectionError
+	if errors.As(err, &sectionErr) {
+		if sectionErr.Field != "" {
+			return fmt.Sprintf("%s %s: %s", sectionErr.Name, sectionErr.Field, cause)
+		}
+		return fmt.Sprintf("%s: %s", sectionErr.Name, cause)
+	}
+	return cause
+}
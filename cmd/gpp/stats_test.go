@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	input := "DBABBgA~xlgWEYCY.YA\nDBABTA~1YXN\nDBABTA~1YNN\nDBGBM~CP\nDBABBgA~xlgWE\n"
+
+	code, stdout, stderr := runCommand(input, "stats")
+
+	assert.Equal(t, exitOK, code)
+	assert.Empty(t, stderr)
+
+	var result stats
+	assert.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, 5, result.Strings)
+	assert.Equal(t, 3, result.Failed)
+	assert.Equal(t, map[string]int{"uspca": 2, "uspv1": 2}, result.Sections)
+	assert.Equal(t, map[string]int{"true": 1}, result.Fields["uspca.gpc_segment.gpc"])
+	assert.Equal(t, map[string]int{"did_not_opt_out": 1}, result.Fields["uspca.core_segment.sale_opt_out"])
+	assert.Equal(t, map[string]int{"1": 1}, result.Fields["uspca.core_segment.sensitive_data_processing[2]"])
+	assert.Equal(t, map[string]int{"N": 1}, result.Fields["uspv1.opt_out_sale"])
+	assert.Equal(t, map[string]int{
+		"header: unexpected EOF":                                    1,
+		"uspv1 OptOutSale: invalid value":                           1,
+		"uspca CoreSegment.SensitiveDataProcessing: unexpected EOF": 1,
+	}, result.Errors)
+}
+
+func TestStatsJSONLFiles(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "requests.jsonl")
+	assert.NoError(t, os.WriteFile(file, []byte(`{"regs":{"gpp":"DBABTA~1YNN"}}`+"\n"+`{"regs":{}}`+"\n"), 0o600))
+
+	code, stdout, _ := runCommand("", "stats", "-field", "regs.gpp", file)
+
+	assert.Equal(t, exitOK, code)
+	var result stats
+	assert.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, 2, result.Strings)
+	assert.Equal(t, map[string]int{"uspv1": 1}, result.Sections)
+	assert.Equal(t, map[string]int{"input": 1}, result.Errors)
+}
+
+func TestStatsLongLine(t *testing.T) {
+	input := strings.Repeat("A", 1024*1024+1) + "\nDBABTA~1YNN\n"
+
+	code, stdout, stderr := runCommand(input, "stats")
+
+	assert.Equal(t, exitOK, code)
+	assert.Empty(t, stderr)
+	var result stats
+	assert.NoError(t, json.Unmarshal([]byte(stdout), &result))
+	assert.Equal(t, 2, result.Strings)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, map[string]int{"uspv1": 1}, result.Sections)
+	assert.Equal(t, map[string]int{"input": 1}, result.Errors)
+}
+
+func TestStatsMissingFile(t *testing.T) {
+	code, _, stderr := runCommand("", "stats", filepath.Join(t.TempDir(), "missing"))
+
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr, "missing")
+}
@@ -0,0 +1,62 @@
+package gpp
+
+import "github.com/prebid/go-gpp/constants"
+
+// Get returns the section with the given ID, if the container has one.
+func (gpp GppContainer) Get(id constants.SectionID) (Section, bool) {
+	for i, sectionID := range gpp.SectionTypes {
+		if sectionID == id && i < len(gpp.Sections) {
+			return gpp.Sections[i], true
+		}
+	}
+	return nil, false
+}
+
+// Without returns a copy of the container without the sections with the given IDs, e.g. to remove the
+// sections of jurisdictions which do not apply to a bidder. The container itself is not changed.
+func (gpp GppContainer) Without(ids ...constants.SectionID) GppContainer {
+	remove := sectionIDSet(ids)
+	return gpp.filter(func(id constants.SectionID) bool { return !remove[id] })
+}
+
+// Only returns a copy of the container with only the sections with the given IDs. The container itself is
+// not changed.
+func (gpp GppContainer) Only(ids ...constants.SectionID) GppContainer {
+	keep := sectionIDSet(ids)
+	return gpp.filter(func(id constants.SectionID) bool { return keep[id] })
+}
+
+// Encode returns the container as a GPP string, encoded as EncodeContainerWithOptions does with Lossless:
+// sections which have not changed since they were parsed keep their original text, and so does the
+// header while the container has the sections it was parsed with. It returns an error if the container
+// cannot be encoded, e.g. because a section failed to decode.
+func (gpp GppContainer) Encode() (string, error) {
+	return EncodeContainerWithOptions(gpp, EncodeOptions{Lossless: true})
+}
+
+// filter returns a copy of the container with the sections whose ID is kept. Sections are matched by
+// SectionTypes, as a section which failed to decode may not hold its ID.
+func (gpp GppContainer) filter(keep func(id constants.SectionID) bool) GppContainer {
+	filtered := GppContainer{
+		Version:      gpp.Version,
+		header:       gpp.header,
+		SectionTypes: make([]constants.SectionID, 0, len(gpp.SectionTypes)),
+		Sections:     make([]Section, 0, len(gpp.Sections)),
+	}
+	for i, id := range gpp.SectionTypes {
+		if i >= len(gpp.Sections) || !keep(id) {
+			continue
+		}
+		filtered.SectionTypes = append(filtered.SectionTypes, id)
+		filtered.Sections = append(filtered.Sections, gpp.Sections[i])
+	}
+	return filtered
+}
+
+func sectionIDSet(ids []constants.SectionID) map[constants.SectionID]bool {
+	set := make(map[constants.SectionID]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
@@ -0,0 +1,103 @@
+package gpp
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+const testContainerString = "DBACNYA~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YNN"
+
+func TestGppContainerGet(t *testing.T) {
+	container, errs := Parse(testContainerString)
+	assert.Empty(t, errs)
+
+	section, ok := container.Get(constants.SectionUSPV1)
+	assert.True(t, ok)
+	assert.Equal(t, "1YNN", section.GetValue())
+
+	_, ok = container.Get(constants.SectionUSPCA)
+	assert.False(t, ok)
+}
+
+func TestGppContainerFilter(t *testing.T) {
+	container, errs := Parse(testContainerString)
+	assert.Empty(t, errs)
+
+	testData := []struct {
+		description   string
+		filtered      GppContainer
+		expectedTypes []constants.SectionID
+		expected      string
+	}{
+		{
+			description:   "should remove a section",
+			filtered:      container.Without(constants.SectionTCFEU2),
+			expectedTypes: []constants.SectionID{constants.SectionUSPV1},
+			expected:      "DBABTA~1YNN",
+		},
+		{
+			description:   "should ignore IDs the container does not have",
+			filtered:      container.Without(constants.SectionUSPCA),
+			expectedTypes: []constants.SectionID{constants.SectionTCFEU2, constants.SectionUSPV1},
+			expected:      "DBACNYA~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YNN",
+		},
+		{
+			description:   "should keep only the given sections",
+			filtered:      container.Only(constants.SectionTCFEU2, constants.SectionUSPCA),
+			expectedTypes: []constants.SectionID{constants.SectionTCFEU2},
+			expected:      "DBABMA~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA",
+		},
+		{
+			description:   "should remove every section",
+			filtered:      container.Only(),
+			expectedTypes: []constants.SectionID{},
+			expected:      "DBAA",
+		},
+	}
+
+	for _, test := range testData {
+		assert.Equal(t, test.expectedTypes, test.filtered.SectionTypes, test.description)
+		assert.Len(t, test.filtered.Sections, len(test.expectedTypes), test.description)
+		encoded, err := test.filtered.Encode()
+		assert.NoError(t, err, test.description)
+		assert.Equal(t, test.expected, encoded, test.description)
+
+		parsed, errs := Parse(encoded)
+		assert.Empty(t, errs, test.description)
+		assert.Equal(t, test.expectedTypes, parsed.SectionTypes, test.description)
+	}
+
+	// The original container is left as it was.
+	assert.Equal(t, []constants.SectionID{constants.SectionTCFEU2, constants.SectionUSPV1}, container.SectionTypes)
+	encoded, err := container.Encode()
+	assert.NoError(t, err)
+	assert.Equal(t, testContainerString, encoded)
+}
+
+func TestGppContainerEncode(t *testing.T) {
+	// A new header would be "DBABBg", without the padding character.
+	container, errs := Parse("DBABBgA~BVoYYZoI.QA")
+	assert.Empty(t, errs)
+
+	encoded, err := container.Encode()
+	assert.NoError(t, err)
+	assert.Equal(t, "DBABBgA~BVoYYZoI.QA", encoded)
+
+	encoded, err = container.Without(constants.SectionUSPCA).Encode()
+	assert.NoError(t, err)
+	assert.Equal(t, "DBAA", encoded)
+}
+
+func TestGppContainerFilterFailedSection(t *testing.T) {
+	// The uspv1 section fails to decode, so it does not hold its ID.
+	container, errs := Parse("DBACNYA~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YXN")
+	assert.Len(t, errs, 1)
+	_, err := container.Encode()
+	assert.Error(t, err)
+
+	encoded, err := container.Without(constants.SectionUSPV1).Encode()
+	assert.NoError(t, err)
+	assert.Equal(t, "DBABMA~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA", encoded)
+}
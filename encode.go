@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
 	"github.com/prebid/go-gpp/util"
+	"reflect"
 	"sort"
 	"strings"
 )
@@ -21,18 +23,110 @@ const (
 var (
 	sectionIdOutOfRangeErr = errors.New("section ID out of range")
 	duplicatedSectionErr   = errors.New("duplicated sections")
+	nilSectionErr          = errors.New("nil section")
+	// ErrSectionOrder is returned when EncodeOptions.RejectUnsorted is set and the sections are not in
+	// ascending ID order, which is the only order the header can describe.
+	ErrSectionOrder = errors.New("sections are not in ascending ID order")
 )
 
+// EncodeOptions controls how EncodeWithOptions writes the sections.
+type EncodeOptions struct {
+	// Strict encodes the sections implementing StrictEncoder with EncodeStrict, see EncodeStrict.
+	Strict bool
+	// RejectUnsorted returns ErrSectionOrder for sections which are not in ascending ID order, instead of
+	// sorting them. Sorted sections are written as they are either way.
+	RejectUnsorted bool
+	// Lossless writes the sections which have not been changed since they were decoded as their original
+	// GetValue text, so that padding, an absent GPC segment and any other detail of the original encoding
+	// is kept. A section is unchanged when decoding its value gives back the section as it is. Changed
+	// sections are encoded again, with a GPC segment only if their original value had one or GPC is set.
+	// EncodeContainerWithOptions also keeps the header of a parsed container while it lists the IDs of
+	// the sections; otherwise the header is written anew.
+	Lossless bool
+}
+
+// Encode writes the sections, sorted by ID, as a GPP string. The slice passed in is not modified.
 func Encode(sections []Section) (string, error) {
+	return EncodeWithOptions(sections, EncodeOptions{})
+}
+
+// EncodeStrict is Encode, but the sections implementing StrictEncoder are encoded with EncodeStrict, so a
+// field which does not fit its section, such as an opt-out of 7 or a SensitiveDataProcessing array of the
+// wrong length, is returned as an error instead of being written as is.
+func EncodeStrict(sections []Section) (string, error) {
+	return EncodeWithOptions(sections, EncodeOptions{Strict: true})
+}
+
+// EncodeContainer writes the sections of a container as a GPP string. Unlike Encode it checks that the
+// SectionTypes of the container are the IDs of its Sections, in the same order, so a container whose two
+// slices have drifted apart is reported rather than encoded from Sections alone.
+func EncodeContainer(container GppContainer) (string, error) {
+	return EncodeContainerWithOptions(container, EncodeOptions{})
+}
+
+// EncodeContainerWithOptions is EncodeContainer with the behaviour set by opts. With Lossless, the header
+// text of a container returned by Parse is written as it was while it gives the version and section IDs
+// the encoder would write, so a string whose sections have not changed is encoded byte for byte.
+func EncodeContainerWithOptions(container GppContainer, opts EncodeOptions) (string, error) {
+	if err := container.checkSections(); err != nil {
+		return "", err
+	}
+	header := ""
+	if opts.Lossless {
+		header = container.header
+	}
+	return encodeSections(container.Sections, opts, header)
+}
+
+// checkSections returns an error if the SectionTypes of the container are not the IDs of its Sections.
+func (gpp GppContainer) checkSections() error {
+	if len(gpp.SectionTypes) != len(gpp.Sections) {
+		return fmt.Errorf("container has %d section types and %d sections", len(gpp.SectionTypes), len(gpp.Sections))
+	}
+	for i, section := range gpp.Sections {
+		if section == nil {
+			return fmt.Errorf("section %d: %w", i, nilSectionErr)
+		}
+		if section.GetID() != gpp.SectionTypes[i] {
+			return fmt.Errorf("section %d has ID %d, but section type %d", i, section.GetID(), gpp.SectionTypes[i])
+		}
+	}
+	return nil
+}
+
+// EncodeWithOptions is Encode with the behaviour set by opts.
+func EncodeWithOptions(sections []Section, opts EncodeOptions) (string, error) {
+	return encodeSections(sections, opts, "")
+}
+
+// encodeSections writes the sections as EncodeWithOptions does. A non-empty header is written instead of
+// a new one if it holds the same version and section IDs.
+func encodeSections(sections []Section, opts EncodeOptions, header string) (string, error) {
 	bs := util.NewBitStreamForWrite()
 	builder := strings.Builder{}
 
 	bs.WriteByte6(gppType)
 	bs.WriteByte6(gppVersion)
 
-	sort.Slice(sections, func(i, j int) bool {
+	for i, section := range sections {
+		if section == nil {
+			return "", fmt.Errorf("section %d: %w", i, nilSectionErr)
+		}
+	}
+
+	// Sort a copy, as the caller may be iterating the slice alongside another, e.g. GppContainer.SectionTypes.
+	sections = append([]Section(nil), sections...)
+	inOrder := sort.SliceIsSorted(sections, func(i, j int) bool {
 		return sections[i].GetID() < sections[j].GetID()
 	})
+	if !inOrder {
+		if opts.RejectUnsorted {
+			return "", ErrSectionOrder
+		}
+		sort.SliceStable(sections, func(i, j int) bool {
+			return sections[i].GetID() < sections[j].GetID()
+		})
+	}
 
 	if len(sections) > 0 && (sections[0].GetID() < minSectionId ||
 		sections[len(sections)-1].GetID() > maxSectionId) {
@@ -61,13 +155,80 @@ func Encode(sections []Section) (string, error) {
 		return "", fmt.Errorf("write int range error: %v", err)
 	}
 
-	builder.Write(bs.Base64Encode())
+	if describesSections(header, sections) {
+		builder.WriteString(header)
+	} else {
+		builder.Write(bs.Base64Encode())
+	}
 
 	for _, sec := range sections {
 		builder.WriteByte('~')
+		if opts.Lossless && unchanged(sec) {
+			builder.WriteString(sec.GetValue())
+			continue
+		}
 		// By default, GPP is included.
-		builder.Write(sec.Encode(true))
+		gpcIncluded := !opts.Lossless || includeGPC(sec)
+		encoder, ok := sec.(StrictEncoder)
+		if !opts.Strict || !ok {
+			builder.Write(sec.Encode(gpcIncluded))
+			continue
+		}
+		encoded, err := encoder.EncodeStrict(gpcIncluded)
+		if err != nil {
+			return "", fmt.Errorf("error encoding %s section: %w", SectionName(sec.GetID()), err)
+		}
+		builder.Write(encoded)
 	}
 
 	return builder.String(), nil
 }
+
+// describesSections reports whether a header holds the version the encoder writes and the IDs of the
+// sorted sections.
+func describesSections(header string, sections []Section) bool {
+	if header == "" {
+		return false
+	}
+	version, ranges, err := parseHeader(header, false)
+	if err != nil || version != int(gppVersion) {
+		return false
+	}
+	ids, count := sectionIDs(ranges, len(sections))
+	if count != len(sections) {
+		return false
+	}
+	for i, id := range ids {
+		if sections[i].GetID() != id {
+			return false
+		}
+	}
+	return true
+}
+
+// unchanged reports whether a section is what its value decodes to, so that the value can be written as it
+// is.
+func unchanged(section Section) bool {
+	value := section.GetValue()
+	if value == "" {
+		return false
+	}
+	if _, ok := section.(GenericSection); ok {
+		return true
+	}
+	registration, ok := lookupSectionDecoder(section.GetID())
+	if !ok {
+		return false
+	}
+	decoded, err := registration.decode(value)
+	return err == nil && reflect.DeepEqual(decoded, section)
+}
+
+// includeGPC reports whether a changed section is encoded with a GPC segment: when its original value had
+// optional segments, or when the GPC signal is set and would otherwise be lost.
+func includeGPC(section Section) bool {
+	if privacy, ok := section.(sections.USPrivacySection); ok && privacy.GPC() {
+		return true
+	}
+	return section.GetValue() == "" || strings.Contains(section.GetValue(), ".")
+}
@@ -338,6 +338,7 @@ func TestEncode2(t *testing.T) {
 		"DBACLMA~BAAAAAAAAAA.QA~BaAAAAA",
 		"DBACTjw~1YYN~BSZZYgkA~BaRlkCSA.QA",
 		"DBACMYA~CPpcCoAPpcCoAPoABABGCyCUACAAACAAAAAAAVQAQAVABZABABYAAAAA.QADgIAAA.IABE~CPpcCoAPpcCoAPoABABGCyCQAEAAAEAAAAEFABAEEAN8AEAN4A.YAAAAAAAAAA",
+		"DBAEAxmj~BZYYYUlY~BmkYYWZA.QA~BWWGGGWA.YA~BmlhhqZA.YA",
 	}
 	for _, s := range gppStrings {
 		container, errs := Parse(s)
@@ -352,6 +353,187 @@ func TestEncode2(t *testing.T) {
 	}
 }
 
+func TestEncodeStrict(t *testing.T) {
+	nat, err := uspnat.Builder().SaleOptOut(sections.OptedOut).Build()
+	assert.Nil(t, err)
+	tooWide := nat
+	tooWide.CoreSegment.SaleOptOut = 7
+	tooShort := nat
+	tooShort.CoreSegment.SensitiveDataProcessing = []byte{0, 0, 0}
+
+	testData := []struct {
+		description string
+		sections    []Section
+		expected    string
+		expectedErr string
+		sentinel    error
+	}{
+		{
+			description: "should encode valid sections as Encode does",
+			sections:    []Section{nat, GenericSection{sectionID: 2, value: "abc"}},
+			expected:    "DBACMMA~abc~" + nat.GetValue(),
+		},
+		{
+			description: "should reject a value too wide for its field",
+			sections:    []Section{tooWide},
+			expectedErr: "error encoding uspnat section: unable to encode field CoreSegment.SaleOptOut: value 7 does not fit in 2 bits",
+			sentinel:    sections.ErrFieldWidth,
+		},
+		{
+			description: "should reject an array of the wrong length",
+			sections:    []Section{tooShort},
+			expectedErr: "error encoding uspnat section: unable to encode field CoreSegment.SensitiveDataProcessing: has 3 values, should have 12",
+			sentinel:    sections.ErrFieldLength,
+		},
+	}
+
+	for _, test := range testData {
+		result, err := EncodeStrict(test.sections)
+		if test.expectedErr != "" {
+			assert.EqualError(t, err, test.expectedErr, test.description)
+			assert.ErrorIs(t, err, test.sentinel, test.description)
+			continue
+		}
+		assert.Nil(t, err, test.description)
+		assert.Equal(t, test.expected, result, test.description)
+
+		lenient, err := Encode(test.sections)
+		assert.Nil(t, err, test.description)
+		assert.Equal(t, lenient, result, test.description)
+	}
+}
+
+func TestEncodeDoesNotModifySections(t *testing.T) {
+	container, errs := Parse("DBACNY~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YNN")
+	assert.Empty(t, errs)
+	reversed := []Section{container.Sections[1], container.Sections[0]}
+
+	result, err := Encode(reversed)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "DBACNYA~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YNN", result)
+	assert.Equal(t, constants.SectionUSPV1, reversed[0].GetID())
+	assert.Equal(t, constants.SectionTCFEU2, reversed[1].GetID())
+}
+
+func TestEncodeWithOptionsRejectUnsorted(t *testing.T) {
+	usp := GenericSection{sectionID: constants.SectionUSPV1, value: "1YNN"}
+	va := GenericSection{sectionID: constants.SectionUSPVA, value: "bSFgmiU"}
+
+	result, err := EncodeWithOptions([]Section{usp, va}, EncodeOptions{RejectUnsorted: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "DBACTGA~1YNN~bSFgmiU", result)
+
+	_, err = EncodeWithOptions([]Section{va, usp}, EncodeOptions{RejectUnsorted: true})
+	assert.Equal(t, ErrSectionOrder, err)
+
+	_, err = EncodeWithOptions([]Section{usp, usp}, EncodeOptions{RejectUnsorted: true})
+	assert.Equal(t, duplicatedSectionErr, err)
+}
+
+func TestEncodeContainer(t *testing.T) {
+	usp := GenericSection{sectionID: constants.SectionUSPV1, value: "1YNN"}
+	va := GenericSection{sectionID: constants.SectionUSPVA, value: "bSFgmiU"}
+
+	testData := []struct {
+		description string
+		container   GppContainer
+		expected    string
+		expectedErr string
+	}{
+		{
+			description: "should encode a consistent container",
+			container: GppContainer{
+				SectionTypes: []constants.SectionID{constants.SectionUSPVA, constants.SectionUSPV1},
+				Sections:     []Section{va, usp},
+			},
+			expected: "DBACTGA~1YNN~bSFgmiU",
+		},
+		{
+			description: "should reject a different number of section types",
+			container: GppContainer{
+				SectionTypes: []constants.SectionID{constants.SectionUSPV1},
+				Sections:     []Section{usp, va},
+			},
+			expectedErr: "container has 1 section types and 2 sections",
+		},
+		{
+			description: "should reject section types which do not match the sections",
+			container: GppContainer{
+				SectionTypes: []constants.SectionID{constants.SectionUSPV1, constants.SectionUSPVA},
+				Sections:     []Section{va, usp},
+			},
+			expectedErr: "section 0 has ID 9, but section type 6",
+		},
+		{
+			description: "should reject a nil section",
+			container: GppContainer{
+				SectionTypes: []constants.SectionID{constants.SectionUSPV1},
+				Sections:     []Section{nil},
+			},
+			expectedErr: "section 0: nil section",
+		},
+	}
+
+	for _, test := range testData {
+		result, err := EncodeContainer(test.container)
+		if test.expectedErr != "" {
+			assert.EqualError(t, err, test.expectedErr, test.description)
+			continue
+		}
+		assert.Nil(t, err, test.description)
+		assert.Equal(t, test.expected, result, test.description)
+		assert.Equal(t, constants.SectionUSPVA, test.container.Sections[0].GetID(), test.description)
+	}
+}
+
+func TestEncodeWithOptionsLossless(t *testing.T) {
+	// uspva has non-zero padding and uspnat no GPC segment, both of which Encode would change.
+	container, errs := Parse("DBACLMA~DSJgmkoZJSA~bSFgmiV")
+	assert.Empty(t, errs)
+
+	regenerated, err := Encode(container.Sections)
+	assert.Nil(t, err)
+	assert.Equal(t, "DBACLMA~DSJgmkoZJSA.QA~bSFgmiU", regenerated)
+
+	lossless, err := EncodeWithOptions(container.Sections, EncodeOptions{Lossless: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "DBACLMA~DSJgmkoZJSA~bSFgmiV", lossless)
+
+	nat := container.Sections[0].(uspnat.USPNAT)
+	nat.CoreSegment.SaleOptOut = 1
+	changed, err := EncodeWithOptions([]Section{nat, container.Sections[1]}, EncodeOptions{Lossless: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "DBACLMA~DSJQmkoZJSA~bSFgmiV", changed)
+
+	nat.GPCSegment.Gpc = true
+	changed, err = EncodeWithOptions([]Section{nat, container.Sections[1]}, EncodeOptions{Lossless: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "DBACLMA~DSJQmkoZJSA.YA~bSFgmiV", changed)
+}
+
+func TestEncodeContainerWithOptionsLossless(t *testing.T) {
+	// The header has a padding character which a new header would not have.
+	container, errs := Parse("DBABBgA~BVoYYZoI.QA")
+	assert.Empty(t, errs)
+
+	lossless, err := EncodeContainerWithOptions(container, EncodeOptions{Lossless: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "DBABBgA~BVoYYZoI.QA", lossless)
+
+	regenerated, err := EncodeContainer(container)
+	assert.Nil(t, err)
+	assert.Equal(t, "DBABBg~BVoYYZoI.QA", regenerated)
+
+	container, errs = Parse("DBACTGA~1YNN~bSFgmiU")
+	assert.Empty(t, errs)
+
+	// The header of the parsed string no longer describes the sections.
+	filtered, err := EncodeContainerWithOptions(container.Without(constants.SectionUSPV1), EncodeOptions{Lossless: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "DBABRg~bSFgmiU", filtered)
+}
+
 // go test -bench="^BenchmarkEncode$" -benchmem .
 // BenchmarkEncode-8         845827              1301 ns/op             472 B/op         27 allocs/op (Apple M1 Pro)
 func BenchmarkEncode(b *testing.B) {
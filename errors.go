@@ -0,0 +1,64 @@
+package gpp
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+)
+
+// HeaderError is returned by Parse when the GPP header cannot be decoded. No sections are decoded
+// in that case. Err is the underlying cause, if any.
+type HeaderError struct {
+	Reason string
+	Err    error
+}
+
+func (e *HeaderError) Error() string {
+	if e.Err == nil {
+		return "error parsing GPP header, " + e.Reason
+	}
+	return fmt.Sprintf("error parsing GPP header, %s: %s", e.Reason, e.Err)
+}
+
+func (e *HeaderError) Unwrap() error {
+	return e.Err
+}
+
+// SectionError is returned by Parse for each section which could not be decoded. Index is the position
+// of the section in the GPP string, not counting the header. Field is the name of the field which failed
+// to parse and BitOffset the position within its segment at which reading failed; they are empty and -1
+// respectively when the decoder does not report them.
+type SectionError struct {
+	SectionID constants.SectionID
+	Name      string
+	Index     int
+	Field     string
+	BitOffset int
+	Err       error
+}
+
+func newSectionError(id constants.SectionID, name string, index int, err error) *SectionError {
+	sectionErr := &SectionError{SectionID: id, Name: name, Index: index, BitOffset: -1, Err: err}
+
+	var fieldErr *sections.FieldError
+	if errors.As(err, &fieldErr) {
+		sectionErr.Field = fieldErr.Field
+	}
+	var readErr *util.ReadError
+	if errors.As(err, &readErr) {
+		sectionErr.BitOffset = int(readErr.BitOffset)
+	}
+
+	return sectionErr
+}
+
+func (e *SectionError) Error() string {
+	return fmt.Sprintf("error parsing %s consent string: %s", e.Name, e.Err)
+}
+
+func (e *SectionError) Unwrap() error {
+	return e.Err
+}
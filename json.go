@@ -0,0 +1,120 @@
+package gpp
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/prebid/go-gpp/constants"
+)
+
+type containerJSON struct {
+	Version      int                   `json:"version"`
+	Header       string                `json:"header,omitempty"`
+	SectionTypes []constants.SectionID `json:"section_types"`
+	Sections     []json.RawMessage     `json:"sections"`
+}
+
+// sectionHeaderJSON holds the fields every section has in JSON.
+type sectionHeaderJSON struct {
+	SectionID *constants.SectionID `json:"section_id"`
+	Value     string               `json:"value"`
+}
+
+// MarshalJSON writes the container with each section in its own JSON form, see
+// sections.MarshalJSONFields, and the header it was parsed from, if any.
+func (gpp GppContainer) MarshalJSON() ([]byte, error) {
+	container := containerJSON{
+		Version:      gpp.Version,
+		Header:       gpp.header,
+		SectionTypes: gpp.SectionTypes,
+		Sections:     make([]json.RawMessage, len(gpp.Sections)),
+	}
+	for i, section := range gpp.Sections {
+		data, err := json.Marshal(section)
+		if err != nil {
+			return nil, err
+		}
+		container.Sections[i] = data
+	}
+	return json.Marshal(container)
+}
+
+// UnmarshalJSON reads a container written by MarshalJSON. The built-in sections are read field by field,
+// so the result can be changed and turned back into a GPP string with Encode. A section registered with
+// RegisterSectionDecoder is decoded from its "value", and the rest of its JSON is then unmarshalled into
+// the decoded section, so its fields can be changed the same way. Sections with no registered decoder are
+// kept as a GenericSection.
+func (gpp *GppContainer) UnmarshalJSON(data []byte) error {
+	var container containerJSON
+	if err := json.Unmarshal(data, &container); err != nil {
+		return err
+	}
+
+	sections := make([]Section, len(container.Sections))
+	for i, raw := range container.Sections {
+		section, err := unmarshalSection(raw, container.SectionTypes, i)
+		if err != nil {
+			return err
+		}
+		sections[i] = section
+	}
+
+	*gpp = GppContainer{
+		Version:      container.Version,
+		SectionTypes: container.SectionTypes,
+		Sections:     sections,
+		header:       container.Header,
+	}
+	return nil
+}
+
+// unmarshalSection reads the section at index i. Its ID is taken from the section, or from the section
+// types of the container if the section has none.
+func unmarshalSection(data []byte, sectionTypes []constants.SectionID, i int) (Section, error) {
+	var header sectionHeaderJSON
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("error unmarshalling section %d: %w", i, err)
+	}
+
+	var id constants.SectionID
+	switch {
+	case header.SectionID != nil:
+		id = *header.SectionID
+	case i < len(sectionTypes):
+		id = sectionTypes[i]
+	default:
+		return nil, fmt.Errorf("error unmarshalling section %d: missing section_id", i)
+	}
+
+	registration, ok := lookupSectionDecoder(id)
+	if !ok {
+		return GenericSection{sectionID: id, value: header.Value}, nil
+	}
+
+	start := registration.zero
+	if start == nil {
+		decoded, err := registration.decode(header.Value)
+		if err != nil {
+			return nil, newSectionError(id, registration.name, i, err)
+		}
+		if decoded == nil {
+			return nil, nil
+		}
+		start = decoded
+	}
+
+	section := reflect.New(reflect.TypeOf(start))
+	section.Elem().Set(reflect.ValueOf(start))
+	if err := json.Unmarshal(data, section.Interface()); err != nil {
+		return nil, fmt.Errorf("error unmarshalling %s section: %w", registration.name, err)
+	}
+	return section.Elem().Interface().(Section), nil
+}
+
+func (gs GenericSection) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		SectionID constants.SectionID `json:"section_id"`
+		Value     string              `json:"value"`
+	}{gs.sectionID, gs.value})
+}
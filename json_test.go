@@ -0,0 +1,166 @@
+package gpp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections/uspca"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerJSONRoundTrip(t *testing.T) {
+	testData := []string{
+		"DBACNY~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YNN",
+		"DBABjw~CPpcCoAPpcCoAPoABABGCyCQAEAAAEAAAAEFABAEEAN8AEAN4A.YAAAAAAAAAA~1YNN",
+		"DBABrGA~DSJgmkoZJSA.YA~BlgWEYCY.QA~BSFgmiU~bSFgmJQ.YA~BWJYJllA~bSFgmSZQ.YA",
+		"DBABKYA~BAAAAAA",
+	}
+
+	for _, gppString := range testData {
+		t.Run(gppString, func(t *testing.T) {
+			container, errs := Parse(gppString)
+			assert.Nil(t, errs)
+
+			data, err := json.Marshal(container)
+			assert.NoError(t, err)
+
+			var result GppContainer
+			assert.NoError(t, json.Unmarshal(data, &result))
+			assert.Equal(t, container, result)
+		})
+	}
+}
+
+func TestContainerJSON(t *testing.T) {
+	container, errs := Parse("DBABBgA~xlgWEYCY.YA")
+	assert.Nil(t, errs)
+
+	data, err := json.Marshal(container)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"version": 1,
+		"header": "DBABBgA",
+		"section_types": [8],
+		"sections": [{
+			"section_id": 8,
+			"section": "uspca",
+			"value": "xlgWEYCY.YA",
+			"core_segment": {
+				"version": 49,
+				"sale_opt_out_notice": "not_provided",
+				"sharing_opt_out_notice": "provided",
+				"sensitive_data_limit_use_notice": "provided",
+				"sale_opt_out": "did_not_opt_out",
+				"sharing_opt_out": "not_applicable",
+				"sensitive_data_processing": [0, 1, 1, 2, 0, 1, 0, 1, 2],
+				"known_child_sensitive_data_consents": [0, 0],
+				"personal_data_consents": "not_applicable",
+				"mspa_covered_transaction": "no",
+				"mspa_opt_out_option_mode": "yes",
+				"mspa_service_provider_mode": "no"
+			},
+			"gpc_segment": {"subsection_type": 1, "gpc": true}
+		}]
+	}`, string(data))
+}
+
+// flagSection is a registered section with fields of its own, to test unmarshalling them from JSON.
+type flagSection struct {
+	SectionID constants.SectionID `json:"section_id"`
+	Value     string              `json:"value"`
+	Flag      bool                `json:"flag"`
+}
+
+func (fs flagSection) GetID() constants.SectionID {
+	return fs.SectionID
+}
+
+func (fs flagSection) GetValue() string {
+	return fs.Value
+}
+
+func (fs flagSection) Encode(bool) []byte {
+	if fs.Flag {
+		return []byte("1")
+	}
+	return []byte("0")
+}
+
+func TestContainerJSONRegisteredSection(t *testing.T) {
+	withRegistration(t, 28, "uspxx", func(s string) (Section, error) {
+		return flagSection{SectionID: 28, Value: s, Flag: s == "1"}, nil
+	})
+	data := `{"section_types": [28], "sections": [{"section_id": 28, "value": "0", "flag": true}]}`
+
+	var container GppContainer
+	assert.NoError(t, json.Unmarshal([]byte(data), &container))
+
+	assert.Equal(t, []Section{flagSection{SectionID: 28, Value: "0", Flag: true}}, container.Sections)
+	gppString, err := Encode(container.Sections)
+	assert.NoError(t, err)
+	assert.Equal(t, "DBABKYA~1", gppString)
+}
+
+func TestContainerJSONEncode(t *testing.T) {
+	data := `{
+		"version": 1,
+		"section_types": [6, 8],
+		"sections": [
+			{"section_id": 6, "version": 1, "notice": "Y", "opt_out_sale": "Y", "lspa_covered_transaction": "-"},
+			{
+				"section_id": 8,
+				"core_segment": {
+					"version": 1,
+					"sale_opt_out_notice": "provided",
+					"sale_opt_out": "opted_out",
+					"sensitive_data_processing": [0, 0, 0, 0, 0, 0, 0, 0, 0],
+					"known_child_sensitive_data_consents": [0, 0],
+					"mspa_covered_transaction": 2
+				},
+				"gpc_segment": {"subsection_type": 1, "gpc": false}
+			}
+		]
+	}`
+
+	var container GppContainer
+	assert.NoError(t, json.Unmarshal([]byte(data), &container))
+
+	gppString, err := Encode(container.Sections)
+	assert.NoError(t, err)
+
+	result, errs := Parse(gppString)
+	assert.Nil(t, errs)
+	assert.Equal(t, []constants.SectionID{constants.SectionUSPV1, constants.SectionUSPCA}, result.SectionTypes)
+	assert.Equal(t, "1YY-", result.Sections[0].GetValue())
+	assert.Equal(t, byte(1), result.Sections[1].(uspca.USPCA).CoreSegment.SaleOptOut)
+	assert.Equal(t, byte(2), result.Sections[1].(uspca.USPCA).CoreSegment.MspaCoveredTransaction)
+}
+
+func TestContainerJSONErrors(t *testing.T) {
+	testData := map[string]struct {
+		data string
+		err  string
+	}{
+		"invalid-enum": {
+			data: `{"section_types": [8], "sections": [{"section_id": 8, "core_segment": {"sale_opt_out": "maybe"}}]}`,
+			err:  "error unmarshalling uspca section: error unmarshalling field CoreSegment: error unmarshalling field SaleOptOut: invalid value \"maybe\"",
+		},
+		"invalid-uspv1-flag": {
+			data: `{"section_types": [6], "sections": [{"section_id": 6, "version": 1, "notice": "X", "opt_out_sale": "N", "lspa_covered_transaction": "N"}]}`,
+			err:  "error unmarshalling uspv1 section: unable to set field Notice due to parse error: invalid character 'X', should be one of 'Y', 'N' or '-'",
+		},
+		"missing-section-id": {
+			data: `{"sections": [{"value": "1YNN"}]}`,
+			err:  "error unmarshalling section 0: missing section_id",
+		},
+	}
+
+	for name, test := range testData {
+		t.Run(name, func(t *testing.T) {
+			var container GppContainer
+			assert.EqualError(t, json.Unmarshal([]byte(test.data), &container), test.err)
+		})
+	}
+}
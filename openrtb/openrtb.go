@@ -0,0 +1,232 @@
+// Package openrtb reads the GPP string and applicable sections of an OpenRTB 2.x bid request, and writes
+// them back, working on the raw JSON of the request so no OpenRTB library is needed.
+package openrtb
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	gpp "github.com/prebid/go-gpp"
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections/tcfeu2"
+	"github.com/prebid/go-gpp/sections/uspv1"
+)
+
+// Source is where the GPP string of a request was found.
+type Source string
+
+const (
+	SourceNone    Source = ""
+	SourceRegs    Source = "regs"
+	SourceRegsExt Source = "regs.ext"
+)
+
+// Signals holds the privacy fields of a bid request.
+type Signals struct {
+	// GPP is regs.gpp, or the legacy regs.ext.gpp if the request has no regs.gpp.
+	GPP string
+	// GPPSID is gpp_sid, taken from the same object as GPP.
+	GPPSID []constants.SectionID
+	// GPPSource is where GPP was found, or SourceNone if the request has no GPP string.
+	GPPSource Source
+	// USPrivacy is regs.us_privacy, or the legacy regs.ext.us_privacy.
+	USPrivacy string
+	// Consent is the TCF consent string in user.consent, or the legacy user.ext.consent.
+	Consent string
+}
+
+// Read returns the privacy fields of the JSON of a bid request.
+func Read(request []byte) (Signals, error) {
+	var signals Signals
+
+	root, err := readObject(request, "request")
+	if err != nil {
+		return signals, err
+	}
+	regs, err := childObject(root, "regs")
+	if err != nil {
+		return signals, err
+	}
+	regsExt, err := childObject(regs, "ext")
+	if err != nil {
+		return signals, fmt.Errorf("regs.%w", err)
+	}
+	user, err := childObject(root, "user")
+	if err != nil {
+		return signals, err
+	}
+	userExt, err := childObject(user, "ext")
+	if err != nil {
+		return signals, fmt.Errorf("user.%w", err)
+	}
+
+	fields := []struct {
+		name   string
+		object map[string]json.RawMessage
+		key    string
+		value  *string
+	}{
+		{"regs.us_privacy", regs, "us_privacy", &signals.USPrivacy},
+		{"regs.ext.us_privacy", regsExt, "us_privacy", &signals.USPrivacy},
+		{"user.consent", user, "consent", &signals.Consent},
+		{"user.ext.consent", userExt, "consent", &signals.Consent},
+	}
+	for _, field := range fields {
+		if *field.value != "" {
+			continue
+		}
+		if err := readString(field.object, field.key, field.value); err != nil {
+			return signals, fmt.Errorf("%s: %w", field.name, err)
+		}
+	}
+
+	for _, source := range []struct {
+		source Source
+		object map[string]json.RawMessage
+	}{
+		{SourceRegs, regs},
+		{SourceRegsExt, regsExt},
+	} {
+		if _, ok := source.object["gpp"]; !ok {
+			continue
+		}
+		if err := readString(source.object, "gpp", &signals.GPP); err != nil {
+			return signals, fmt.Errorf("%s.gpp: %w", source.source, err)
+		}
+		if signals.GPP == "" {
+			continue
+		}
+		signals.GPPSource = source.source
+		if raw, ok := source.object["gpp_sid"]; ok && string(raw) != "null" {
+			if err := json.Unmarshal(raw, &signals.GPPSID); err != nil {
+				return signals, fmt.Errorf("%s.gpp_sid: %w", source.source, err)
+			}
+		}
+		break
+	}
+
+	return signals, nil
+}
+
+// Parse reads the privacy fields of a bid request and parses its GPP string. If the request has no GPP
+// string, the container is made of the US Privacy and TCF consent strings instead, where present. Errors
+// reading the JSON are returned alone; otherwise the errors are those of parsing the strings.
+func Parse(request []byte) (gpp.GppContainer, Signals, []error) {
+	signals, err := Read(request)
+	if err != nil {
+		return gpp.GppContainer{}, signals, []error{err}
+	}
+	if signals.GPP != "" {
+		container, errs := gpp.Parse(signals.GPP)
+		return container, signals, errs
+	}
+
+	var sections []gpp.Section
+	var errs []error
+	if signals.Consent != "" {
+		tcf, err := tcfeu2.NewTCFEU2(signals.Consent)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error parsing user.consent: %w", err))
+		} else {
+			sections = append(sections, tcf)
+		}
+	}
+	if signals.USPrivacy != "" {
+		usp, err := uspv1.NewUSPV1(signals.USPrivacy)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error parsing regs.us_privacy: %w", err))
+		} else {
+			sections = append(sections, usp)
+		}
+	}
+
+	container := gpp.GppContainer{}
+	if len(sections) > 0 {
+		container.Version = 1
+	}
+	sort.Slice(sections, func(i, j int) bool { return sections[i].GetID() < sections[j].GetID() })
+	for _, section := range sections {
+		container.SectionTypes = append(container.SectionTypes, section.GetID())
+		container.Sections = append(container.Sections, section)
+	}
+	return container, signals, errs
+}
+
+// Write sets regs.gpp and regs.gpp_sid of a bid request, creating regs if needed, and returns the updated
+// JSON. The legacy regs.ext.gpp and regs.ext.gpp_sid are updated too if the request has them, so the two
+// cannot disagree. A nil gppSID removes gpp_sid. Other fields are kept, though the keys of the objects
+// written are sorted.
+func Write(request []byte, gppString string, gppSID []constants.SectionID) ([]byte, error) {
+	root, err := readObject(request, "request")
+	if err != nil {
+		return nil, err
+	}
+	regs, err := childObject(root, "regs")
+	if err != nil {
+		return nil, err
+	}
+	if regs == nil {
+		regs = make(map[string]json.RawMessage)
+	}
+	if err := setGPP(regs, gppString, gppSID); err != nil {
+		return nil, err
+	}
+
+	regsExt, err := childObject(regs, "ext")
+	if err != nil {
+		return nil, fmt.Errorf("regs.%w", err)
+	}
+	if _, ok := regsExt["gpp"]; ok {
+		if err := setGPP(regsExt, gppString, gppSID); err != nil {
+			return nil, err
+		}
+		if regs["ext"], err = json.Marshal(regsExt); err != nil {
+			return nil, err
+		}
+	}
+
+	if root["regs"], err = json.Marshal(regs); err != nil {
+		return nil, err
+	}
+	return json.Marshal(root)
+}
+
+func setGPP(object map[string]json.RawMessage, gppString string, gppSID []constants.SectionID) error {
+	var err error
+	if object["gpp"], err = json.Marshal(gppString); err != nil {
+		return err
+	}
+	if gppSID == nil {
+		delete(object, "gpp_sid")
+		return nil
+	}
+	object["gpp_sid"], err = json.Marshal(gppSID)
+	return err
+}
+
+func readObject(data []byte, name string) (map[string]json.RawMessage, error) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return object, nil
+}
+
+// childObject returns the object at a key of parent, or nil if there is none.
+func childObject(parent map[string]json.RawMessage, key string) (map[string]json.RawMessage, error) {
+	raw, ok := parent[key]
+	if !ok || string(raw) == "null" {
+		return nil, nil
+	}
+	return readObject(raw, key)
+}
+
+// readString sets value to the string at a key of object, if there is one.
+func readString(object map[string]json.RawMessage, key string, value *string) error {
+	raw, ok := object[key]
+	if !ok || string(raw) == "null" {
+		return nil
+	}
+	return json.Unmarshal(raw, value)
+}
@@ -0,0 +1,140 @@
+package openrtb
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRead(t *testing.T) {
+	testData := []struct {
+		description string
+		request     string
+		expected    Signals
+		expectedErr string
+	}{
+		{
+			description: "should read regs.gpp and regs.gpp_sid",
+			request:     `{"id":"1","regs":{"gpp":"DBABTA~1YNN","gpp_sid":[6],"ext":{"gpp":"DBABTA~1NNN"}}}`,
+			expected: Signals{
+				GPP:       "DBABTA~1YNN",
+				GPPSID:    []constants.SectionID{6},
+				GPPSource: SourceRegs,
+			},
+		},
+		{
+			description: "should fall back to regs.ext.gpp",
+			request:     `{"regs":{"ext":{"gpp":"DBABTA~1YNN","gpp_sid":[6]}}}`,
+			expected: Signals{
+				GPP:       "DBABTA~1YNN",
+				GPPSID:    []constants.SectionID{6},
+				GPPSource: SourceRegsExt,
+			},
+		},
+		{
+			description: "should read us_privacy and consent from the legacy ext objects",
+			request:     `{"regs":{"ext":{"us_privacy":"1YNN"}},"user":{"ext":{"consent":"CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA"}}}`,
+			expected: Signals{
+				USPrivacy: "1YNN",
+				Consent:   "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA",
+			},
+		},
+		{
+			description: "should prefer regs.us_privacy to regs.ext.us_privacy",
+			request:     `{"regs":{"us_privacy":"1YYN","ext":{"us_privacy":"1YNN"}}}`,
+			expected:    Signals{USPrivacy: "1YYN"},
+		},
+		{
+			description: "should accept a request without privacy fields",
+			request:     `{"id":"1","regs":null}`,
+		},
+		{
+			description: "should reject a gpp_sid which is not a list of IDs",
+			request:     `{"regs":{"gpp":"DBABTA~1YNN","gpp_sid":"6"}}`,
+			expectedErr: "regs.gpp_sid: json: cannot unmarshal string",
+		},
+		{
+			description: "should reject regs which is not an object",
+			request:     `{"regs":[]}`,
+			expectedErr: "regs: json: cannot unmarshal array",
+		},
+	}
+
+	for _, test := range testData {
+		signals, err := Read([]byte(test.request))
+		if test.expectedErr != "" {
+			assert.ErrorContains(t, err, test.expectedErr, test.description)
+			continue
+		}
+		assert.Nil(t, err, test.description)
+		assert.Equal(t, test.expected, signals, test.description)
+	}
+}
+
+func TestParse(t *testing.T) {
+	container, signals, errs := Parse([]byte(`{"regs":{"gpp":"DBABTA~1YNN","gpp_sid":[6]}}`))
+	assert.Empty(t, errs)
+	assert.Equal(t, SourceRegs, signals.GPPSource)
+	assert.Equal(t, []constants.SectionID{constants.SectionUSPV1}, container.SectionTypes)
+
+	container, _, errs = Parse([]byte(`{"regs":{"us_privacy":"1YNN"},"user":{"consent":"CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA"}}`))
+	assert.Empty(t, errs)
+	assert.Equal(t, 1, container.Version)
+	assert.Equal(t, []constants.SectionID{constants.SectionTCFEU2, constants.SectionUSPV1}, container.SectionTypes)
+	encoded, err := container.Encode()
+	assert.NoError(t, err)
+	assert.Equal(t, "DBACNYA~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YNN", encoded)
+
+	container, _, errs = Parse([]byte(`{"regs":{"us_privacy":"1YXN"}}`))
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "error parsing regs.us_privacy: unable to set field OptOutSale due to parse error: invalid character 'X', should be one of 'Y', 'N' or '-'")
+	assert.Empty(t, container.Sections)
+
+	_, _, errs = Parse([]byte(`{`))
+	assert.Len(t, errs, 1)
+}
+
+func TestWrite(t *testing.T) {
+	testData := []struct {
+		description string
+		request     string
+		gppSID      []constants.SectionID
+		expected    string
+	}{
+		{
+			description: "should add regs",
+			request:     `{"id":"1"}`,
+			gppSID:      []constants.SectionID{6},
+			expected:    `{"id":"1","regs":{"gpp":"DBABTA~1YNN","gpp_sid":[6]}}`,
+		},
+		{
+			description: "should replace the GPP fields and keep the others",
+			request:     `{"id":"1","regs":{"coppa":1,"gpp":"DBABTA~1NNN","gpp_sid":[2,6]}}`,
+			gppSID:      []constants.SectionID{6},
+			expected:    `{"id":"1","regs":{"coppa":1,"gpp":"DBABTA~1YNN","gpp_sid":[6]}}`,
+		},
+		{
+			description: "should update the legacy ext fields when present",
+			request:     `{"regs":{"ext":{"gpp":"DBABTA~1NNN","gpp_sid":[6],"gdpr":0}}}`,
+			gppSID:      []constants.SectionID{6},
+			expected:    `{"regs":{"ext":{"gdpr":0,"gpp":"DBABTA~1YNN","gpp_sid":[6]},"gpp":"DBABTA~1YNN","gpp_sid":[6]}}`,
+		},
+		{
+			description: "should remove gpp_sid for a nil list",
+			request:     `{"regs":{"gpp":"DBABTA~1NNN","gpp_sid":[6]}}`,
+			expected:    `{"regs":{"gpp":"DBABTA~1YNN"}}`,
+		},
+	}
+
+	for _, test := range testData {
+		result, err := Write([]byte(test.request), "DBABTA~1YNN", test.gppSID)
+		assert.Nil(t, err, test.description)
+		assert.JSONEq(t, test.expected, string(result), test.description)
+
+		signals, err := Read(result)
+		assert.Nil(t, err, test.description)
+		assert.Equal(t, "DBABTA~1YNN", signals.GPP, test.description)
+		assert.Equal(t, test.gppSID, signals.GPPSID, test.description)
+	}
+}
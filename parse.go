@@ -1,16 +1,11 @@
 package gpp
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/prebid/go-gpp/constants"
-	"github.com/prebid/go-gpp/sections/uspca"
-	"github.com/prebid/go-gpp/sections/uspco"
-	"github.com/prebid/go-gpp/sections/uspct"
-	"github.com/prebid/go-gpp/sections/uspnat"
-	"github.com/prebid/go-gpp/sections/usput"
-	"github.com/prebid/go-gpp/sections/uspva"
 	"github.com/prebid/go-gpp/util"
 )
 
@@ -23,6 +18,9 @@ type GppContainer struct {
 	Version      int
 	SectionTypes []constants.SectionID
 	Sections     []Section
+	// header is the header text the container was parsed from, which EncodeContainerWithOptions writes
+	// back as it is when it still describes the sections.
+	header string
 }
 
 type Section interface {
@@ -31,94 +29,154 @@ type Section interface {
 	Encode(bool) []byte
 }
 
+// StrictEncoder is implemented by the sections which can check their fields while encoding, returning an
+// error for a value which does not fit instead of writing it truncated. All the built-in sections
+// implement it.
+type StrictEncoder interface {
+	EncodeStrict(gpcIncluded bool) ([]byte, error)
+}
+
+// Options controls how ParseWithOptions treats malformed GPP strings. The zero value gives the behaviour
+// of Parse: sections which fail to decode are reported and skipped over, while any problem with the
+// header aborts the parse.
+type Options struct {
+	// FailFast stops at the first section which fails to decode. Only that error is returned, and the
+	// container holds the sections up to and including the failed one.
+	FailFast bool
+	// SkipUnknownSections leaves sections without a registered decoder out of the container, instead of
+	// returning them as a GenericSection.
+	SkipUnknownSections bool
+	// AllowTrailingSeparator ignores '~' separators at the end of the string.
+	AllowTrailingSeparator bool
+	// TruncateOnCountMismatch decodes as many sections as there are both header IDs and encoded sections
+	// when the two counts differ, instead of failing with a HeaderError.
+	TruncateOnCountMismatch bool
+	// RejectNonZeroPadding reports an error wrapping ErrNonZeroPadding for the header or any section with
+	// set bits after its last field. Only the sections implementing PaddingChecker are checked.
+	RejectNonZeroPadding bool
+}
+
+// ErrNonZeroPadding is returned, wrapped in a HeaderError or SectionError, when the unused bits at the
+// end of a segment are not all zero and Options.RejectNonZeroPadding is set.
+var ErrNonZeroPadding = errors.New("non-zero padding bits")
+
 func Parse(v string) (GppContainer, []error) {
+	return ParseWithOptions(v, Options{})
+}
+
+// ParseWithOptions parses a GPP string as Parse does, with the leniency set by opts.
+func ParseWithOptions(v string, opts Options) (GppContainer, []error) {
 	var gpp GppContainer
 
+	if opts.AllowTrailingSeparator {
+		v = strings.TrimRight(v, "~")
+	}
 	sectionStrings := strings.Split(v, "~")
 
 	header := sectionStrings[0]
-	if err := failFastHeaderValidate(header); err != nil {
+	version, secRanges, err := parseHeader(header, opts.RejectNonZeroPadding)
+	if err != nil {
 		return gpp, []error{err}
 	}
+	gpp.Version = version
+	gpp.header = header
+
+	// We do not count the GPP header as a section
+	secCount := len(sectionStrings) - 1
+	secIDs, idCount := sectionIDs(secRanges, secCount)
+	if idCount != secCount && !opts.TruncateOnCountMismatch {
+		return gpp, []error{&HeaderError{Reason: fmt.Sprintf("section IDs do not match the number of sections: found %d IDs, have %d sections", idCount, secCount)}}
+	}
+
+	gpp.SectionTypes = make([]constants.SectionID, 0, len(secIDs))
+	gpp.Sections = make([]Section, 0, len(secIDs))
+	var errs []error
+	for i, id := range secIDs {
+		registration, ok := lookupSectionDecoder(id)
+		if !ok {
+			if !opts.SkipUnknownSections {
+				gpp.SectionTypes = append(gpp.SectionTypes, id)
+				gpp.Sections = append(gpp.Sections, GenericSection{sectionID: id, value: sectionStrings[i+1]})
+			}
+			continue
+		}
+
+		section, err := registration.decode(sectionStrings[i+1])
+		if err == nil && opts.RejectNonZeroPadding && hasNonZeroPadding(section) {
+			err = ErrNonZeroPadding
+		}
+		gpp.SectionTypes = append(gpp.SectionTypes, id)
+		gpp.Sections = append(gpp.Sections, section)
+		if err != nil {
+			errs = append(errs, newSectionError(id, registration.name, i, err))
+			if opts.FailFast {
+				break
+			}
+		}
+	}
+
+	return gpp, errs
+}
+
+// parseHeader returns the version and the ranges of section IDs held by a GPP header.
+func parseHeader(header string, rejectNonZeroPadding bool) (int, []util.IRange, error) {
+	if err := failFastHeaderValidate(header); err != nil {
+		return 0, nil, err
+	}
 
 	bs, err := util.NewBitStreamFromBase64(header)
 	if err != nil {
-		return gpp, []error{fmt.Errorf("error parsing GPP header, base64 decoding: %s", err)}
+		return 0, nil, &HeaderError{Reason: "base64 decoding", Err: err}
 	}
 
-	// We checked the GPP header type above outside of the bitstream framework, so we advance the bit stream past the first 6 bits.
+	// We checked the GPP header type above outside of the bit stream framework, so we advance the bit stream past the first 6 bits.
 	bs.SetPosition(6)
 
 	ver, err := bs.ReadByte6()
 	if err != nil {
-		return gpp, []error{fmt.Errorf("error parsing GPP header, unable to parse GPP version: %s", err)}
+		return 0, nil, &HeaderError{Reason: "unable to parse GPP version", Err: err}
 	}
-	gpp.Version = int(ver)
 
 	intRange, err := bs.ReadFibonacciRange()
 	if err != nil {
-		return gpp, []error{fmt.Errorf("error parsing GPP header, section identifiers: %s", err)}
+		return 0, nil, &HeaderError{Reason: "section identifiers", Err: err}
+	}
+	if rejectNonZeroPadding && bs.HasNonZeroRemainder() {
+		return 0, nil, &HeaderError{Reason: "section identifiers", Err: ErrNonZeroPadding}
 	}
 
-	// We do not count the GPP header as a section
-	secCount := len(sectionStrings) - 1
-	secIDs := make([]constants.SectionID, 0, secCount)
+	return int(ver), intRange.Range, nil
+}
 
-	for _, sec := range intRange.Range {
-		for i := sec.StartID; i <= sec.EndID; i++ {
-			secIDs = append(secIDs, constants.SectionID(i))
+// sectionIDs returns the section IDs of the ranges of a GPP header in order, up to limit of them, and the
+// number of IDs the ranges hold in all. IDs past the limit are counted without being listed, so a header of
+// many long ranges cannot make the parser list more IDs than there are sections.
+func sectionIDs(ranges []util.IRange, limit int) ([]constants.SectionID, int) {
+	var ids []constants.SectionID
+	count := 0
+	for _, r := range ranges {
+		if r.EndID < r.StartID {
+			continue
 		}
-	}
-	if len(secIDs) != secCount {
-		return gpp, []error{fmt.Errorf("error parsing GPP header, section IDs do not match the number of sections: found %d IDs, have %d sections", len(secIDs), secCount)}
-	}
-	gpp.SectionTypes = secIDs
-
-	sections := make([]Section, secCount)
-	var errs []error
-	for i, id := range secIDs {
-		switch id {
-		case constants.SectionUSPNAT:
-			sections[i], err = uspnat.NewUSPNAT(sectionStrings[i+1])
-			if err != nil {
-				errs = append(errs, fmt.Errorf("error parsing %s consent string: %s", constants.SectionNamesByID[int(id)], err))
-			}
-		case constants.SectionUSPCA:
-			sections[i], err = uspca.NewUSPCA(sectionStrings[i+1])
-			if err != nil {
-				errs = append(errs, fmt.Errorf("error parsing %s consent string: %s", constants.SectionNamesByID[int(id)], err))
-			}
-		case constants.SectionUSPVA:
-			sections[i], err = uspva.NewUSPVA(sectionStrings[i+1])
-			if err != nil {
-				errs = append(errs, fmt.Errorf("error parsing %s consent string: %s", constants.SectionNamesByID[int(id)], err))
-			}
-		case constants.SectionUSPCO:
-			sections[i], err = uspco.NewUSPCO(sectionStrings[i+1])
-			if err != nil {
-				errs = append(errs, fmt.Errorf("error parsing %s consent string: %s", constants.SectionNamesByID[int(id)], err))
-			}
-		case constants.SectionUSPUT:
-			sections[i], err = usput.NewUSPUT(sectionStrings[i+1])
-			if err != nil {
-				errs = append(errs, fmt.Errorf("error parsing %s consent string: %s", constants.SectionNamesByID[int(id)], err))
-			}
-		case constants.SectionUSPCT:
-			sections[i], err = uspct.NewUSPCT(sectionStrings[i+1])
-			if err != nil {
-				errs = append(errs, fmt.Errorf("error parsing %s consent string: %s", constants.SectionNamesByID[int(id)], err))
-			}
-		default:
-			sections[i] = GenericSection{sectionID: id, value: sectionStrings[i+1]}
-			if err != nil {
-				errs = append(errs, fmt.Errorf("error parsing unsupported (section %d) consent string: %s", int(id), err))
-			}
+		for id := int(r.StartID); id <= int(r.EndID) && len(ids) < limit; id++ {
+			ids = append(ids, constants.SectionID(id))
 		}
+		count += int(r.EndID) - int(r.StartID) + 1
 	}
+	return ids, count
+}
 
-	gpp.Sections = sections
+// PaddingChecker is implemented by the sections which can tell whether any bit after the last field read
+// from one of their segments is set. All the built-in base64 sections implement it, and
+// Options.RejectNonZeroPadding skips the sections which do not.
+type PaddingChecker interface {
+	HasNonZeroPadding() bool
+}
 
-	return gpp, errs
+// hasNonZeroPadding reports whether a decoded section has set bits after the last field of a segment.
+func hasNonZeroPadding(section Section) bool {
+	checker, ok := section.(PaddingChecker)
+	return ok && checker.HasNonZeroPadding()
 }
 
 // failFastHeaderValidate performs quick validations of the header section before decoding
@@ -127,13 +185,13 @@ func failFastHeaderValidate(h string) error {
 	// the GPP header must be at least 24 bits to represent the type, version, and a fibonacci sequence
 	// of at least 1 item. this requires at least 4 characters.
 	if len(h) < MinHeaderCharacters {
-		return fmt.Errorf("error parsing GPP header, should be at least %d bytes long", MinHeaderCharacters)
+		return &HeaderError{Reason: fmt.Sprintf("should be at least %d bytes long", MinHeaderCharacters)}
 	}
 
 	// base64-url encodes 6 bits into each character. the first 6 bits of GPP header must always
 	// evaluate to the integer '3', so we can short cut by checking the first character directly.
 	if h[0] != SectionGPPByte {
-		return fmt.Errorf("error parsing GPP header, header must have type=%d", constants.SectionGPP)
+		return &HeaderError{Reason: fmt.Sprintf("header must have type=%d", constants.SectionGPP)}
 	}
 
 	return nil
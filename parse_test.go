@@ -1,16 +1,105 @@
 package gpp
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/prebid/go-gpp/constants"
 	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/sections/tcfcav1"
+	"github.com/prebid/go-gpp/sections/tcfeu2"
 	"github.com/prebid/go-gpp/sections/uspca"
+	"github.com/prebid/go-gpp/sections/uspv1"
 	"github.com/prebid/go-gpp/sections/uspva"
+	"github.com/prebid/go-gpp/util"
 	"github.com/stretchr/testify/assert"
 )
 
+// testTCFEU2 is the decoded form of the TCF EU v2 consent string used across the parse tests.
+var testTCFEU2 = tcfeu2.TCFEU2{
+	SectionID: constants.SectionTCFEU2,
+	Value:     "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA",
+	CoreSegment: tcfeu2.TCFEU2CoreSegment{
+		Version:                    2,
+		Created:                    time.Date(2022, time.April, 20, 22, 0, 0, 0, time.UTC),
+		LastUpdated:                time.Date(2022, time.April, 20, 22, 0, 0, 0, time.UTC),
+		CmpID:                      31,
+		CmpVersion:                 640,
+		ConsentScreen:              1,
+		ConsentLanguage:            "EN",
+		VendorListVersion:          126,
+		TcfPolicyVersion:           2,
+		IsServiceSpecific:          true,
+		UseNonStandardTexts:        false,
+		SpecialFeatureOptIns:       bitField(12),
+		PurposeConsents:            bitField(24),
+		PurposeLegitimateInterests: bitField(24),
+		PurposeOneTreatment:        false,
+		PublisherCC:                "DE",
+		VendorConsents:             sections.OptimizedRange{BitField: []bool{}},
+		VendorLegitimateInterests:  sections.OptimizedRange{BitField: []bool{}},
+		PublisherRestrictions:      []tcfeu2.PublisherRestriction{},
+	},
+}
+
+// testTCFCAV1 is the decoded form of the TCF CA v1 consent string used across the parse tests.
+var testTCFCAV1 = tcfcav1.TCFCAV1{
+	SectionID: constants.SectionTCFCAV1,
+	Value:     "CPpcCoAPpcCoAPoABABGCyCQAEAAAEAAAAEFABAEEAN8AEAN4A.YAAAAAAAAAA",
+	CoreSegment: tcfcav1.TCFCAV1CoreSegment{
+		Version:                      2,
+		Created:                      time.Date(2023, time.March, 30, 0, 0, 0, 0, time.UTC),
+		LastUpdated:                  time.Date(2023, time.March, 30, 0, 0, 0, 0, time.UTC),
+		CmpID:                        1000,
+		CmpVersion:                   1,
+		ConsentScreen:                0,
+		ConsentLanguage:              "BG",
+		VendorListVersion:            178,
+		TcfPolicyVersion:             2,
+		UseNonStandardTexts:          false,
+		SpecialFeatureExpressConsent: bitField(12, 1),
+		PurposesExpressConsent:       bitField(24, 3),
+		PurposesImpliedConsent:       bitField(24, 3),
+		VendorExpressConsent: sections.OptimizedRange{
+			MaxID:           130,
+			IsRangeEncoding: true,
+			Range:           &util.IntRange{Size: 1, Range: []util.IRange{{StartID: 130, EndID: 130}}, Max: 130},
+		},
+		VendorImpliedConsent: sections.OptimizedRange{
+			MaxID:           111,
+			IsRangeEncoding: true,
+			Range:           &util.IntRange{Size: 1, Range: []util.IRange{{StartID: 111, EndID: 111}}, Max: 111},
+		},
+	},
+	PublisherPurposesSegment: &tcfcav1.TCFCAV1PublisherPurposesSegment{
+		SegmentType:                  tcfcav1.SegmentTypePublisherPurposes,
+		PubPurposesExpressConsent:    bitField(24),
+		PubPurposesImpliedConsent:    bitField(24),
+		CustomPurposesExpressConsent: []bool{},
+		CustomPurposesImpliedConsent: []bool{},
+	},
+}
+
+// bitField builds a bitfield of the given size with the 1-based positions in set turned on.
+func bitField(size int, set ...int) []bool {
+	field := make([]bool, size)
+	for _, i := range set {
+		field[i-1] = true
+	}
+	return field
+}
+
+var testUSPV1 = uspv1.USPV1{
+	SectionID:              constants.SectionUSPV1,
+	Value:                  "1YNN",
+	Version:                1,
+	Notice:                 uspv1.Yes,
+	OptOutSale:             uspv1.No,
+	LSPACoveredTransaction: uspv1.No,
+}
+
 type gppTestData struct {
 	description   string
 	gppString     string
@@ -25,9 +114,9 @@ func TestParse(t *testing.T) {
 			gppString:   "DBABM~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA",
 			expected: GppContainer{
 				Version:      1,
+				header:       "DBABM",
 				SectionTypes: []constants.SectionID{2},
-				Sections: []Section{GenericSection{sectionID: 2,
-					value: "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA"}},
+				Sections:     []Section{testTCFEU2},
 			},
 		},
 		"gpp-tcf-valid-quantum": { // header is valid base64 quantum, should gracefully decode correctly
@@ -35,9 +124,9 @@ func TestParse(t *testing.T) {
 			gppString:   "DBABMA~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA",
 			expected: GppContainer{
 				Version:      1,
+				header:       "DBABMA",
 				SectionTypes: []constants.SectionID{2},
-				Sections: []Section{GenericSection{sectionID: 2,
-					value: "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA"}},
+				Sections:     []Section{testTCFEU2},
 			},
 		},
 		"gpp-tcf-usp": {
@@ -45,23 +134,20 @@ func TestParse(t *testing.T) {
 			gppString:   "DBACNY~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YNN",
 			expected: GppContainer{
 				Version:      1,
+				header:       "DBACNY",
 				SectionTypes: []constants.SectionID{2, 6},
-				Sections: []Section{GenericSection{sectionID: 2,
-					value: "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA"},
-					GenericSection{sectionID: 6,
-						value: "1YNN"}},
+				Sections: []Section{testTCFEU2,
+					testUSPV1},
 			},
 		},
 		"gpp-tcfca-usp": {
 			description: "GPP string with Canadian TCF and US Privacy",
-			gppString:   "DBABjw~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA~1YNN",
+			gppString:   "DBABjw~CPpcCoAPpcCoAPoABABGCyCQAEAAAEAAAAEFABAEEAN8AEAN4A.YAAAAAAAAAA~1YNN",
 			expected: GppContainer{
 				Version:      1,
+				header:       "DBABjw",
 				SectionTypes: []constants.SectionID{5, 6},
-				Sections: []Section{GenericSection{sectionID: 5,
-					value: "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA"},
-					GenericSection{sectionID: 6,
-						value: "1YNN"}},
+				Sections:     []Section{testTCFCAV1, testUSPV1},
 			},
 		},
 		"gpp-uspca": {
@@ -69,6 +155,7 @@ func TestParse(t *testing.T) {
 			gppString:   "DBABBgA~xlgWEYCZAA",
 			expected: GppContainer{
 				Version:      1,
+				header:       "DBABBgA",
 				SectionTypes: []constants.SectionID{8},
 				Sections: []Section{uspca.USPCA{
 					CoreSegment: uspca.USPCACoreSegment{
@@ -103,6 +190,7 @@ func TestParse(t *testing.T) {
 			gppString:   "DBABRgA~bSFgmiU",
 			expected: GppContainer{
 				Version:      1,
+				header:       "DBABRgA",
 				SectionTypes: []constants.SectionID{9},
 				Sections: []Section{uspva.USPVA{
 					CoreSegment: sections.CommonUSCoreSegment{
@@ -130,11 +218,16 @@ func TestParse(t *testing.T) {
 			gppString:   "DBGBM~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA",
 			expected: GppContainer{
 				Version:      1,
+				header:       "DBGBM",
 				SectionTypes: []constants.SectionID{2},
-				Sections: []Section{GenericSection{sectionID: 2,
-					value: "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA"}},
+				Sections:     []Section{testTCFEU2},
 			},
-			expectedError: []error{fmt.Errorf("error parsing GPP header, section identifiers: error reading an int offset value in a Range(Fibonacci) entry(1): error reading bit 4 of Integer(Fibonacci): expected 1 bit at bit 32, but the byte array was only 4 bytes long")},
+			expectedError: []error{fmt.Errorf("error parsing GPP header, section identifiers: error reading an int offset value in a Range(Fibonacci) entry(1): error reading bit 4 of Integer(Fibonacci): expected 1 bit to start at bit 32, but the byte array was only 4 bytes long")},
+		},
+		"gpp-uspv1-error": {
+			description:   "GPP string with an invalid US Privacy string",
+			gppString:     "DBABTA~1YXN",
+			expectedError: []error{fmt.Errorf("error parsing uspv1 consent string: unable to set field OptOutSale due to parse error: invalid character 'X', should be one of 'Y', 'N' or '-'")},
 		},
 		"gpp-uspca-error": {
 			description:   "GPP string with USPCA",
@@ -150,13 +243,248 @@ func TestParse(t *testing.T) {
 			if len(test.expectedError) == 0 {
 				assert.Nil(t, err)
 				assert.Equal(t, test.expected, result)
+
+				strictResult, strictErr := ParseWithOptions(test.gppString, Options{FailFast: true, AllowTrailingSeparator: true, TruncateOnCountMismatch: true})
+				assert.Nil(t, strictErr)
+				assert.Equal(t, test.expected, strictResult)
 			} else {
-				assert.Equal(t, test.expectedError, err)
+				assert.Equal(t, errorMessages(test.expectedError), errorMessages(err))
 			}
 		})
 	}
 }
 
+func TestParseWithOptions(t *testing.T) {
+	unknownSection := GenericSection{sectionID: 28, value: "BAAAAAA"}
+
+	testData := map[string]struct {
+		gppString     string
+		options       Options
+		expected      GppContainer
+		expectedError []string
+	}{
+		"unknown-kept": {
+			gppString: "DBACTQY~1YNN~BAAAAAA",
+			expected:  GppContainer{Version: 1, header: "DBACTQY", SectionTypes: []constants.SectionID{6, 28}, Sections: []Section{testUSPV1, unknownSection}},
+		},
+		"unknown-skipped": {
+			gppString: "DBACTQY~1YNN~BAAAAAA",
+			options:   Options{SkipUnknownSections: true},
+			expected:  GppContainer{Version: 1, header: "DBACTQY", SectionTypes: []constants.SectionID{6}, Sections: []Section{testUSPV1}},
+		},
+		"trailing-separator-rejected": {
+			gppString:     "DBABTA~1YNN~",
+			expectedError: []string{"error parsing GPP header, section IDs do not match the number of sections: found 1 IDs, have 2 sections"},
+		},
+		"trailing-separator-allowed": {
+			gppString: "DBABTA~1YNN~~",
+			options:   Options{AllowTrailingSeparator: true},
+			expected:  GppContainer{Version: 1, header: "DBABTA", SectionTypes: []constants.SectionID{6}, Sections: []Section{testUSPV1}},
+		},
+		"too-few-sections-truncated": {
+			gppString: "DBACTMA~1YNN",
+			options:   Options{TruncateOnCountMismatch: true},
+			expected:  GppContainer{Version: 1, header: "DBACTMA", SectionTypes: []constants.SectionID{6}, Sections: []Section{testUSPV1}},
+		},
+		"too-many-sections-truncated": {
+			gppString: "DBABTA~1YNN~1YNN",
+			options:   Options{TruncateOnCountMismatch: true},
+			expected:  GppContainer{Version: 1, header: "DBABTA", SectionTypes: []constants.SectionID{6}, Sections: []Section{testUSPV1}},
+		},
+		"all-errors": {
+			gppString: "DBACTMA~1YXN~xlgWE",
+			expectedError: []string{
+				"error parsing uspv1 consent string: unable to set field OptOutSale due to parse error: invalid character 'X', should be one of 'Y', 'N' or '-'",
+				"error parsing uspca consent string: unable to set field CoreSegment.SensitiveDataProcessing due to parse error: expected 2 bits to start at bit 32, but the byte array was only 4 bytes long",
+			},
+		},
+		"fail-fast": {
+			gppString: "DBACTMA~1YXN~xlgWE",
+			options:   Options{FailFast: true},
+			expectedError: []string{
+				"error parsing uspv1 consent string: unable to set field OptOutSale due to parse error: invalid character 'X', should be one of 'Y', 'N' or '-'",
+			},
+		},
+		"header-padding-allowed": {
+			gppString: "DBABTB~1YNN",
+			expected:  GppContainer{Version: 1, header: "DBABTB", SectionTypes: []constants.SectionID{6}, Sections: []Section{testUSPV1}},
+		},
+		"header-padding-rejected": {
+			gppString:     "DBABTB~1YNN",
+			options:       Options{RejectNonZeroPadding: true},
+			expectedError: []string{"error parsing GPP header, section identifiers: non-zero padding bits"},
+		},
+		"section-padding-rejected": {
+			gppString:     "DBABRgA~bSFgmiV",
+			options:       Options{RejectNonZeroPadding: true},
+			expectedError: []string{"error parsing uspva consent string: non-zero padding bits"},
+		},
+		"tcf-padding-rejected": {
+			gppString:     "DBABM~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAB",
+			options:       Options{RejectNonZeroPadding: true},
+			expectedError: []string{"error parsing tcfeu2 consent string: non-zero padding bits"},
+		},
+	}
+
+	for name, test := range testData {
+		t.Run(name, func(t *testing.T) {
+			result, errs := ParseWithOptions(test.gppString, test.options)
+
+			if len(test.expectedError) == 0 {
+				assert.Nil(t, errs)
+				assert.Equal(t, test.expected, result)
+			} else {
+				assert.Equal(t, test.expectedError, errorMessages(errs))
+			}
+		})
+	}
+}
+
+func TestParseWithOptionsFailFastSections(t *testing.T) {
+	result, errs := ParseWithOptions("DBACTMA~1YXN~xlgWE", Options{FailFast: true})
+
+	assert.Len(t, errs, 1)
+	assert.Equal(t, []constants.SectionID{6}, result.SectionTypes)
+	assert.Len(t, result.Sections, 1)
+	assert.False(t, errors.Is(errs[0], ErrNonZeroPadding))
+}
+
+// reencodedSection is a custom section which encodes to a different string from the one it was decoded
+// from.
+type reencodedSection struct {
+	customSection
+}
+
+func (rs reencodedSection) Encode(bool) []byte {
+	return []byte("CAAA")
+}
+
+// paddedSection is a reencodedSection which reports its own padding.
+type paddedSection struct {
+	reencodedSection
+	padded bool
+}
+
+func (ps paddedSection) HasNonZeroPadding() bool {
+	return ps.padded
+}
+
+func TestParseWithOptionsCustomSectionPadding(t *testing.T) {
+	t.Run("reencoded", func(t *testing.T) {
+		withRegistration(t, 28, "uspxx", func(s string) (Section, error) {
+			return reencodedSection{customSection{id: 28, value: s}}, nil
+		})
+
+		_, errs := ParseWithOptions("DBABKYA~BAAAAAP", Options{RejectNonZeroPadding: true})
+
+		assert.Nil(t, errs)
+	})
+
+	t.Run("padding-checker", func(t *testing.T) {
+		withRegistration(t, 28, "uspxx", func(s string) (Section, error) {
+			section := reencodedSection{customSection{id: 28, value: s}}
+			return paddedSection{reencodedSection: section, padded: s != "BAAAAAA"}, nil
+		})
+
+		_, errs := ParseWithOptions("DBABKYA~BAAAAAA", Options{RejectNonZeroPadding: true})
+		assert.Nil(t, errs)
+
+		_, errs = ParseWithOptions("DBABKYA~BAAAAAP", Options{RejectNonZeroPadding: true})
+		assert.Equal(t, []string{"error parsing uspxx consent string: non-zero padding bits"}, errorMessages(errs))
+	})
+}
+
+func TestParseHeaderLongRange(t *testing.T) {
+	// A header of every section ID, up to the largest one, which must neither be listed one by one for a
+	// single section nor wrap around to 0.
+	bs := util.NewBitStreamForWrite()
+	bs.WriteByte6(3)
+	bs.WriteByte6(1)
+	assert.NoError(t, bs.WriteIntRange(&util.IntRange{Size: 1, Range: []util.IRange{{StartID: 1, EndID: 65535}}}))
+	header := string(bs.Base64Encode())
+
+	_, errs := Parse(header + "~1YNN")
+	assert.Equal(t, []string{"error parsing GPP header, section IDs do not match the number of sections: found 65535 IDs, have 1 sections"}, errorMessages(errs))
+
+	result, _ := ParseWithOptions(header+"~1YNN", Options{TruncateOnCountMismatch: true})
+	assert.Equal(t, []constants.SectionID{1}, result.SectionTypes)
+}
+
+func TestParseErrorTypes(t *testing.T) {
+	t.Run("header-eof", func(t *testing.T) {
+		_, errs := Parse("DBGBM~CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA")
+
+		var headerErr *HeaderError
+		assert.Len(t, errs, 1)
+		assert.True(t, errors.As(errs[0], &headerErr))
+		assert.Equal(t, "section identifiers", headerErr.Reason)
+		assert.True(t, errors.Is(errs[0], util.ErrUnexpectedEOF))
+	})
+
+	t.Run("header-base64", func(t *testing.T) {
+		_, errs := Parse("DBA*~1YNN")
+
+		var headerErr *HeaderError
+		assert.Len(t, errs, 1)
+		assert.True(t, errors.As(errs[0], &headerErr))
+		assert.True(t, errors.Is(errs[0], util.ErrInvalidBase64))
+		assert.False(t, errors.Is(errs[0], util.ErrUnexpectedEOF))
+	})
+
+	t.Run("header-count-mismatch", func(t *testing.T) {
+		_, errs := Parse("DBABTA~1YNN~1YNN")
+
+		var headerErr *HeaderError
+		assert.Len(t, errs, 1)
+		assert.True(t, errors.As(errs[0], &headerErr))
+		assert.Nil(t, headerErr.Err)
+	})
+
+	t.Run("section-eof", func(t *testing.T) {
+		_, errs := Parse("DBACTMA~1YNN~xlgWE")
+
+		var sectionErr *SectionError
+		assert.Len(t, errs, 1)
+		assert.True(t, errors.As(errs[0], &sectionErr))
+		assert.Equal(t, constants.SectionUSPCA, sectionErr.SectionID)
+		assert.Equal(t, "uspca", sectionErr.Name)
+		assert.Equal(t, 1, sectionErr.Index)
+		assert.Equal(t, "CoreSegment.SensitiveDataProcessing", sectionErr.Field)
+		assert.Equal(t, 32, sectionErr.BitOffset)
+		assert.True(t, errors.Is(errs[0], util.ErrUnexpectedEOF))
+	})
+
+	t.Run("section-base64", func(t *testing.T) {
+		_, errs := Parse("DBABBgA~xlg*E")
+
+		var sectionErr *SectionError
+		assert.Len(t, errs, 1)
+		assert.True(t, errors.As(errs[0], &sectionErr))
+		assert.Equal(t, 0, sectionErr.Index)
+		assert.Equal(t, -1, sectionErr.BitOffset)
+		assert.True(t, errors.Is(errs[0], util.ErrInvalidBase64))
+	})
+
+	t.Run("section-invalid-value", func(t *testing.T) {
+		_, errs := Parse("DBABTA~1YXN")
+
+		var sectionErr *SectionError
+		assert.Len(t, errs, 1)
+		assert.True(t, errors.As(errs[0], &sectionErr))
+		assert.Equal(t, "OptOutSale", sectionErr.Field)
+		assert.Equal(t, -1, sectionErr.BitOffset)
+		assert.False(t, errors.Is(errs[0], util.ErrUnexpectedEOF))
+	})
+}
+
+func errorMessages(errs []error) []string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return messages
+}
+
 func TestFailFastHeaderValidate(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		err := failFastHeaderValidate("DBABM")
@@ -0,0 +1,207 @@
+// Package policy turns the US privacy sections of a GPP string into allow or deny decisions for the
+// activities a bidder performs with user data.
+package policy
+
+import (
+	"fmt"
+
+	gpp "github.com/prebid/go-gpp"
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/sections/uspv1"
+)
+
+type Activity string
+
+const (
+	ActivitySyncUser           Activity = "syncUser"
+	ActivityTransmitUfpd       Activity = "transmitUfpd"
+	ActivityTransmitPreciseGeo Activity = "transmitPreciseGeo"
+	ActivityTransmitEids       Activity = "transmitEids"
+)
+
+// Activities lists every activity Evaluate decides on, in the order of its result.
+var Activities = []Activity{
+	ActivitySyncUser,
+	ActivityTransmitUfpd,
+	ActivityTransmitPreciseGeo,
+	ActivityTransmitEids,
+}
+
+// PreciseGeolocationCategory holds the 1-based sensitive data category of precise geolocation for each
+// US section which has one. Sections missing from the map never restrict ActivityTransmitPreciseGeo
+// through their sensitive data fields.
+var PreciseGeolocationCategory = map[constants.SectionID]int{
+	constants.SectionUSPNAT: 8,
+	constants.SectionUSPCA:  3,
+	constants.SectionUSPVA:  8,
+	constants.SectionUSPUT:  8,
+	constants.SectionUSPCT:  8,
+	constants.SectionUSPFL:  8,
+	constants.SectionUSPMT:  8,
+	constants.SectionUSPOR:  11,
+	constants.SectionUSPTX:  8,
+	constants.SectionUSPDE:  8,
+	constants.SectionUSPIA:  8,
+	constants.SectionUSPNE:  8,
+	constants.SectionUSPNH:  8,
+	constants.SectionUSPNJ:  8,
+	constants.SectionUSPTN:  8,
+	constants.SectionUSPMN:  8,
+	constants.SectionUSPMD:  8,
+	constants.SectionUSPIN:  8,
+	constants.SectionUSPKY:  8,
+	constants.SectionUSPRI:  8,
+}
+
+// Decision is the outcome for one activity. When an applicable section restricts the activity, SectionID
+// names the section and Field the field which did so, or is empty if the section could not be decoded.
+// Otherwise they are zero.
+type Decision struct {
+	Activity  Activity
+	Allow     bool
+	SectionID constants.SectionID
+	Field     string
+	Reason    string
+}
+
+// Evaluate decides on each of the Activities from the US sections of the container whose IDs are listed
+// in gppSID, the sections which apply to the request. An activity is denied by the first applicable
+// section, in gppSID order, which restricts it, and allowed when none does. An applicable section which is
+// missing from the container's Sections or failed to decode denies every activity.
+func Evaluate(container gpp.GppContainer, gppSID []constants.SectionID) []Decision {
+	applicable := applicableSections(container, gppSID)
+
+	decisions := make([]Decision, 0, len(Activities))
+	for _, activity := range Activities {
+		decisions = append(decisions, evaluateActivity(activity, applicable))
+	}
+	return decisions
+}
+
+// denyFunc returns the decision denying an activity because of a field of the section.
+type denyFunc func(field, reason string) (Decision, bool)
+
+type applicableSection struct {
+	id      constants.SectionID
+	section gpp.Section
+}
+
+// isUSSection reports whether a section ID is one of the US sections, from the US Privacy string to the
+// last of the state sections.
+func isUSSection(id constants.SectionID) bool {
+	return id >= constants.SectionUSPV1 && id <= constants.SectionUSPRI
+}
+
+// applicableSections returns the US sections listed both in gppSID and in the container's SectionTypes, in
+// gppSID order. The section is nil when the container has no section at the index of its type.
+func applicableSections(container gpp.GppContainer, gppSID []constants.SectionID) []applicableSection {
+	var applicable []applicableSection
+	for _, id := range gppSID {
+		if !isUSSection(id) {
+			continue
+		}
+		for i, sectionType := range container.SectionTypes {
+			if sectionType != id {
+				continue
+			}
+			var section gpp.Section
+			if i < len(container.Sections) {
+				section = container.Sections[i]
+			}
+			applicable = append(applicable, applicableSection{id: id, section: section})
+		}
+	}
+	return applicable
+}
+
+func evaluateActivity(activity Activity, applicable []applicableSection) Decision {
+	for _, section := range applicable {
+		if decision, denied := denyActivity(activity, section); denied {
+			return decision
+		}
+	}
+
+	if len(applicable) == 0 {
+		return Decision{Activity: activity, Allow: true, Reason: "no US privacy section applies"}
+	}
+	return Decision{Activity: activity, Allow: true, Reason: "no applicable US privacy section restricts the activity"}
+}
+
+// denyActivity returns the decision denying the activity if the section restricts it. The decoders return
+// a section without its ID when they fail, so a section whose ID is not the applicable one is treated as
+// one which failed to decode.
+func denyActivity(activity Activity, section applicableSection) (Decision, bool) {
+	name := constants.SectionNamesByID[int(section.id)]
+	deny := func(field, reason string) (Decision, bool) {
+		return Decision{
+			Activity:  activity,
+			Allow:     false,
+			SectionID: section.id,
+			Field:     field,
+			Reason:    fmt.Sprintf("%s: %s", name, reason),
+		}, true
+	}
+
+	if section.section == nil || section.section.GetID() != section.id {
+		return deny("", "section is missing or could not be decoded")
+	}
+	switch signals := section.section.(type) {
+	case uspv1.USPV1:
+		if activity != ActivityTransmitPreciseGeo && signals.OptOutSale == uspv1.Yes {
+			return deny("OptOutSale", "user opted out of the sale of personal data")
+		}
+		return Decision{}, false
+	case sections.USPrivacySection:
+		return denyUSPrivacyActivity(activity, section.id, signals, deny)
+	}
+	return deny("", "section is missing or could not be decoded")
+}
+
+// denyUSPrivacyActivity applies the rules of the US national and state sections.
+func denyUSPrivacyActivity(activity Activity, id constants.SectionID, signals sections.USPrivacySection, deny denyFunc) (Decision, bool) {
+	for i, consent := range signals.KnownChildSensitiveDataConsents() {
+		if consent == sections.NoConsent {
+			return deny(fmt.Sprintf("KnownChildSensitiveDataConsents[%d]", i+1), "no consent to process the data of a known child")
+		}
+	}
+
+	if activity == ActivityTransmitPreciseGeo {
+		category, ok := PreciseGeolocationCategory[id]
+		if ok && signals.SensitiveDataConsent(category) == sections.NoConsent {
+			return deny(fmt.Sprintf("SensitiveDataProcessing[%d]", category), "no consent to process precise geolocation")
+		}
+		return Decision{}, false
+	}
+
+	if signals.GPC() {
+		return deny("GPC", "Global Privacy Control signal is set")
+	}
+	if signals.SaleOptOut() == sections.OptedOut {
+		return deny("SaleOptOut", "user opted out of the sale of personal data")
+	}
+	if signals.SharingOptOut() == sections.OptedOut {
+		return deny("SharingOptOut", "user opted out of the sharing of personal data")
+	}
+	if signals.TargetedAdvertisingOptOut() == sections.OptedOut {
+		return deny("TargetedAdvertisingOptOut", "user opted out of targeted advertising")
+	}
+	if signals.SaleOptOutNotice() == sections.NoticeNotProvided {
+		return deny("SaleOptOutNotice", "user was not given notice of the right to opt out of sale")
+	}
+	if signals.TargetedAdvertisingOptOutNotice() == sections.NoticeNotProvided {
+		return deny("TargetedAdvertisingOptOutNotice", "user was not given notice of the right to opt out of targeted advertising")
+	}
+	if signals.MspaServiceProviderMode() == sections.MspaYes {
+		return deny("MspaServiceProviderMode", "transaction is in MSPA service provider mode")
+	}
+
+	if activity == ActivityTransmitUfpd {
+		for i, consent := range signals.SensitiveDataConsents() {
+			if consent == sections.NoConsent {
+				return deny(fmt.Sprintf("SensitiveDataProcessing[%d]", i+1), "no consent to process sensitive data")
+			}
+		}
+	}
+	return Decision{}, false
+}
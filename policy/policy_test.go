@@ -0,0 +1,306 @@
+package policy
+
+import (
+	"testing"
+
+	gpp "github.com/prebid/go-gpp"
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/sections/uspca"
+	"github.com/prebid/go-gpp/sections/usput"
+	"github.com/prebid/go-gpp/sections/uspv1"
+	"github.com/prebid/go-gpp/sections/uspva"
+	"github.com/stretchr/testify/assert"
+)
+
+var testUSPVA = uspva.USPVA{
+	SectionID: constants.SectionUSPVA,
+	CoreSegment: sections.CommonUSCoreSegment{
+		TargetedAdvertisingOptOut:       1,
+		SensitiveDataProcessing:         []byte{1, 2, 0, 0, 2, 1, 2, 2},
+		KnownChildSensitiveDataConsents: []byte{0},
+	},
+}
+
+var testUSPCA = uspca.USPCA{
+	SectionID: constants.SectionUSPCA,
+	CoreSegment: uspca.USPCACoreSegment{
+		SaleOptOut:                      2,
+		SensitiveDataProcessing:         []byte{0, 1, 1, 2, 0, 1, 0, 1, 2},
+		KnownChildSensitiveDataConsents: []byte{0, 0},
+	},
+	GPCSegment: sections.CommonUSGPCSegment{SubsectionType: 1, Gpc: true},
+}
+
+var testUSPUT = usput.USPUT{
+	SectionID: constants.SectionUSPUT,
+	CoreSegment: usput.USPUTCoreSegment{
+		SaleOptOut:                      2,
+		TargetedAdvertisingOptOut:       2,
+		SensitiveDataProcessing:         []byte{2, 2, 2, 2, 2, 2, 2, 2},
+		KnownChildSensitiveDataConsents: 1,
+	},
+}
+
+func allowed(reason string) []Decision {
+	decisions := make([]Decision, 0, len(Activities))
+	for _, activity := range Activities {
+		decisions = append(decisions, Decision{Activity: activity, Allow: true, Reason: reason})
+	}
+	return decisions
+}
+
+func TestEvaluate(t *testing.T) {
+	testData := map[string]struct {
+		sections []gpp.Section
+		gppSID   []constants.SectionID
+		expected []Decision
+	}{
+		"no-gpp-sid": {
+			sections: []gpp.Section{testUSPVA},
+			expected: allowed("no US privacy section applies"),
+		},
+		"section-not-applicable": {
+			sections: []gpp.Section{testUSPVA},
+			gppSID:   []constants.SectionID{constants.SectionUSPCO},
+			expected: allowed("no US privacy section applies"),
+		},
+		"uspv1-sale-opt-out": {
+			sections: []gpp.Section{uspv1.USPV1{SectionID: constants.SectionUSPV1, OptOutSale: uspv1.Yes}},
+			gppSID:   []constants.SectionID{constants.SectionUSPV1},
+			expected: []Decision{
+				{Activity: ActivitySyncUser, SectionID: constants.SectionUSPV1, Field: "OptOutSale", Reason: "uspv1: user opted out of the sale of personal data"},
+				{Activity: ActivityTransmitUfpd, SectionID: constants.SectionUSPV1, Field: "OptOutSale", Reason: "uspv1: user opted out of the sale of personal data"},
+				{Activity: ActivityTransmitPreciseGeo, Allow: true, Reason: "no applicable US privacy section restricts the activity"},
+				{Activity: ActivityTransmitEids, SectionID: constants.SectionUSPV1, Field: "OptOutSale", Reason: "uspv1: user opted out of the sale of personal data"},
+			},
+		},
+		"missing-section": {
+			sections: []gpp.Section{nil},
+			gppSID:   []constants.SectionID{constants.SectionUSPVA},
+			expected: []Decision{
+				{Activity: ActivitySyncUser, SectionID: constants.SectionUSPVA, Reason: "uspva: section is missing or could not be decoded"},
+				{Activity: ActivityTransmitUfpd, SectionID: constants.SectionUSPVA, Reason: "uspva: section is missing or could not be decoded"},
+				{Activity: ActivityTransmitPreciseGeo, SectionID: constants.SectionUSPVA, Reason: "uspva: section is missing or could not be decoded"},
+				{Activity: ActivityTransmitEids, SectionID: constants.SectionUSPVA, Reason: "uspva: section is missing or could not be decoded"},
+			},
+		},
+		"targeted-advertising-opt-out": {
+			sections: []gpp.Section{testUSPVA},
+			gppSID:   []constants.SectionID{constants.SectionUSPVA},
+			expected: []Decision{
+				{Activity: ActivitySyncUser, SectionID: constants.SectionUSPVA, Field: "TargetedAdvertisingOptOut", Reason: "uspva: user opted out of targeted advertising"},
+				{Activity: ActivityTransmitUfpd, SectionID: constants.SectionUSPVA, Field: "TargetedAdvertisingOptOut", Reason: "uspva: user opted out of targeted advertising"},
+				{Activity: ActivityTransmitPreciseGeo, Allow: true, Reason: "no applicable US privacy section restricts the activity"},
+				{Activity: ActivityTransmitEids, SectionID: constants.SectionUSPVA, Field: "TargetedAdvertisingOptOut", Reason: "uspva: user opted out of targeted advertising"},
+			},
+		},
+		"gpc-and-precise-geo": {
+			sections: []gpp.Section{testUSPCA},
+			gppSID:   []constants.SectionID{constants.SectionUSPCA},
+			expected: []Decision{
+				{Activity: ActivitySyncUser, SectionID: constants.SectionUSPCA, Field: "GPC", Reason: "uspca: Global Privacy Control signal is set"},
+				{Activity: ActivityTransmitUfpd, SectionID: constants.SectionUSPCA, Field: "GPC", Reason: "uspca: Global Privacy Control signal is set"},
+				{Activity: ActivityTransmitPreciseGeo, SectionID: constants.SectionUSPCA, Field: "SensitiveDataProcessing[3]", Reason: "uspca: no consent to process precise geolocation"},
+				{Activity: ActivityTransmitEids, SectionID: constants.SectionUSPCA, Field: "GPC", Reason: "uspca: Global Privacy Control signal is set"},
+			},
+		},
+		"known-child": {
+			sections: []gpp.Section{testUSPUT},
+			gppSID:   []constants.SectionID{constants.SectionUSPUT},
+			expected: []Decision{
+				{Activity: ActivitySyncUser, SectionID: constants.SectionUSPUT, Field: "KnownChildSensitiveDataConsents[1]", Reason: "usput: no consent to process the data of a known child"},
+				{Activity: ActivityTransmitUfpd, SectionID: constants.SectionUSPUT, Field: "KnownChildSensitiveDataConsents[1]", Reason: "usput: no consent to process the data of a known child"},
+				{Activity: ActivityTransmitPreciseGeo, SectionID: constants.SectionUSPUT, Field: "KnownChildSensitiveDataConsents[1]", Reason: "usput: no consent to process the data of a known child"},
+				{Activity: ActivityTransmitEids, SectionID: constants.SectionUSPUT, Field: "KnownChildSensitiveDataConsents[1]", Reason: "usput: no consent to process the data of a known child"},
+			},
+		},
+		"first-applicable-section-wins": {
+			sections: []gpp.Section{testUSPCA, testUSPVA},
+			gppSID:   []constants.SectionID{constants.SectionUSPVA, constants.SectionUSPCA},
+			expected: []Decision{
+				{Activity: ActivitySyncUser, SectionID: constants.SectionUSPVA, Field: "TargetedAdvertisingOptOut", Reason: "uspva: user opted out of targeted advertising"},
+				{Activity: ActivityTransmitUfpd, SectionID: constants.SectionUSPVA, Field: "TargetedAdvertisingOptOut", Reason: "uspva: user opted out of targeted advertising"},
+				{Activity: ActivityTransmitPreciseGeo, SectionID: constants.SectionUSPCA, Field: "SensitiveDataProcessing[3]", Reason: "uspca: no consent to process precise geolocation"},
+				{Activity: ActivityTransmitEids, SectionID: constants.SectionUSPVA, Field: "TargetedAdvertisingOptOut", Reason: "uspva: user opted out of targeted advertising"},
+			},
+		},
+	}
+
+	for name, test := range testData {
+		t.Run(name, func(t *testing.T) {
+			container := gpp.GppContainer{Version: 1, Sections: test.sections}
+			for _, section := range test.sections {
+				if section == nil {
+					container.SectionTypes = append(container.SectionTypes, constants.SectionUSPVA)
+					continue
+				}
+				container.SectionTypes = append(container.SectionTypes, section.GetID())
+			}
+
+			assert.Equal(t, test.expected, Evaluate(container, test.gppSID))
+		})
+	}
+}
+
+func TestEvaluateParsed(t *testing.T) {
+	container, errs := gpp.Parse("DBABRgA~bSFgmiU")
+	assert.Nil(t, errs)
+
+	decisions := Evaluate(container, []constants.SectionID{constants.SectionUSPVA})
+
+	assert.Len(t, decisions, len(Activities))
+	assert.False(t, decisions[0].Allow)
+	assert.Equal(t, "TargetedAdvertisingOptOut", decisions[0].Field)
+}
+
+func denied(id constants.SectionID, field, reason string) []Decision {
+	decisions := make([]Decision, 0, len(Activities))
+	for _, activity := range Activities {
+		decisions = append(decisions, Decision{Activity: activity, SectionID: id, Field: field, Reason: reason})
+	}
+	return decisions
+}
+
+// TestEvaluateEncoded evaluates GPP strings with a single US section in which one field restricts the
+// activities.
+func TestEvaluateEncoded(t *testing.T) {
+	restricted := func(id constants.SectionID, field, reason string) []Decision {
+		decisions := denied(id, field, reason)
+		decisions[2] = Decision{Activity: ActivityTransmitPreciseGeo, Allow: true, Reason: "no applicable US privacy section restricts the activity"}
+		return decisions
+	}
+
+	testData := map[string]struct {
+		gppString string
+		gppSID    []constants.SectionID
+		expected  []Decision
+	}{
+		"uspv1-sale-opt-out": {
+			gppString: "DBABTA~1YYN",
+			gppSID:    []constants.SectionID{constants.SectionUSPV1},
+			expected:  restricted(constants.SectionUSPV1, "OptOutSale", "uspv1: user opted out of the sale of personal data"),
+		},
+		"uspv1-no-opt-out": {
+			gppString: "DBABTA~1YNN",
+			gppSID:    []constants.SectionID{constants.SectionUSPV1},
+			expected:  allowed("no applicable US privacy section restricts the activity"),
+		},
+		"undecodable-section": {
+			gppString: "DBABBg~BVo",
+			gppSID:    []constants.SectionID{constants.SectionUSPCA},
+			expected:  denied(constants.SectionUSPCA, "", "uspca: section is missing or could not be decoded"),
+		},
+		"undecodable-section-not-applicable": {
+			gppString: "DBABBg~BVo",
+			gppSID:    []constants.SectionID{constants.SectionUSPVA},
+			expected:  allowed("no US privacy section applies"),
+		},
+		"uspnat-sharing-opt-out": {
+			gppString: "DBABLA~BJAEAAAAAgA.QA",
+			gppSID:    []constants.SectionID{constants.SectionUSPNAT},
+			expected:  restricted(constants.SectionUSPNAT, "SharingOptOut", "uspnat: user opted out of the sharing of personal data"),
+		},
+		"uspca-sharing-opt-out": {
+			gppString: "DBABBg~BAEAAACA.QA",
+			gppSID:    []constants.SectionID{constants.SectionUSPCA},
+			expected:  restricted(constants.SectionUSPCA, "SharingOptOut", "uspca: user opted out of the sharing of personal data"),
+		},
+		"uspva-sale-opt-out-notice-not-provided": {
+			gppString: "DBABRg~BIAAACA",
+			gppSID:    []constants.SectionID{constants.SectionUSPVA},
+			expected:  restricted(constants.SectionUSPVA, "SaleOptOutNotice", "uspva: user was not given notice of the right to opt out of sale"),
+		},
+		"uspco-targeted-advertising-opt-out-notice-not-provided": {
+			gppString: "DBABJg~BCAAAIA.QA",
+			gppSID:    []constants.SectionID{constants.SectionUSPCO},
+			expected:  restricted(constants.SectionUSPCO, "TargetedAdvertisingOptOutNotice", "uspco: user was not given notice of the right to opt out of targeted advertising"),
+		},
+		"usptx-service-provider-mode": {
+			gppString: "DBABEw~BAAAAAhA.QA",
+			gppSID:    []constants.SectionID{constants.SectionUSPTX},
+			expected:  restricted(constants.SectionUSPTX, "MspaServiceProviderMode", "usptx: transaction is in MSPA service provider mode"),
+		},
+		"uspfl-sensitive-data": {
+			gppString: "DBABAw~BABAAACA",
+			gppSID:    []constants.SectionID{constants.SectionUSPFL},
+			expected: []Decision{
+				{Activity: ActivitySyncUser, Allow: true, Reason: "no applicable US privacy section restricts the activity"},
+				{Activity: ActivityTransmitUfpd, SectionID: constants.SectionUSPFL, Field: "SensitiveDataProcessing[1]", Reason: "uspfl: no consent to process sensitive data"},
+				{Activity: ActivityTransmitPreciseGeo, Allow: true, Reason: "no applicable US privacy section restricts the activity"},
+				{Activity: ActivityTransmitEids, Allow: true, Reason: "no applicable US privacy section restricts the activity"},
+			},
+		},
+		"uspnj-no-restriction": {
+			gppString: "DBABAYA~BAAAAAAAgA.QA",
+			gppSID:    []constants.SectionID{constants.SectionUSPNJ},
+			expected:  allowed("no applicable US privacy section restricts the activity"),
+		},
+	}
+
+	for name, test := range testData {
+		t.Run(name, func(t *testing.T) {
+			container, _ := gpp.Parse(test.gppString)
+
+			assert.Equal(t, test.expected, Evaluate(container, test.gppSID))
+		})
+	}
+}
+
+// TestEvaluatePreciseGeolocation evaluates a GPP string for each state with only the precise geolocation
+// category of its specification set to no consent, or opted out.
+func TestEvaluatePreciseGeolocation(t *testing.T) {
+	testData := map[string]struct {
+		gppString string
+		id        constants.SectionID
+		field     string
+	}{
+		"uspnat": {"DBABLA~BAAAAAEAAgA.QA", constants.SectionUSPNAT, "SensitiveDataProcessing[8]"},
+		"uspca":  {"DBABBg~BAAEAACA.QA", constants.SectionUSPCA, "SensitiveDataProcessing[3]"},
+		"uspva":  {"DBABRg~BAAAASA", constants.SectionUSPVA, "SensitiveDataProcessing[8]"},
+		"usput":  {"DBABFg~BAAAAEgA", constants.SectionUSPUT, "SensitiveDataProcessing[8]"},
+		"uspct":  {"DBABVg~BAAAAQIA.QA", constants.SectionUSPCT, "SensitiveDataProcessing[8]"},
+		"uspfl":  {"DBABAw~BAAAAQCA", constants.SectionUSPFL, "SensitiveDataProcessing[8]"},
+		"uspmt":  {"DBABQw~BAAAAQCA.QA", constants.SectionUSPMT, "SensitiveDataProcessing[8]"},
+		"uspor":  {"DBABIw~BAAAAAQCAA.QA", constants.SectionUSPOR, "SensitiveDataProcessing[11]"},
+		"usptx":  {"DBABEw~BAAAAQgA.QA", constants.SectionUSPTX, "SensitiveDataProcessing[8]"},
+		"uspde":  {"DBABUw~BAAAAQACAA.QA", constants.SectionUSPDE, "SensitiveDataProcessing[8]"},
+		"uspia":  {"DBABCw~BAAAAEgA.QA", constants.SectionUSPIA, "SensitiveDataProcessing[8]"},
+		"uspne":  {"DBABSw~BAAAAQgA.QA", constants.SectionUSPNE, "SensitiveDataProcessing[8]"},
+		"uspnh":  {"DBABKw~BAAAAQCA.QA", constants.SectionUSPNH, "SensitiveDataProcessing[8]"},
+		"uspnj":  {"DBABAYA~BAAAAQAAgA.QA", constants.SectionUSPNJ, "SensitiveDataProcessing[8]"},
+		"usptn":  {"DBABQYA~BAAAAQgA.QA", constants.SectionUSPTN, "SensitiveDataProcessing[8]"},
+		"uspmn":  {"DBABIYA~BAAAAQgA.QA", constants.SectionUSPMN, "SensitiveDataProcessing[8]"},
+		"uspmd":  {"DBABEYA~BAAAAQCA.QA", constants.SectionUSPMD, "SensitiveDataProcessing[8]"},
+		"uspin":  {"DBABUYA~BAAAAQgA.QA", constants.SectionUSPIN, "SensitiveDataProcessing[8]"},
+		"uspky":  {"DBABCYA~BAAAAQgA.QA", constants.SectionUSPKY, "SensitiveDataProcessing[8]"},
+		"uspri":  {"DBABSYA~BAAAAQgA.QA", constants.SectionUSPRI, "SensitiveDataProcessing[8]"},
+	}
+
+	for name, test := range testData {
+		t.Run(name, func(t *testing.T) {
+			container, errs := gpp.Parse(test.gppString)
+			assert.Nil(t, errs)
+
+			decisions := Evaluate(container, []constants.SectionID{test.id})
+
+			assert.Equal(t, Decision{
+				Activity:  ActivityTransmitPreciseGeo,
+				SectionID: test.id,
+				Field:     test.field,
+				Reason:    name + ": no consent to process precise geolocation",
+			}, decisions[2])
+			assert.True(t, decisions[0].Allow)
+		})
+	}
+
+	t.Run("other-category", func(t *testing.T) {
+		// Category 8 of Oregon is not precise geolocation.
+		container, errs := gpp.Parse("DBABIw~BAAAAQACAA.QA")
+		assert.Nil(t, errs)
+
+		decisions := Evaluate(container, []constants.SectionID{constants.SectionUSPOR})
+
+		assert.True(t, decisions[2].Allow)
+	})
+}
@@ -0,0 +1,123 @@
+package gpp
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections/tcfcav1"
+	"github.com/prebid/go-gpp/sections/tcfeu2"
+	"github.com/prebid/go-gpp/sections/uspca"
+	"github.com/prebid/go-gpp/sections/uspco"
+	"github.com/prebid/go-gpp/sections/uspct"
+	"github.com/prebid/go-gpp/sections/uspde"
+	"github.com/prebid/go-gpp/sections/uspfl"
+	"github.com/prebid/go-gpp/sections/uspia"
+	"github.com/prebid/go-gpp/sections/uspin"
+	"github.com/prebid/go-gpp/sections/uspky"
+	"github.com/prebid/go-gpp/sections/uspmd"
+	"github.com/prebid/go-gpp/sections/uspmn"
+	"github.com/prebid/go-gpp/sections/uspmt"
+	"github.com/prebid/go-gpp/sections/uspnat"
+	"github.com/prebid/go-gpp/sections/uspne"
+	"github.com/prebid/go-gpp/sections/uspnh"
+	"github.com/prebid/go-gpp/sections/uspnj"
+	"github.com/prebid/go-gpp/sections/uspor"
+	"github.com/prebid/go-gpp/sections/uspri"
+	"github.com/prebid/go-gpp/sections/usptn"
+	"github.com/prebid/go-gpp/sections/usptx"
+	"github.com/prebid/go-gpp/sections/usput"
+	"github.com/prebid/go-gpp/sections/uspv1"
+	"github.com/prebid/go-gpp/sections/uspva"
+)
+
+// SectionDecoder decodes the encoded value of a single section, i.e. the text between two '~' separators.
+type SectionDecoder func(encoded string) (Section, error)
+
+// sectionRegistration holds what is known about a section ID. zero is the zero value of the section
+// type JSON is unmarshalled into, and is nil for sections registered with RegisterSectionDecoder.
+type sectionRegistration struct {
+	name   string
+	decode SectionDecoder
+	zero   Section
+}
+
+var (
+	nilSectionDecoderErr = errors.New("section decoder must not be nil")
+
+	registryLock sync.RWMutex
+	registry     = make(map[constants.SectionID]sectionRegistration)
+)
+
+func init() {
+	registerBuiltin(constants.SectionTCFEU2, tcfeu2.TCFEU2{}, func(s string) (Section, error) { return tcfeu2.NewTCFEU2(s) })
+	registerBuiltin(constants.SectionTCFCAV1, tcfcav1.TCFCAV1{}, func(s string) (Section, error) { return tcfcav1.NewTCFCAV1(s) })
+	registerBuiltin(constants.SectionUSPV1, uspv1.USPV1{}, func(s string) (Section, error) { return uspv1.NewUSPV1(s) })
+	registerBuiltin(constants.SectionUSPNAT, uspnat.USPNAT{}, func(s string) (Section, error) { return uspnat.NewUSPNAT(s) })
+	registerBuiltin(constants.SectionUSPCA, uspca.USPCA{}, func(s string) (Section, error) { return uspca.NewUSPCA(s) })
+	registerBuiltin(constants.SectionUSPVA, uspva.USPVA{}, func(s string) (Section, error) { return uspva.NewUSPVA(s) })
+	registerBuiltin(constants.SectionUSPCO, uspco.USPCO{}, func(s string) (Section, error) { return uspco.NewUSPCO(s) })
+	registerBuiltin(constants.SectionUSPUT, usput.USPUT{}, func(s string) (Section, error) { return usput.NewUSPUT(s) })
+	registerBuiltin(constants.SectionUSPCT, uspct.USPCT{}, func(s string) (Section, error) { return uspct.NewUSPCT(s) })
+	registerBuiltin(constants.SectionUSPFL, uspfl.USPFL{}, func(s string) (Section, error) { return uspfl.NewUSPFL(s) })
+	registerBuiltin(constants.SectionUSPMT, uspmt.USPMT{}, func(s string) (Section, error) { return uspmt.NewUSPMT(s) })
+	registerBuiltin(constants.SectionUSPOR, uspor.USPOR{}, func(s string) (Section, error) { return uspor.NewUSPOR(s) })
+	registerBuiltin(constants.SectionUSPTX, usptx.USPTX{}, func(s string) (Section, error) { return usptx.NewUSPTX(s) })
+	registerBuiltin(constants.SectionUSPDE, uspde.USPDE{}, func(s string) (Section, error) { return uspde.NewUSPDE(s) })
+	registerBuiltin(constants.SectionUSPIA, uspia.USPIA{}, func(s string) (Section, error) { return uspia.NewUSPIA(s) })
+	registerBuiltin(constants.SectionUSPNE, uspne.USPNE{}, func(s string) (Section, error) { return uspne.NewUSPNE(s) })
+	registerBuiltin(constants.SectionUSPNH, uspnh.USPNH{}, func(s string) (Section, error) { return uspnh.NewUSPNH(s) })
+	registerBuiltin(constants.SectionUSPNJ, uspnj.USPNJ{}, func(s string) (Section, error) { return uspnj.NewUSPNJ(s) })
+	registerBuiltin(constants.SectionUSPTN, usptn.USPTN{}, func(s string) (Section, error) { return usptn.NewUSPTN(s) })
+	registerBuiltin(constants.SectionUSPMN, uspmn.USPMN{}, func(s string) (Section, error) { return uspmn.NewUSPMN(s) })
+	registerBuiltin(constants.SectionUSPMD, uspmd.USPMD{}, func(s string) (Section, error) { return uspmd.NewUSPMD(s) })
+	registerBuiltin(constants.SectionUSPIN, uspin.USPIN{}, func(s string) (Section, error) { return uspin.NewUSPIN(s) })
+	registerBuiltin(constants.SectionUSPKY, uspky.USPKY{}, func(s string) (Section, error) { return uspky.NewUSPKY(s) })
+	registerBuiltin(constants.SectionUSPRI, uspri.USPRI{}, func(s string) (Section, error) { return uspri.NewUSPRI(s) })
+}
+
+func registerBuiltin(id constants.SectionID, zero Section, decoder SectionDecoder) {
+	if err := registerSection(id, constants.SectionNamesByID[int(id)], decoder, zero); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterSectionDecoder makes Parse decode sections with the given ID using decoder. The name is used to
+// identify the section in parse errors. Registering an ID which already has a decoder, including the
+// built-in ones, replaces it. It is safe to call concurrently with Parse, but is typically called from
+// an init function.
+func RegisterSectionDecoder(id constants.SectionID, name string, decoder SectionDecoder) error {
+	return registerSection(id, name, decoder, nil)
+}
+
+func registerSection(id constants.SectionID, name string, decoder SectionDecoder, zero Section) error {
+	if id < minSectionId || id > maxSectionId {
+		return sectionIdOutOfRangeErr
+	}
+	if decoder == nil {
+		return nilSectionDecoderErr
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	registry[id] = sectionRegistration{name: name, decode: decoder, zero: zero}
+	return nil
+}
+
+// lookupSectionDecoder returns the registered decoder for a section ID, if any.
+func lookupSectionDecoder(id constants.SectionID) (sectionRegistration, bool) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	registration, ok := registry[id]
+	return registration, ok
+}
+
+// SectionName returns the registered name of a section ID, such as "uspca", or the ID itself if it has
+// none.
+func SectionName(id constants.SectionID) string {
+	if registration, ok := lookupSectionDecoder(id); ok {
+		return registration.name
+	}
+	return strconv.Itoa(int(id))
+}
@@ -0,0 +1,100 @@
+package gpp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+type customSection struct {
+	id    constants.SectionID
+	value string
+}
+
+func (cs customSection) GetID() constants.SectionID {
+	return cs.id
+}
+
+func (cs customSection) GetValue() string {
+	return cs.value
+}
+
+func (cs customSection) Encode(bool) []byte {
+	return []byte(cs.value)
+}
+
+// withRegistration registers a decoder for the duration of the test, restoring whatever was registered before.
+func withRegistration(t *testing.T, id constants.SectionID, name string, decoder SectionDecoder) {
+	previous, ok := lookupSectionDecoder(id)
+	assert.NoError(t, RegisterSectionDecoder(id, name, decoder))
+	t.Cleanup(func() {
+		registryLock.Lock()
+		defer registryLock.Unlock()
+		if ok {
+			registry[id] = previous
+		} else {
+			delete(registry, id)
+		}
+	})
+}
+
+func TestRegisterSectionDecoder(t *testing.T) {
+	t.Run("new-section", func(t *testing.T) {
+		withRegistration(t, 28, "uspxx", func(s string) (Section, error) {
+			return customSection{id: 28, value: s}, nil
+		})
+
+		result, errs := Parse("DBABKYA~BAAAAAA")
+
+		assert.Nil(t, errs)
+		assert.Equal(t, []Section{customSection{id: 28, value: "BAAAAAA"}}, result.Sections)
+	})
+
+	t.Run("new-section-error", func(t *testing.T) {
+		withRegistration(t, 28, "uspxx", func(s string) (Section, error) {
+			return nil, errors.New("bad section")
+		})
+
+		_, errs := Parse("DBABKYA~BAAAAAA")
+
+		var sectionErr *SectionError
+		assert.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], "error parsing uspxx consent string: bad section")
+		assert.True(t, errors.As(errs[0], &sectionErr))
+		assert.Equal(t, constants.SectionID(28), sectionErr.SectionID)
+	})
+
+	t.Run("override-builtin", func(t *testing.T) {
+		withRegistration(t, constants.SectionUSPV1, "custom uspv1", func(s string) (Section, error) {
+			return customSection{id: constants.SectionUSPV1, value: s}, nil
+		})
+
+		result, errs := Parse("DBABTA~1YXN")
+
+		assert.Nil(t, errs)
+		assert.Equal(t, []Section{customSection{id: constants.SectionUSPV1, value: "1YXN"}}, result.Sections)
+	})
+
+	t.Run("invalid-id", func(t *testing.T) {
+		err := RegisterSectionDecoder(0, "zero", func(s string) (Section, error) { return nil, nil })
+		assert.Equal(t, sectionIdOutOfRangeErr, err)
+	})
+
+	t.Run("nil-decoder", func(t *testing.T) {
+		err := RegisterSectionDecoder(28, "uspxx", nil)
+		assert.Equal(t, nilSectionDecoderErr, err)
+	})
+}
+
+func TestBuiltinSectionDecoders(t *testing.T) {
+	for id, name := range constants.SectionNamesByID {
+		if constants.SectionID(id) == constants.SectionGPP {
+			continue
+		}
+		registration, ok := lookupSectionDecoder(constants.SectionID(id))
+		assert.True(t, ok, "no decoder registered for section %d", id)
+		assert.Equal(t, name, registration.name)
+	}
+}
@@ -0,0 +1,176 @@
+package gpp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrMissingField is reported, wrapped in an InputError, when a JSON line has no string at the field
+// path set with Scanner.SetJSONField.
+var ErrMissingField = errors.New("no GPP string at field path")
+
+// InputError is reported by Scanner for a line from which no GPP string could be read.
+type InputError struct {
+	Line int
+	Err  error
+}
+
+func (e *InputError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+func (e *InputError) Unwrap() error {
+	return e.Err
+}
+
+// Scanner reads GPP strings from newline-delimited input and parses each of them, in the style of
+// bufio.Scanner. Blank lines are skipped.
+//
+//	scanner := gpp.NewScanner(r)
+//	for scanner.Scan() {
+//		container, errs := scanner.Container(), scanner.Errors()
+//		...
+//	}
+//	if err := scanner.Err(); err != nil {
+//		...
+//	}
+type Scanner struct {
+	reader    *bufio.Reader
+	err       error
+	options   Options
+	fieldPath []string
+
+	line      int
+	text      string
+	container GppContainer
+	errs      []error
+}
+
+// maxLineSize is the longest line a Scanner reads; bid requests logged as JSON can be much longer
+// than bufio.MaxScanTokenSize. Longer lines are reported with an InputError wrapping bufio.ErrTooLong.
+const maxLineSize = 1024 * 1024
+
+func NewScanner(r io.Reader) *Scanner {
+	return &Scanner{reader: bufio.NewReader(r)}
+}
+
+// SetOptions sets the options each string is parsed with. It must be called before Scan.
+func (s *Scanner) SetOptions(opts Options) {
+	s.options = opts
+}
+
+// SetJSONField makes the Scanner read each line as a JSON object and take the GPP string from the
+// dot-separated field path, e.g. "regs.gpp". It must be called before Scan.
+func (s *Scanner) SetJSONField(path string) {
+	s.fieldPath = strings.Split(path, ".")
+}
+
+// Scan reads and parses the next GPP string. It returns false at the end of the input or when reading
+// fails, in which case Err returns the error. A line longer than 1 MB is skipped, and Errors returns an
+// InputError wrapping bufio.ErrTooLong for it.
+func (s *Scanner) Scan() bool {
+	for s.err == nil {
+		line, tooLong := s.readLine()
+		s.line++
+		if line == "" && !tooLong {
+			continue
+		}
+
+		s.text, s.container, s.errs = "", GppContainer{}, nil
+		if tooLong {
+			s.errs = []error{&InputError{Line: s.line, Err: fmt.Errorf("longer than %d bytes: %w", maxLineSize, bufio.ErrTooLong)}}
+			return true
+		}
+		if s.fieldPath == nil {
+			s.text = line
+		} else if text, err := s.field(line); err != nil {
+			s.errs = []error{&InputError{Line: s.line, Err: err}}
+			return true
+		} else {
+			s.text = text
+		}
+
+		s.container, s.errs = ParseWithOptions(s.text, s.options)
+		return true
+	}
+	return false
+}
+
+// readLine reads the next line, trimmed of spaces, and sets s.err at the end of the input or if reading
+// fails. The line is empty and tooLong is true if the line is longer than maxLineSize.
+func (s *Scanner) readLine() (string, bool) {
+	var line []byte
+	tooLong := false
+	for {
+		chunk, err := s.reader.ReadSlice('\n')
+		if len(line)+len(chunk) > maxLineSize+1 {
+			line, tooLong = nil, true
+		} else if !tooLong {
+			line = append(line, chunk...)
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err != nil {
+			s.err = err
+		}
+		if len(line) > maxLineSize && line[len(line)-1] != '\n' {
+			line, tooLong = nil, true
+		}
+		return strings.TrimSpace(string(line)), tooLong
+	}
+}
+
+// field returns the string at the field path of a JSON line.
+func (s *Scanner) field(line string) (string, error) {
+	var value interface{}
+	if err := json.Unmarshal([]byte(line), &value); err != nil {
+		return "", err
+	}
+	for _, key := range s.fieldPath {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return "", ErrMissingField
+		}
+		value = object[key]
+	}
+	text, ok := value.(string)
+	if !ok {
+		return "", ErrMissingField
+	}
+	return text, nil
+}
+
+// Text returns the GPP string read by the last call to Scan. It is empty if the string could not be
+// read from a JSON line.
+func (s *Scanner) Text() string {
+	return s.text
+}
+
+// Line returns the line number of the GPP string read by the last call to Scan, counting from 1.
+func (s *Scanner) Line() int {
+	return s.line
+}
+
+// Container returns the container parsed by the last call to Scan.
+func (s *Scanner) Container() GppContainer {
+	return s.container
+}
+
+// Errors returns the parse errors of the last call to Scan, or an InputError if no GPP string could
+// be read from the line.
+func (s *Scanner) Errors() []error {
+	return s.errs
+}
+
+// Err returns the first error reading the input, other than io.EOF.
+func (s *Scanner) Err() error {
+	if s.err == io.EOF {
+		return nil
+	}
+	return s.err
+}
@@ -0,0 +1,110 @@
+package gpp
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanner(t *testing.T) {
+	input := "DBABTA~1YNN\n\n  DBABTA~1YXN  \nDBABTA~1YNN~\n"
+	scanner := NewScanner(strings.NewReader(input))
+
+	assert.True(t, scanner.Scan())
+	assert.Equal(t, 1, scanner.Line())
+	assert.Equal(t, "DBABTA~1YNN", scanner.Text())
+	assert.Nil(t, scanner.Errors())
+	assert.Equal(t, []Section{testUSPV1}, scanner.Container().Sections)
+
+	assert.True(t, scanner.Scan())
+	assert.Equal(t, 3, scanner.Line())
+	assert.Equal(t, "DBABTA~1YXN", scanner.Text())
+	assert.Len(t, scanner.Errors(), 1)
+
+	assert.True(t, scanner.Scan())
+	assert.Equal(t, 4, scanner.Line())
+	assert.Len(t, scanner.Errors(), 1)
+
+	assert.False(t, scanner.Scan())
+	assert.NoError(t, scanner.Err())
+}
+
+func TestScannerOptions(t *testing.T) {
+	scanner := NewScanner(strings.NewReader("DBABTA~1YNN~\n"))
+	scanner.SetOptions(Options{AllowTrailingSeparator: true})
+
+	assert.True(t, scanner.Scan())
+	assert.Nil(t, scanner.Errors())
+	assert.Equal(t, []constants.SectionID{constants.SectionUSPV1}, scanner.Container().SectionTypes)
+}
+
+func TestScannerJSONField(t *testing.T) {
+	input := `{"id":"1","regs":{"gpp":"DBABTA~1YNN"}}
+{"id":"2","regs":{}}
+{"id":"3","regs":"DBABTA~1YNN"}
+not json
+`
+	scanner := NewScanner(strings.NewReader(input))
+	scanner.SetJSONField("regs.gpp")
+
+	assert.True(t, scanner.Scan())
+	assert.Equal(t, "DBABTA~1YNN", scanner.Text())
+	assert.Nil(t, scanner.Errors())
+	assert.Equal(t, []Section{testUSPV1}, scanner.Container().Sections)
+
+	for _, line := range []int{2, 3} {
+		assert.True(t, scanner.Scan())
+		assert.Equal(t, "", scanner.Text())
+		assert.Len(t, scanner.Errors(), 1)
+
+		var inputErr *InputError
+		assert.True(t, errors.As(scanner.Errors()[0], &inputErr))
+		assert.Equal(t, line, inputErr.Line)
+		assert.True(t, errors.Is(scanner.Errors()[0], ErrMissingField))
+	}
+
+	assert.True(t, scanner.Scan())
+	assert.Len(t, scanner.Errors(), 1)
+	assert.False(t, errors.Is(scanner.Errors()[0], ErrMissingField))
+
+	assert.False(t, scanner.Scan())
+	assert.NoError(t, scanner.Err())
+}
+
+func TestScannerLineTooLong(t *testing.T) {
+	input := strings.Repeat("A", maxLineSize+1) + "\nDBABTA~1YNN\n" + strings.Repeat("A", maxLineSize+1)
+	scanner := NewScanner(strings.NewReader(input))
+	assertTooLong := func(line int) {
+		assert.True(t, scanner.Scan())
+		assert.Equal(t, line, scanner.Line())
+		assert.Equal(t, "", scanner.Text())
+		assert.Len(t, scanner.Errors(), 1)
+
+		var inputErr *InputError
+		assert.True(t, errors.As(scanner.Errors()[0], &inputErr))
+		assert.Equal(t, line, inputErr.Line)
+		assert.True(t, errors.Is(scanner.Errors()[0], bufio.ErrTooLong))
+	}
+
+	assertTooLong(1)
+	assert.True(t, scanner.Scan())
+	assert.Equal(t, "DBABTA~1YNN", scanner.Text())
+	assert.Nil(t, scanner.Errors())
+	assertTooLong(3)
+
+	assert.False(t, scanner.Scan())
+	assert.NoError(t, scanner.Err())
+}
+
+func TestScannerLineAtLimit(t *testing.T) {
+	scanner := NewScanner(strings.NewReader(strings.Repeat("A", maxLineSize) + "\n"))
+
+	assert.True(t, scanner.Scan())
+	assert.Equal(t, maxLineSize, len(scanner.Text()))
+	assert.False(t, scanner.Scan())
+	assert.NoError(t, scanner.Err())
+}
@@ -0,0 +1,64 @@
+package sections
+
+import (
+	"errors"
+	"fmt"
+)
+
+// NoticeState is the value of a US notice field such as SaleOptOutNotice.
+type NoticeState byte
+
+const (
+	NoticeNotApplicable NoticeState = 0
+	NoticeProvided      NoticeState = 1
+	NoticeNotProvided   NoticeState = 2
+)
+
+// MspaMode is the value of the MSPA fields of the US sections. MspaCoveredTransaction may only be MspaYes
+// or MspaNo.
+type MspaMode byte
+
+const (
+	MspaNotApplicable MspaMode = 0
+	MspaYes           MspaMode = 1
+	MspaNo            MspaMode = 2
+)
+
+// ErrOutOfRange is wrapped by the errors of the section builders for a value or index a field does not have.
+var ErrOutOfRange = errors.New("out of range")
+
+// SetField sets a two bit field of a US section to value. If the value is outside the range of the field,
+// the field is left unchanged and an error is stored in err, unless it already holds one. It is used by the
+// section builders, which report the first error from Build.
+func SetField(err *error, name string, field *byte, value byte) {
+	min, max := fieldRange(name)
+	if value < min || value > max {
+		setError(err, fmt.Errorf("invalid value %d for field %s, should be %d to %d: %w", value, name, min, max, ErrOutOfRange))
+		return
+	}
+	*field = value
+}
+
+// SetFieldElem is SetField for the element at a 1-based index of an array field such as
+// SensitiveDataProcessing.
+func SetFieldElem(err *error, name string, fields []byte, index int, value byte) {
+	if index < 1 || index > len(fields) {
+		setError(err, fmt.Errorf("invalid index %d for field %s, should be 1 to %d: %w", index, name, len(fields), ErrOutOfRange))
+		return
+	}
+	SetField(err, name, &fields[index-1], value)
+}
+
+func setError(err *error, e error) {
+	if *err == nil {
+		*err = e
+	}
+}
+
+// fieldRange returns the smallest and largest value of a two bit field.
+func fieldRange(name string) (byte, byte) {
+	if name == "CoreSegment.MspaCoveredTransaction" {
+		return 1, 2
+	}
+	return 0, 2
+}
@@ -0,0 +1,69 @@
+package sections
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetField(t *testing.T) {
+	testData := []struct {
+		description string
+		name        string
+		value       byte
+		expected    byte
+		expectedErr string
+	}{
+		{
+			description: "should set a value in range",
+			name:        "CoreSegment.SaleOptOut",
+			value:       2,
+			expected:    2,
+		},
+		{
+			description: "should reject a value out of range",
+			name:        "CoreSegment.SaleOptOut",
+			value:       3,
+			expected:    0,
+			expectedErr: "invalid value 3 for field CoreSegment.SaleOptOut, should be 0 to 2: out of range",
+		},
+		{
+			description: "should reject a not applicable MspaCoveredTransaction",
+			name:        "CoreSegment.MspaCoveredTransaction",
+			value:       0,
+			expected:    0,
+			expectedErr: "invalid value 0 for field CoreSegment.MspaCoveredTransaction, should be 1 to 2: out of range",
+		},
+	}
+
+	for _, test := range testData {
+		var field byte
+		var err error
+		SetField(&err, test.name, &field, test.value)
+
+		assert.Equal(t, test.expected, field, test.description)
+		if test.expectedErr == "" {
+			assert.Nil(t, err, test.description)
+		} else {
+			assert.EqualError(t, err, test.expectedErr, test.description)
+			assert.ErrorIs(t, err, ErrOutOfRange, test.description)
+		}
+	}
+}
+
+func TestSetFieldElem(t *testing.T) {
+	var err error
+	fields := make([]byte, 3)
+
+	SetFieldElem(&err, "CoreSegment.SensitiveDataProcessing", fields, 3, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0, 0, 1}, fields)
+
+	SetFieldElem(&err, "CoreSegment.SensitiveDataProcessing", fields, 4, 1)
+	assert.EqualError(t, err, "invalid index 4 for field CoreSegment.SensitiveDataProcessing, should be 1 to 3: out of range")
+
+	// The first error is kept.
+	SetFieldElem(&err, "CoreSegment.SensitiveDataProcessing", fields, 0, 1)
+	assert.EqualError(t, err, "invalid index 4 for field CoreSegment.SensitiveDataProcessing, should be 1 to 3: out of range")
+	assert.Equal(t, []byte{0, 0, 1}, fields)
+}
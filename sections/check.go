@@ -0,0 +1,197 @@
+package sections
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/prebid/go-gpp/util"
+)
+
+// Errors wrapped by EncodeError, see the EncodeStrict methods of the sections.
+var (
+	ErrFieldWidth         = errors.New("value too wide for field")
+	ErrFieldLength        = errors.New("wrong number of values")
+	ErrUnsupportedVersion = errors.New("unsupported version")
+	ErrInvalidValue       = errors.New("invalid value")
+)
+
+// USVersion is the version of the US national and state sections this package encodes.
+const USVersion = 1
+
+// EncodeError reports the field of a section which cannot be encoded as it is. It unwraps to one of
+// ErrFieldWidth, ErrFieldLength, ErrUnsupportedVersion or ErrInvalidValue.
+type EncodeError struct {
+	Field string
+	Err   error
+	msg   string
+}
+
+func NewEncodeError(field string, err error, format string, args ...interface{}) *EncodeError {
+	return &EncodeError{Field: field, Err: err, msg: fmt.Sprintf(format, args...)}
+}
+
+func (e *EncodeError) Error() string {
+	return fmt.Sprintf("unable to encode field %s: %s", e.Field, e.msg)
+}
+
+func (e *EncodeError) Unwrap() error {
+	return e.Err
+}
+
+// CheckWidth returns an EncodeError if value does not fit in a field of the given number of bits.
+func CheckWidth(field string, value uint64, bits int) error {
+	if value>>uint(bits) != 0 {
+		return NewEncodeError(field, ErrFieldWidth, "value %d does not fit in %d bits", value, bits)
+	}
+	return nil
+}
+
+// CheckLength returns an EncodeError if an array field does not have the expected number of values.
+func CheckLength(field string, length int, expected int) error {
+	if length != expected {
+		return NewEncodeError(field, ErrFieldLength, "has %d values, should have %d", length, expected)
+	}
+	return nil
+}
+
+// CheckVersion returns an EncodeError if version is not the supported one.
+func CheckVersion(field string, version byte, supported byte) error {
+	if version != supported {
+		return NewEncodeError(field, ErrUnsupportedVersion, "version %d is not supported, should be %d", version, supported)
+	}
+	return nil
+}
+
+// CheckValue returns an EncodeError if value is not the expected one, e.g. for segment types.
+func CheckValue(field string, value byte, expected byte) error {
+	if value != expected {
+		return NewEncodeError(field, ErrInvalidValue, "value %d, should be %d", value, expected)
+	}
+	return nil
+}
+
+// CheckString6 returns an EncodeError if s is not numChars letters from A to Z, the only characters
+// util.BitStream.WriteString6Unchecked can write, as checked by util.ValidateString6.
+func CheckString6(field string, s string, numChars int) error {
+	if len(s) != numChars {
+		return NewEncodeError(field, ErrFieldLength, "%q has %d characters, should have %d", s, len(s), numChars)
+	}
+	if err := util.ValidateString6(s); err != nil {
+		return NewEncodeError(field, ErrInvalidValue, "%v", err)
+	}
+	return nil
+}
+
+// CheckDatetime returns an EncodeError if t cannot be written by util.BitStream.WriteDatetime, which holds the
+// deciseconds since the unix epoch in 36 bits.
+func CheckDatetime(field string, t time.Time) error {
+	if t.Unix() < 0 {
+		return NewEncodeError(field, ErrInvalidValue, "%s is before the unix epoch", t.Format(time.RFC3339))
+	}
+	return CheckWidth(field, uint64(t.Unix())*10+uint64(t.Nanosecond())/uint64(100*time.Millisecond), 36)
+}
+
+// CheckIntRange returns an EncodeError if a Range(Int) written by util.BitStream.WriteFixedIntRange would
+// not match intRange.
+func CheckIntRange(field string, intRange *util.IntRange) error {
+	if intRange == nil {
+		return nil
+	}
+	if err := CheckLength(field, len(intRange.Range), int(intRange.Size)); err != nil {
+		return err
+	}
+	if err := CheckWidth(field, uint64(intRange.Size), 12); err != nil {
+		return err
+	}
+	for _, r := range intRange.Range {
+		if r.StartID > r.EndID {
+			return NewEncodeError(field, ErrInvalidValue, "range %d-%d ends before it starts", r.StartID, r.EndID)
+		}
+	}
+	return nil
+}
+
+// Check returns an EncodeError if the range cannot be encoded: the bitfield must have MaxID flags, and
+// the ranges must not go past MaxID.
+func (r OptimizedRange) Check(field string) error {
+	if !r.IsRangeEncoding {
+		return CheckLength(field+".BitField", len(r.BitField), int(r.MaxID))
+	}
+	if err := CheckIntRange(field+".Range", r.Range); err != nil {
+		return err
+	}
+	if r.Range != nil {
+		for _, ir := range r.Range.Range {
+			if ir.EndID > r.MaxID {
+				return NewEncodeError(field+".Range", ErrInvalidValue, "ID %d is greater than MaxID %d", ir.EndID, r.MaxID)
+			}
+		}
+	}
+	return nil
+}
+
+// Check returns an EncodeError if the GPC segment cannot be encoded: its subsection type must be 1, the
+// only one the GPP specification defines.
+func (segment CommonUSGPCSegment) Check() error {
+	return CheckValue("GPCSegment.SubsectionType", segment.SubsectionType, 1)
+}
+
+// CheckUSCoreSegment returns an EncodeError if the core segment of a US section cannot be encoded as it
+// is: its version must be USVersion, every other byte field must fit in 2 bits, the notice and opt-out
+// fields must not hold the reserved value 3, and the SensitiveDataProcessing and
+// KnownChildSensitiveDataConsents arrays must have the given lengths.
+func CheckUSCoreSegment(segment interface{}, sensitiveDataFields int, knownChildDataFields int) error {
+	v := reflect.ValueOf(segment)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := "CoreSegment." + t.Field(i).Name
+		field := v.Field(i)
+		switch {
+		case t.Field(i).Name == "Version":
+			if err := CheckVersion(name, byte(field.Uint()), USVersion); err != nil {
+				return err
+			}
+		case field.Kind() == reflect.Slice:
+			size := sensitiveDataFields
+			if t.Field(i).Name == "KnownChildSensitiveDataConsents" {
+				size = knownChildDataFields
+			}
+			if err := CheckLength(name, field.Len(), size); err != nil {
+				return err
+			}
+			for j := 0; j < field.Len(); j++ {
+				if err := CheckWidth(fmt.Sprintf("%s[%d]", name, j+1), field.Index(j).Uint(), 2); err != nil {
+					return err
+				}
+			}
+		case field.Kind() == reflect.Uint8:
+			if err := CheckWidth(name, field.Uint(), 2); err != nil {
+				return err
+			}
+			if isNoticeOrOptOut(t.Field(i).Name) && field.Uint() == 3 {
+				return NewEncodeError(name, ErrInvalidValue, "value 3 is reserved, should be 0, 1 or 2")
+			}
+		}
+	}
+	return nil
+}
+
+// isNoticeOrOptOut reports whether a US core segment field is a notice, such as SaleOptOutNotice, or an
+// opt-out, such as SaleOptOut.
+func isNoticeOrOptOut(name string) bool {
+	return strings.HasSuffix(name, "Notice") || strings.HasSuffix(name, "OptOut")
+}
+
+// FirstError returns the first of errs which is not nil, so the checks of a segment can be listed in the
+// order of its fields.
+func FirstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package sections
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prebid/go-gpp/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecks(t *testing.T) {
+	testData := []struct {
+		description string
+		err         error
+		expectedErr string
+		sentinel    error
+	}{
+		{
+			description: "should accept a value which fits",
+			err:         CheckWidth("CmpID", 4095, 12),
+		},
+		{
+			description: "should reject a value which does not fit",
+			err:         CheckWidth("CmpID", 4096, 12),
+			expectedErr: "unable to encode field CmpID: value 4096 does not fit in 12 bits",
+			sentinel:    ErrFieldWidth,
+		},
+		{
+			description: "should reject an array of the wrong length",
+			err:         CheckLength("PurposeConsents", 23, 24),
+			expectedErr: "unable to encode field PurposeConsents: has 23 values, should have 24",
+			sentinel:    ErrFieldLength,
+		},
+		{
+			description: "should reject an unsupported version",
+			err:         CheckVersion("Version", 3, 1),
+			expectedErr: "unable to encode field Version: version 3 is not supported, should be 1",
+			sentinel:    ErrUnsupportedVersion,
+		},
+		{
+			description: "should reject an unexpected value",
+			err:         CheckValue("SegmentType", 2, 3),
+			expectedErr: "unable to encode field SegmentType: value 2, should be 3",
+			sentinel:    ErrInvalidValue,
+		},
+		{
+			description: "should accept letters",
+			err:         CheckString6("ConsentLanguage", "EN", 2),
+		},
+		{
+			description: "should reject a string of the wrong length",
+			err:         CheckString6("ConsentLanguage", "ENG", 2),
+			expectedErr: `unable to encode field ConsentLanguage: "ENG" has 3 characters, should have 2`,
+			sentinel:    ErrFieldLength,
+		},
+		{
+			description: "should reject characters other than A to Z",
+			err:         CheckString6("ConsentLanguage", "en", 2),
+			expectedErr: "unable to encode field ConsentLanguage: character 'e' at 0, should be A to Z: invalid character",
+			sentinel:    ErrInvalidValue,
+		},
+		{
+			description: "should accept a datetime after the epoch",
+			err:         CheckDatetime("Created", time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			description: "should reject a datetime before the epoch",
+			err:         CheckDatetime("Created", time.Time{}),
+			expectedErr: "unable to encode field Created: 0001-01-01T00:00:00Z is before the unix epoch",
+			sentinel:    ErrInvalidValue,
+		},
+		{
+			description: "should reject a datetime too late for 36 bits",
+			err:         CheckDatetime("Created", time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC)),
+			expectedErr: "unable to encode field Created: value 104137920000 does not fit in 36 bits",
+			sentinel:    ErrFieldWidth,
+		},
+		{
+			description: "should reject a range size which does not match the ranges",
+			err:         CheckIntRange("Vendors", &util.IntRange{Size: 2, Range: []util.IRange{{StartID: 1, EndID: 2}}}),
+			expectedErr: "unable to encode field Vendors: has 1 values, should have 2",
+			sentinel:    ErrFieldLength,
+		},
+		{
+			description: "should reject a range which ends before it starts",
+			err:         CheckIntRange("Vendors", &util.IntRange{Size: 1, Range: []util.IRange{{StartID: 3, EndID: 2}}}),
+			expectedErr: "unable to encode field Vendors: range 3-2 ends before it starts",
+			sentinel:    ErrInvalidValue,
+		},
+		{
+			description: "should reject a bitfield which does not match MaxID",
+			err:         OptimizedRange{MaxID: 3, BitField: []bool{true}}.Check("VendorConsents"),
+			expectedErr: "unable to encode field VendorConsents.BitField: has 1 values, should have 3",
+			sentinel:    ErrFieldLength,
+		},
+		{
+			description: "should reject a range past MaxID",
+			err: OptimizedRange{
+				MaxID:           3,
+				IsRangeEncoding: true,
+				Range:           &util.IntRange{Size: 1, Range: []util.IRange{{StartID: 2, EndID: 4}}},
+			}.Check("VendorConsents"),
+			expectedErr: "unable to encode field VendorConsents.Range: ID 4 is greater than MaxID 3",
+			sentinel:    ErrInvalidValue,
+		},
+		{
+			description: "should accept GPC subsection type 1",
+			err:         CommonUSGPCSegment{SubsectionType: 1, Gpc: true}.Check(),
+		},
+		{
+			description: "should reject a GPC subsection type which does not fit",
+			err:         CommonUSGPCSegment{SubsectionType: 4}.Check(),
+			expectedErr: "unable to encode field GPCSegment.SubsectionType: value 4, should be 1",
+			sentinel:    ErrInvalidValue,
+		},
+		{
+			description: "should reject a GPC subsection type other than 1",
+			err:         CommonUSGPCSegment{SubsectionType: 0}.Check(),
+			expectedErr: "unable to encode field GPCSegment.SubsectionType: value 0, should be 1",
+			sentinel:    ErrInvalidValue,
+		},
+	}
+
+	for _, test := range testData {
+		if test.expectedErr == "" {
+			assert.Nil(t, test.err, test.description)
+			continue
+		}
+		assert.EqualError(t, test.err, test.expectedErr, test.description)
+		assert.True(t, errors.Is(test.err, test.sentinel), test.description)
+	}
+}
+
+func TestCheckUSCoreSegment(t *testing.T) {
+	valid := func() CommonUSCoreSegment {
+		return CommonUSCoreSegment{
+			Version:                         1,
+			SaleOptOut:                      2,
+			SensitiveDataProcessing:         []byte{0, 1, 2},
+			KnownChildSensitiveDataConsents: []byte{1},
+		}
+	}
+
+	testData := []struct {
+		description string
+		change      func(segment *CommonUSCoreSegment)
+		expectedErr string
+	}{
+		{
+			description: "should accept the values 0, 1 and 2",
+			change:      func(segment *CommonUSCoreSegment) {},
+		},
+		{
+			description: "should reject the reserved value of a notice",
+			change:      func(segment *CommonUSCoreSegment) { segment.SaleOptOutNotice = 3 },
+			expectedErr: "unable to encode field CoreSegment.SaleOptOutNotice: value 3 is reserved, should be 0, 1 or 2",
+		},
+		{
+			description: "should reject the reserved value of an opt-out",
+			change:      func(segment *CommonUSCoreSegment) { segment.TargetedAdvertisingOptOut = 3 },
+			expectedErr: "unable to encode field CoreSegment.TargetedAdvertisingOptOut: value 3 is reserved, should be 0, 1 or 2",
+		},
+		{
+			description: "should reject an unsupported version",
+			change:      func(segment *CommonUSCoreSegment) { segment.Version = 2 },
+			expectedErr: "unable to encode field CoreSegment.Version: version 2 is not supported, should be 1",
+		},
+		{
+			description: "should reject a value wider than 2 bits",
+			change:      func(segment *CommonUSCoreSegment) { segment.MspaServiceProviderMode = 7 },
+			expectedErr: "unable to encode field CoreSegment.MspaServiceProviderMode: value 7 does not fit in 2 bits",
+		},
+		{
+			description: "should reject an array of the wrong length",
+			change:      func(segment *CommonUSCoreSegment) { segment.SensitiveDataProcessing = []byte{0, 1} },
+			expectedErr: "unable to encode field CoreSegment.SensitiveDataProcessing: has 2 values, should have 3",
+		},
+		{
+			description: "should reject an array value wider than 2 bits",
+			change:      func(segment *CommonUSCoreSegment) { segment.KnownChildSensitiveDataConsents[0] = 4 },
+			expectedErr: "unable to encode field CoreSegment.KnownChildSensitiveDataConsents[1]: value 4 does not fit in 2 bits",
+		},
+	}
+
+	for _, test := range testData {
+		segment := valid()
+		test.change(&segment)
+		err := CheckUSCoreSegment(segment, 3, 1)
+		if test.expectedErr == "" {
+			assert.Nil(t, err, test.description)
+		} else {
+			assert.EqualError(t, err, test.expectedErr, test.description)
+		}
+	}
+}
+
+func TestOptimizedRangeIDs(t *testing.T) {
+	sparse, err := NewOptimizedRangeFromIDs(util.NewIDSet(2, 900))
+	assert.Nil(t, err)
+	assert.True(t, sparse.IsRangeEncoding)
+	assert.Equal(t, uint16(900), sparse.MaxID)
+	assert.True(t, sparse.IsSet(900))
+	assert.Equal(t, []uint16{2, 900}, sparse.IDs().IDs())
+
+	dense, err := NewOptimizedRangeFromIDs(util.NewIDSet(1, 3, 4))
+	assert.Nil(t, err)
+	assert.False(t, dense.IsRangeEncoding)
+	assert.Equal(t, []bool{true, false, true, true}, dense.BitField)
+	assert.Equal(t, []uint16{1, 3, 4}, dense.IDs().IDs())
+
+	empty, err := NewOptimizedRangeFromIDs(util.IDSet{})
+	assert.Nil(t, err)
+	assert.Equal(t, OptimizedRange{BitField: []bool{}}, empty)
+	assert.Equal(t, 1, OptimizedRange{MaxID: 1, BitField: []bool{true, true}}.IDs().Len())
+
+	overlapping := OptimizedRange{MaxID: 100, IsRangeEncoding: true, Range: &util.IntRange{Size: 4095}}
+	for i := 0; i < 4095; i++ {
+		overlapping.Range.Range = append(overlapping.Range.Range, util.IRange{StartID: 1, EndID: 65535})
+	}
+	assert.Equal(t, 100, overlapping.IDs().Len())
+	assert.Equal(t, uint16(100), overlapping.IDs().Max())
+}
@@ -0,0 +1,264 @@
+package sections
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/prebid/go-gpp/constants"
+)
+
+// Names of the values of the US notice, opt-out, consent and MSPA fields in JSON. Values without a name
+// are written as numbers.
+var (
+	noticeValues  = []string{"not_applicable", "provided", "not_provided"}
+	optOutValues  = []string{"not_applicable", "opted_out", "did_not_opt_out"}
+	consentValues = []string{"not_applicable", "no_consent", "consent"}
+	mspaValues    = []string{"not_applicable", "yes", "no"}
+)
+
+var (
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	sectionIDType       = reflect.TypeOf(constants.SectionID(0))
+)
+
+// MarshalJSONFields marshals a section or segment struct with snake_case keys. Byte fields of the US
+// sections holding a notice, opt-out, consent or MSPA value are written as names such as "opted_out",
+// byte arrays as arrays of numbers, and a SectionID field is followed by the section name. It is used
+// by the MarshalJSON methods of the sections.
+func MarshalJSONFields(v interface{}) ([]byte, error) {
+	return marshalStruct(reflect.ValueOf(v))
+}
+
+// UnmarshalJSONFields is the inverse of MarshalJSONFields. v must be a pointer to a struct. Enum fields
+// accept either the name or the number of a value.
+func UnmarshalJSONFields(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot unmarshal JSON fields into %T", v)
+	}
+	return unmarshalStruct(data, rv.Elem())
+}
+
+func marshalStruct(v reflect.Value) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	t := v.Type()
+	first := true
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		value, err := marshalValue(field.Name, v.Field(i))
+		if err != nil {
+			return nil, err
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		writeKey(&buf, jsonKey(field.Name))
+		buf.Write(value)
+
+		if field.Type == sectionIDType {
+			name, _ := json.Marshal(constants.SectionNamesByID[int(v.Field(i).Int())])
+			buf.WriteByte(',')
+			writeKey(&buf, "section")
+			buf.Write(name)
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func writeKey(buf *bytes.Buffer, key string) {
+	buf.WriteByte('"')
+	buf.WriteString(key)
+	buf.WriteString(`":`)
+}
+
+func marshalValue(name string, v reflect.Value) ([]byte, error) {
+	if v.Type().Implements(jsonMarshalerType) {
+		return json.Marshal(v.Interface())
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return marshalStruct(v)
+	case reflect.Ptr:
+		if v.IsNil() {
+			return []byte("null"), nil
+		}
+		return marshalValue(name, v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			return []byte("null"), nil
+		}
+		elems := make([]json.RawMessage, v.Len())
+		for i := range elems {
+			elem, err := marshalSliceElem(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			elems[i] = elem
+		}
+		return json.Marshal(elems)
+	case reflect.Uint8:
+		if names := enumValues(name); names != nil && int(v.Uint()) < len(names) {
+			return json.Marshal(names[v.Uint()])
+		}
+	}
+	return json.Marshal(v.Interface())
+}
+
+// marshalSliceElem marshals an element of a slice. Elements of byte arrays are numbers, not names.
+func marshalSliceElem(v reflect.Value) ([]byte, error) {
+	if v.Kind() == reflect.Uint8 {
+		return json.Marshal(v.Uint())
+	}
+	return marshalValue("", v)
+}
+
+func unmarshalStruct(data []byte, v reflect.Value) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		raw, ok := fields[jsonKey(field.Name)]
+		if !ok {
+			continue
+		}
+		if err := unmarshalValue(field.Name, raw, v.Field(i)); err != nil {
+			return fmt.Errorf("error unmarshalling field %s: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+func unmarshalValue(name string, raw json.RawMessage, v reflect.Value) error {
+	if reflect.PtrTo(v.Type()).Implements(jsonUnmarshalerType) {
+		return json.Unmarshal(raw, v.Addr().Interface())
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return unmarshalStruct(raw, v)
+	case reflect.Ptr:
+		if string(raw) == "null" {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		elem := reflect.New(v.Type().Elem())
+		if err := unmarshalValue(name, raw, elem.Elem()); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	case reflect.Slice:
+		if string(raw) == "null" {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		var elems []json.RawMessage
+		if err := json.Unmarshal(raw, &elems); err != nil {
+			return err
+		}
+		slice := reflect.MakeSlice(v.Type(), len(elems), len(elems))
+		for i, elem := range elems {
+			if err := unmarshalValue("", elem, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	case reflect.Uint8:
+		var valueName string
+		if names := enumValues(name); names != nil && json.Unmarshal(raw, &valueName) == nil {
+			for i, n := range names {
+				if n == valueName {
+					v.SetUint(uint64(i))
+					return nil
+				}
+			}
+			return fmt.Errorf("invalid value %q", valueName)
+		}
+	}
+	return json.Unmarshal(raw, v.Addr().Interface())
+}
+
+// enumValues returns the names of the values of a US section field, or nil if its values are not named.
+func enumValues(field string) []string {
+	switch {
+	case strings.HasPrefix(field, "Mspa"):
+		return mspaValues
+	case strings.HasSuffix(field, "Notice"):
+		return noticeValues
+	case strings.HasSuffix(field, "OptOut"):
+		return optOutValues
+	case strings.HasSuffix(field, "Consent"), strings.HasSuffix(field, "Consents"):
+		return consentValues
+	}
+	return nil
+}
+
+// jsonKey converts a field name to snake_case, keeping acronyms together: SectionID becomes section_id
+// and GPCSegment gpc_segment.
+func jsonKey(field string) string {
+	runes := []rune(field)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func (segment CommonUSCoreSegment) MarshalJSON() ([]byte, error) {
+	return MarshalJSONFields(segment)
+}
+
+func (segment *CommonUSCoreSegment) UnmarshalJSON(data []byte) error {
+	return UnmarshalJSONFields(data, segment)
+}
+
+func (segment CommonUSProcessingCoreSegment) MarshalJSON() ([]byte, error) {
+	return MarshalJSONFields(segment)
+}
+
+func (segment *CommonUSProcessingCoreSegment) UnmarshalJSON(data []byte) error {
+	return UnmarshalJSONFields(data, segment)
+}
+
+func (segment CommonUSGPCSegment) MarshalJSON() ([]byte, error) {
+	return MarshalJSONFields(segment)
+}
+
+func (segment *CommonUSGPCSegment) UnmarshalJSON(data []byte) error {
+	return UnmarshalJSONFields(data, segment)
+}
+
+func (r OptimizedRange) MarshalJSON() ([]byte, error) {
+	return MarshalJSONFields(r)
+}
+
+func (r *OptimizedRange) UnmarshalJSON(data []byte) error {
+	return UnmarshalJSONFields(data, r)
+}
@@ -0,0 +1,59 @@
+package sections
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONKey(t *testing.T) {
+	testData := map[string]string{
+		"Version":                         "version",
+		"SectionID":                       "section_id",
+		"GPCSegment":                      "gpc_segment",
+		"CmpID":                           "cmp_id",
+		"PublisherCC":                     "publisher_cc",
+		"LSPACoveredTransaction":          "lspa_covered_transaction",
+		"KnownChildSensitiveDataConsents": "known_child_sensitive_data_consents",
+	}
+
+	for field, expected := range testData {
+		assert.Equal(t, expected, jsonKey(field))
+	}
+}
+
+func TestCommonUSCoreSegmentJSON(t *testing.T) {
+	segment := CommonUSCoreSegment{
+		Version:                         1,
+		SharingNotice:                   1,
+		SaleOptOutNotice:                2,
+		TargetedAdvertisingOptOutNotice: 0,
+		SaleOptOut:                      1,
+		TargetedAdvertisingOptOut:       3,
+		SensitiveDataProcessing:         []byte{0, 1, 2},
+		KnownChildSensitiveDataConsents: []byte{},
+		MspaCoveredTransaction:          1,
+		MspaOptOutOptionMode:            2,
+		MspaServiceProviderMode:         0,
+	}
+
+	data, err := json.Marshal(segment)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"version":1,"sharing_notice":"provided","sale_opt_out_notice":"not_provided",`+
+		`"targeted_advertising_opt_out_notice":"not_applicable","sale_opt_out":"opted_out",`+
+		`"targeted_advertising_opt_out":3,"sensitive_data_processing":[0,1,2],"known_child_sensitive_data_consents":[],`+
+		`"mspa_covered_transaction":"yes","mspa_opt_out_option_mode":"no","mspa_service_provider_mode":"not_applicable"}`, string(data))
+
+	var result CommonUSCoreSegment
+	assert.NoError(t, json.Unmarshal(data, &result))
+	assert.Equal(t, segment, result)
+}
+
+func TestCommonUSGPCSegmentJSON(t *testing.T) {
+	var segment CommonUSGPCSegment
+
+	assert.NoError(t, json.Unmarshal([]byte(`{"subsection_type":1,"gpc":true}`), &segment))
+	assert.Equal(t, CommonUSGPCSegment{SubsectionType: 1, Gpc: true}, segment)
+}
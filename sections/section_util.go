@@ -25,13 +25,45 @@ type CommonUSCoreSegment struct {
 	MspaServiceProviderMode         byte
 }
 
+// CommonUSProcessingCoreSegment replicates the structure of the US State consent strings added to the GPP
+// registry from Florida onwards. Compared to CommonUSCoreSegment, the first notice field is a processing
+// notice and an AdditionalDataProcessingConsent field precedes the MSPA fields.
+type CommonUSProcessingCoreSegment struct {
+	Version                         byte
+	ProcessingNotice                byte
+	SaleOptOutNotice                byte
+	TargetedAdvertisingOptOutNotice byte
+	SaleOptOut                      byte
+	TargetedAdvertisingOptOut       byte
+	SensitiveDataProcessing         []byte
+	KnownChildSensitiveDataConsents []byte
+	AdditionalDataProcessingConsent byte
+	MspaCoveredTransaction          byte
+	MspaOptOutOptionMode            byte
+	MspaServiceProviderMode         byte
+}
+
 type CommonUSGPCSegment struct {
 	SubsectionType byte
 	Gpc            bool
 }
 
+// FieldError reports the field of a section which could not be parsed.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("unable to set field %s due to parse error: %s", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
 func ErrorHelper(name string, err error) error {
-	return fmt.Errorf("unable to set field %s due to parse error: %s", name, err.Error())
+	return &FieldError{Field: name, Err: err}
 }
 
 func NewCommonUSCoreSegment(sensitiveDataFields int, knownChildDataFields int, bs *util.BitStream) (CommonUSCoreSegment, error) {
@@ -110,6 +142,88 @@ func (segment CommonUSCoreSegment) Encode(bs *util.BitStream) {
 	bs.WriteByte2(segment.MspaServiceProviderMode)
 }
 
+func NewCommonUSProcessingCoreSegment(sensitiveDataFields int, knownChildDataFields int, bs *util.BitStream) (CommonUSProcessingCoreSegment, error) {
+	var commonUSCore CommonUSProcessingCoreSegment
+	var err error
+
+	commonUSCore.Version, err = bs.ReadByte6()
+	if err != nil {
+		return commonUSCore, ErrorHelper("CoreSegment.Version", err)
+	}
+
+	commonUSCore.ProcessingNotice, err = bs.ReadByte2()
+	if err != nil {
+		return commonUSCore, ErrorHelper("CoreSegment.ProcessingNotice", err)
+	}
+
+	commonUSCore.SaleOptOutNotice, err = bs.ReadByte2()
+	if err != nil {
+		return commonUSCore, ErrorHelper("CoreSegment.SaleOptOutNotice", err)
+	}
+
+	commonUSCore.TargetedAdvertisingOptOutNotice, err = bs.ReadByte2()
+	if err != nil {
+		return commonUSCore, ErrorHelper("CoreSegment.TargetedAdvertisingOptOutNotice", err)
+	}
+
+	commonUSCore.SaleOptOut, err = bs.ReadByte2()
+	if err != nil {
+		return commonUSCore, ErrorHelper("CoreSegment.SaleOptOut", err)
+	}
+
+	commonUSCore.TargetedAdvertisingOptOut, err = bs.ReadByte2()
+	if err != nil {
+		return commonUSCore, ErrorHelper("CoreSegment.TargetedAdvertisingOptOut", err)
+	}
+
+	commonUSCore.SensitiveDataProcessing, err = bs.ReadTwoBitField(sensitiveDataFields)
+	if err != nil {
+		return commonUSCore, ErrorHelper("CoreSegment.SensitiveDataProcessing", err)
+	}
+
+	commonUSCore.KnownChildSensitiveDataConsents, err = bs.ReadTwoBitField(knownChildDataFields)
+	if err != nil {
+		return commonUSCore, ErrorHelper("CoreSegment.KnownChildSensitiveDataConsents", err)
+	}
+
+	commonUSCore.AdditionalDataProcessingConsent, err = bs.ReadByte2()
+	if err != nil {
+		return commonUSCore, ErrorHelper("CoreSegment.AdditionalDataProcessingConsent", err)
+	}
+
+	commonUSCore.MspaCoveredTransaction, err = bs.ReadByte2()
+	if err != nil {
+		return commonUSCore, ErrorHelper("CoreSegment.MspaCoveredTransaction", err)
+	}
+
+	commonUSCore.MspaOptOutOptionMode, err = bs.ReadByte2()
+	if err != nil {
+		return commonUSCore, ErrorHelper("CoreSegment.MspaOptOutOptionMode", err)
+	}
+
+	commonUSCore.MspaServiceProviderMode, err = bs.ReadByte2()
+	if err != nil {
+		return commonUSCore, ErrorHelper("CoreSegment.MspaServiceProviderMode", err)
+	}
+
+	return commonUSCore, nil
+}
+
+func (segment CommonUSProcessingCoreSegment) Encode(bs *util.BitStream) {
+	bs.WriteByte6(segment.Version)
+	bs.WriteByte2(segment.ProcessingNotice)
+	bs.WriteByte2(segment.SaleOptOutNotice)
+	bs.WriteByte2(segment.TargetedAdvertisingOptOutNotice)
+	bs.WriteByte2(segment.SaleOptOut)
+	bs.WriteByte2(segment.TargetedAdvertisingOptOut)
+	bs.WriteTwoBitField(segment.SensitiveDataProcessing)
+	bs.WriteTwoBitField(segment.KnownChildSensitiveDataConsents)
+	bs.WriteByte2(segment.AdditionalDataProcessingConsent)
+	bs.WriteByte2(segment.MspaCoveredTransaction)
+	bs.WriteByte2(segment.MspaOptOutOptionMode)
+	bs.WriteByte2(segment.MspaServiceProviderMode)
+}
+
 func NewCommonUSGPCSegment(bs *util.BitStream) (CommonUSGPCSegment, error) {
 	var commonUSGPC CommonUSGPCSegment
 	var err error
@@ -164,3 +278,40 @@ func CreateBitStreams(encoded string, gpcCheck bool) (*util.BitStream, *util.Bit
 
 	return coreBitStream, nil, nil
 }
+
+// HasNonZeroPadding reports whether a segment of an encoded section has set bits after the last bit read
+// from it. readSegment is called with the index and bit stream of each segment and reads it as the decoder
+// does. Segments which are not valid base64 are ignored, as the decoder has already rejected them.
+func HasNonZeroPadding(encoded string, readSegment func(i int, bs *util.BitStream) error) bool {
+	for i, segment := range strings.Split(encoded, ".") {
+		bs, err := util.NewBitStreamFromBase64(segment)
+		if err != nil {
+			continue
+		}
+		if err := readSegment(i, bs); err != nil {
+			continue
+		}
+		if bs.HasNonZeroRemainder() {
+			return true
+		}
+	}
+	return false
+}
+
+// errSegmentNotRead is returned by a segment reader for a segment which the decoder ignores.
+var errSegmentNotRead = errors.New("segment not read by the decoder")
+
+// HasNonZeroUSPadding is HasNonZeroPadding for a US section, which is read by readCore followed by an
+// optional GPC segment.
+func HasNonZeroUSPadding(encoded string, readCore func(bs *util.BitStream) error) bool {
+	return HasNonZeroPadding(encoded, func(i int, bs *util.BitStream) error {
+		switch i {
+		case 0:
+			return readCore(bs)
+		case 1:
+			_, err := NewCommonUSGPCSegment(bs)
+			return err
+		}
+		return errSegmentNotRead
+	})
+}
@@ -0,0 +1,148 @@
+package sections
+
+import "github.com/prebid/go-gpp/util"
+
+// OptimizedRange is the vendor list encoding used by the TCF sections. The list is written either as a
+// bitfield of MaxID bits or as a Range(Int), whichever the encoder chose, and the chosen representation is
+// kept so that re-encoding produces the same bits.
+type OptimizedRange struct {
+	MaxID           uint16
+	IsRangeEncoding bool
+	BitField        []bool
+	Range           *util.IntRange
+}
+
+// IsSet checks to see if an ID is contained within the vendor list
+func (r OptimizedRange) IsSet(id uint16) bool {
+	if id == 0 || id > r.MaxID {
+		return false
+	}
+	if r.IsRangeEncoding {
+		return r.Range != nil && r.Range.IsSet(id)
+	}
+	return int(id) <= len(r.BitField) && r.BitField[id-1]
+}
+
+// IDs returns the vendors in the list, leaving out any above MaxID as IsSet does.
+func (r OptimizedRange) IDs() util.IDSet {
+	if r.IsRangeEncoding {
+		if r.Range == nil {
+			return util.IDSet{}
+		}
+		return r.Range.IDSet().UpTo(r.MaxID)
+	}
+	var ids []uint16
+	for i, set := range r.BitField {
+		if set && i < int(r.MaxID) {
+			ids = append(ids, uint16(i+1))
+		}
+	}
+	return util.NewIDSet(ids...)
+}
+
+// NewOptimizedRangeFromIDs returns the vendor list of a set of IDs, in the encoding
+// util.BitStream.WriteOptimizedRange chooses for it.
+func NewOptimizedRangeFromIDs(set util.IDSet) (OptimizedRange, error) {
+	bs := util.NewBitStreamForWrite()
+	bs.WriteOptimizedRange(set)
+	bs.SetPosition(0)
+	return NewOptimizedRange(bs)
+}
+
+// NewOptimizedRange reads an OptimizedRange with util.BitStream.ReadOptimizedRangeHeader and the reader of
+// the encoding it gives, keeping that encoding.
+func NewOptimizedRange(bs *util.BitStream) (OptimizedRange, error) {
+	var optimizedRange OptimizedRange
+	var err error
+
+	optimizedRange.MaxID, optimizedRange.IsRangeEncoding, err = bs.ReadOptimizedRangeHeader()
+	if err != nil {
+		return optimizedRange, err
+	}
+
+	if optimizedRange.IsRangeEncoding {
+		optimizedRange.Range, err = bs.ReadIntRange()
+		if err != nil {
+			return optimizedRange, err
+		}
+		return optimizedRange, nil
+	}
+
+	optimizedRange.BitField, err = ReadBitField(bs, int(optimizedRange.MaxID))
+	if err != nil {
+		return optimizedRange, err
+	}
+	return optimizedRange, nil
+}
+
+func (r OptimizedRange) Encode(bs *util.BitStream) {
+	bs.WriteOptimizedRangeHeader(r.MaxID, r.IsRangeEncoding)
+	if r.IsRangeEncoding {
+		intRange := r.Range
+		if intRange == nil {
+			intRange = &util.IntRange{}
+		}
+		bs.WriteFixedIntRange(intRange)
+		return
+	}
+	WriteBitField(bs, r.BitField)
+}
+
+// ReadBitField reads numFields single bit flags from the bit stream, as a util.BitStream.ReadBitfield.
+func ReadBitField(bs *util.BitStream, numFields int) ([]bool, error) {
+	set, err := bs.ReadBitfield(numFields)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]bool, numFields)
+	for _, id := range set.IDs() {
+		result[id-1] = true
+	}
+	return result, nil
+}
+
+func WriteBitField(bs *util.BitStream, flags []bool) {
+	for _, f := range flags {
+		WriteBool(bs, f)
+	}
+}
+
+// ReadBool reads a single bit flag from the bit stream.
+func ReadBool(bs *util.BitStream) (bool, error) {
+	b, err := bs.ReadByte1()
+	return b == 1, err
+}
+
+func WriteBool(bs *util.BitStream, b bool) {
+	if b {
+		bs.WriteByte1(1)
+	} else {
+		bs.WriteByte1(0)
+	}
+}
+
+// PadTraditionalBase64 pads the bit stream with zeros up to a multiple of 24 bits, which is how the TCF
+// reference implementations pad before base64 encoding, so that every character is fully populated.
+func PadTraditionalBase64(bs *util.BitStream) {
+	for bs.GetPosition()%24 != 0 {
+		bs.WriteByte1(0)
+	}
+}
+
+// ReadSegmentType reads the 3-bit segment type which leads every optional TCF segment.
+func ReadSegmentType(bs *util.BitStream) (byte, error) {
+	high, err := bs.ReadByte2()
+	if err != nil {
+		return 0, err
+	}
+	low, err := bs.ReadByte1()
+	if err != nil {
+		return 0, err
+	}
+	return high<<1 | low, nil
+}
+
+func WriteSegmentType(bs *util.BitStream, segmentType byte) {
+	bs.WriteByte2(segmentType >> 1)
+	bs.WriteByte1(segmentType)
+}
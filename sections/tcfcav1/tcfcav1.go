@@ -0,0 +1,425 @@
+package tcfcav1
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+)
+
+// Segment types of the optional TCF Canada segments. The core segment has no type field.
+const (
+	SegmentTypeDisclosedVendors  byte = 1
+	SegmentTypePublisherPurposes byte = 3
+)
+
+type TCFCAV1CoreSegment struct {
+	Version                      byte
+	Created                      time.Time
+	LastUpdated                  time.Time
+	CmpID                        uint16
+	CmpVersion                   uint16
+	ConsentScreen                byte
+	ConsentLanguage              string
+	VendorListVersion            uint16
+	TcfPolicyVersion             byte
+	UseNonStandardTexts          bool
+	SpecialFeatureExpressConsent []bool
+	PurposesExpressConsent       []bool
+	PurposesImpliedConsent       []bool
+	VendorExpressConsent         sections.OptimizedRange
+	VendorImpliedConsent         sections.OptimizedRange
+}
+
+type TCFCAV1DisclosedVendorsSegment struct {
+	SegmentType      byte
+	DisclosedVendors sections.OptimizedRange
+}
+
+type TCFCAV1PublisherPurposesSegment struct {
+	SegmentType                  byte
+	PubPurposesExpressConsent    []bool
+	PubPurposesImpliedConsent    []bool
+	NumCustomPurposes            byte
+	CustomPurposesExpressConsent []bool
+	CustomPurposesImpliedConsent []bool
+}
+
+// TCFCAV1 holds the core segment and whichever optional segments were present in the consent string.
+// Optional segments which were not present are nil.
+type TCFCAV1 struct {
+	SectionID                constants.SectionID
+	Value                    string
+	CoreSegment              TCFCAV1CoreSegment
+	PublisherPurposesSegment *TCFCAV1PublisherPurposesSegment
+	DisclosedVendorsSegment  *TCFCAV1DisclosedVendorsSegment
+}
+
+func NewTCFCAV1CoreSegment(bs *util.BitStream) (TCFCAV1CoreSegment, error) {
+	var tcfCore TCFCAV1CoreSegment
+	var err error
+
+	tcfCore.Version, err = bs.ReadByte6()
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.Version", err)
+	}
+
+	tcfCore.Created, err = bs.ReadDatetime()
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.Created", err)
+	}
+
+	tcfCore.LastUpdated, err = bs.ReadDatetime()
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.LastUpdated", err)
+	}
+
+	tcfCore.CmpID, err = bs.ReadUInt12()
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.CmpID", err)
+	}
+
+	tcfCore.CmpVersion, err = bs.ReadUInt12()
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.CmpVersion", err)
+	}
+
+	tcfCore.ConsentScreen, err = bs.ReadByte6()
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.ConsentScreen", err)
+	}
+
+	tcfCore.ConsentLanguage, err = bs.ReadString6(2)
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.ConsentLanguage", err)
+	}
+
+	tcfCore.VendorListVersion, err = bs.ReadUInt12()
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.VendorListVersion", err)
+	}
+
+	tcfCore.TcfPolicyVersion, err = bs.ReadByte6()
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.TcfPolicyVersion", err)
+	}
+
+	tcfCore.UseNonStandardTexts, err = sections.ReadBool(bs)
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.UseNonStandardTexts", err)
+	}
+
+	tcfCore.SpecialFeatureExpressConsent, err = sections.ReadBitField(bs, 12)
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.SpecialFeatureExpressConsent", err)
+	}
+
+	tcfCore.PurposesExpressConsent, err = sections.ReadBitField(bs, 24)
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.PurposesExpressConsent", err)
+	}
+
+	tcfCore.PurposesImpliedConsent, err = sections.ReadBitField(bs, 24)
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.PurposesImpliedConsent", err)
+	}
+
+	tcfCore.VendorExpressConsent, err = sections.NewOptimizedRange(bs)
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.VendorExpressConsent", err)
+	}
+
+	tcfCore.VendorImpliedConsent, err = sections.NewOptimizedRange(bs)
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.VendorImpliedConsent", err)
+	}
+
+	return tcfCore, nil
+}
+
+func (segment TCFCAV1CoreSegment) Encode(bs *util.BitStream) {
+	bs.WriteByte6(segment.Version)
+	bs.WriteDatetime(segment.Created)
+	bs.WriteDatetime(segment.LastUpdated)
+	bs.WriteUInt12(segment.CmpID)
+	bs.WriteUInt12(segment.CmpVersion)
+	bs.WriteByte6(segment.ConsentScreen)
+	bs.WriteString6Unchecked(segment.ConsentLanguage)
+	bs.WriteUInt12(segment.VendorListVersion)
+	bs.WriteByte6(segment.TcfPolicyVersion)
+	sections.WriteBool(bs, segment.UseNonStandardTexts)
+	sections.WriteBitField(bs, segment.SpecialFeatureExpressConsent)
+	sections.WriteBitField(bs, segment.PurposesExpressConsent)
+	sections.WriteBitField(bs, segment.PurposesImpliedConsent)
+	segment.VendorExpressConsent.Encode(bs)
+	segment.VendorImpliedConsent.Encode(bs)
+}
+
+// Check returns a sections.EncodeError if the segment cannot be encoded as it is.
+func (segment TCFCAV1CoreSegment) Check() error {
+	return sections.FirstError(
+		sections.CheckVersion("CoreSegment.Version", segment.Version, 2),
+		sections.CheckDatetime("CoreSegment.Created", segment.Created),
+		sections.CheckDatetime("CoreSegment.LastUpdated", segment.LastUpdated),
+		sections.CheckWidth("CoreSegment.CmpID", uint64(segment.CmpID), 12),
+		sections.CheckWidth("CoreSegment.CmpVersion", uint64(segment.CmpVersion), 12),
+		sections.CheckWidth("CoreSegment.ConsentScreen", uint64(segment.ConsentScreen), 6),
+		sections.CheckString6("CoreSegment.ConsentLanguage", segment.ConsentLanguage, 2),
+		sections.CheckWidth("CoreSegment.VendorListVersion", uint64(segment.VendorListVersion), 12),
+		sections.CheckWidth("CoreSegment.TcfPolicyVersion", uint64(segment.TcfPolicyVersion), 6),
+		sections.CheckLength("CoreSegment.SpecialFeatureExpressConsent", len(segment.SpecialFeatureExpressConsent), 12),
+		sections.CheckLength("CoreSegment.PurposesExpressConsent", len(segment.PurposesExpressConsent), 24),
+		sections.CheckLength("CoreSegment.PurposesImpliedConsent", len(segment.PurposesImpliedConsent), 24),
+		segment.VendorExpressConsent.Check("CoreSegment.VendorExpressConsent"),
+		segment.VendorImpliedConsent.Check("CoreSegment.VendorImpliedConsent"),
+	)
+}
+
+func NewTCFCAV1DisclosedVendorsSegment(bs *util.BitStream) (TCFCAV1DisclosedVendorsSegment, error) {
+	var vendorsSegment TCFCAV1DisclosedVendorsSegment
+	var err error
+
+	vendorsSegment.SegmentType, err = sections.ReadSegmentType(bs)
+	if err != nil {
+		return vendorsSegment, sections.ErrorHelper("DisclosedVendorsSegment.SegmentType", err)
+	}
+
+	vendorsSegment.DisclosedVendors, err = sections.NewOptimizedRange(bs)
+	if err != nil {
+		return vendorsSegment, sections.ErrorHelper("DisclosedVendorsSegment.DisclosedVendors", err)
+	}
+
+	return vendorsSegment, nil
+}
+
+func (segment TCFCAV1DisclosedVendorsSegment) Encode(bs *util.BitStream) {
+	sections.WriteSegmentType(bs, segment.SegmentType)
+	segment.DisclosedVendors.Encode(bs)
+}
+
+// Check returns a sections.EncodeError if the segment cannot be encoded as it is.
+func (segment TCFCAV1DisclosedVendorsSegment) Check() error {
+	return sections.FirstError(
+		sections.CheckValue("DisclosedVendorsSegment.SegmentType", segment.SegmentType, SegmentTypeDisclosedVendors),
+		segment.DisclosedVendors.Check("DisclosedVendorsSegment.DisclosedVendors"),
+	)
+}
+
+func NewTCFCAV1PublisherPurposesSegment(bs *util.BitStream) (TCFCAV1PublisherPurposesSegment, error) {
+	var publisherSegment TCFCAV1PublisherPurposesSegment
+	var err error
+
+	publisherSegment.SegmentType, err = sections.ReadSegmentType(bs)
+	if err != nil {
+		return publisherSegment, sections.ErrorHelper("PublisherPurposesSegment.SegmentType", err)
+	}
+
+	publisherSegment.PubPurposesExpressConsent, err = sections.ReadBitField(bs, 24)
+	if err != nil {
+		return publisherSegment, sections.ErrorHelper("PublisherPurposesSegment.PubPurposesExpressConsent", err)
+	}
+
+	publisherSegment.PubPurposesImpliedConsent, err = sections.ReadBitField(bs, 24)
+	if err != nil {
+		return publisherSegment, sections.ErrorHelper("PublisherPurposesSegment.PubPurposesImpliedConsent", err)
+	}
+
+	publisherSegment.NumCustomPurposes, err = bs.ReadByte6()
+	if err != nil {
+		return publisherSegment, sections.ErrorHelper("PublisherPurposesSegment.NumCustomPurposes", err)
+	}
+
+	publisherSegment.CustomPurposesExpressConsent, err = sections.ReadBitField(bs, int(publisherSegment.NumCustomPurposes))
+	if err != nil {
+		return publisherSegment, sections.ErrorHelper("PublisherPurposesSegment.CustomPurposesExpressConsent", err)
+	}
+
+	publisherSegment.CustomPurposesImpliedConsent, err = sections.ReadBitField(bs, int(publisherSegment.NumCustomPurposes))
+	if err != nil {
+		return publisherSegment, sections.ErrorHelper("PublisherPurposesSegment.CustomPurposesImpliedConsent", err)
+	}
+
+	return publisherSegment, nil
+}
+
+func (segment TCFCAV1PublisherPurposesSegment) Encode(bs *util.BitStream) {
+	sections.WriteSegmentType(bs, segment.SegmentType)
+	sections.WriteBitField(bs, segment.PubPurposesExpressConsent)
+	sections.WriteBitField(bs, segment.PubPurposesImpliedConsent)
+	bs.WriteByte6(segment.NumCustomPurposes)
+	sections.WriteBitField(bs, segment.CustomPurposesExpressConsent)
+	sections.WriteBitField(bs, segment.CustomPurposesImpliedConsent)
+}
+
+// Check returns a sections.EncodeError if the segment cannot be encoded as it is.
+func (segment TCFCAV1PublisherPurposesSegment) Check() error {
+	return sections.FirstError(
+		sections.CheckValue("PublisherPurposesSegment.SegmentType", segment.SegmentType, SegmentTypePublisherPurposes),
+		sections.CheckLength("PublisherPurposesSegment.PubPurposesExpressConsent", len(segment.PubPurposesExpressConsent), 24),
+		sections.CheckLength("PublisherPurposesSegment.PubPurposesImpliedConsent", len(segment.PubPurposesImpliedConsent), 24),
+		sections.CheckWidth("PublisherPurposesSegment.NumCustomPurposes", uint64(segment.NumCustomPurposes), 6),
+		sections.CheckLength("PublisherPurposesSegment.CustomPurposesExpressConsent", len(segment.CustomPurposesExpressConsent), int(segment.NumCustomPurposes)),
+		sections.CheckLength("PublisherPurposesSegment.CustomPurposesImpliedConsent", len(segment.CustomPurposesImpliedConsent), int(segment.NumCustomPurposes)),
+	)
+}
+
+func NewTCFCAV1(encoded string) (TCFCAV1, error) {
+	tcfcav1 := TCFCAV1{}
+
+	segments := strings.Split(encoded, ".")
+
+	coreBitStream, err := util.NewBitStreamFromBase64(segments[0])
+	if err != nil {
+		return tcfcav1, err
+	}
+
+	coreSegment, err := NewTCFCAV1CoreSegment(coreBitStream)
+	if err != nil {
+		return tcfcav1, err
+	}
+
+	tcfcav1 = TCFCAV1{
+		SectionID:   constants.SectionTCFCAV1,
+		Value:       encoded,
+		CoreSegment: coreSegment,
+	}
+
+	for _, segment := range segments[1:] {
+		bs, err := util.NewBitStreamFromBase64(segment)
+		if err != nil {
+			return tcfcav1, err
+		}
+
+		// Peek at the segment type and let the segment constructor read it again.
+		segmentType, err := sections.ReadSegmentType(bs)
+		if err != nil {
+			return tcfcav1, sections.ErrorHelper("SegmentType", err)
+		}
+		bs.SetPosition(0)
+
+		switch segmentType {
+		case SegmentTypeDisclosedVendors:
+			disclosedVendors, err := NewTCFCAV1DisclosedVendorsSegment(bs)
+			if err != nil {
+				return tcfcav1, err
+			}
+			tcfcav1.DisclosedVendorsSegment = &disclosedVendors
+		case SegmentTypePublisherPurposes:
+			publisherPurposes, err := NewTCFCAV1PublisherPurposesSegment(bs)
+			if err != nil {
+				return tcfcav1, err
+			}
+			tcfcav1.PublisherPurposesSegment = &publisherPurposes
+		default:
+			return tcfcav1, fmt.Errorf("invalid segment type %d for TCF CA v1 section", segmentType)
+		}
+	}
+
+	return tcfcav1, nil
+}
+
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (tcfcav1 TCFCAV1) HasNonZeroPadding() bool {
+	return sections.HasNonZeroPadding(tcfcav1.Value, func(i int, bs *util.BitStream) error {
+		if i == 0 {
+			_, err := NewTCFCAV1CoreSegment(bs)
+			return err
+		}
+		segmentType, err := sections.ReadSegmentType(bs)
+		if err != nil {
+			return err
+		}
+		bs.SetPosition(0)
+		if segmentType == SegmentTypePublisherPurposes {
+			_, err = NewTCFCAV1PublisherPurposesSegment(bs)
+		} else {
+			_, err = NewTCFCAV1DisclosedVendorsSegment(bs)
+		}
+		return err
+	})
+}
+
+// Encode writes the core segment followed by the publisher purposes and disclosed vendors segments when
+// present. The GPC flag does not apply to TCF and is ignored.
+func (tcfcav1 TCFCAV1) Encode(bool) []byte {
+	bs := util.NewBitStreamForWrite()
+	tcfcav1.CoreSegment.Encode(bs)
+	res := bs.Base64Encode()
+
+	if tcfcav1.PublisherPurposesSegment != nil {
+		bs.Reset()
+		res = append(res, '.')
+		tcfcav1.PublisherPurposesSegment.Encode(bs)
+		res = append(res, bs.Base64Encode()...)
+	}
+	if tcfcav1.DisclosedVendorsSegment != nil {
+		bs.Reset()
+		res = append(res, '.')
+		tcfcav1.DisclosedVendorsSegment.Encode(bs)
+		res = append(res, bs.Base64Encode()...)
+	}
+
+	return res
+}
+
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (tcfcav1 TCFCAV1) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := tcfcav1.CoreSegment.Check(); err != nil {
+		return nil, err
+	}
+	if tcfcav1.PublisherPurposesSegment != nil {
+		if err := tcfcav1.PublisherPurposesSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	if tcfcav1.DisclosedVendorsSegment != nil {
+		if err := tcfcav1.DisclosedVendorsSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return tcfcav1.Encode(gpcIncluded), nil
+}
+
+func (tcfcav1 TCFCAV1) GetID() constants.SectionID {
+	return tcfcav1.SectionID
+}
+
+func (tcfcav1 TCFCAV1) GetValue() string {
+	return tcfcav1.Value
+}
+
+func (segment TCFCAV1CoreSegment) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(segment)
+}
+
+func (segment *TCFCAV1CoreSegment) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, segment)
+}
+
+func (segment TCFCAV1DisclosedVendorsSegment) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(segment)
+}
+
+func (segment *TCFCAV1DisclosedVendorsSegment) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, segment)
+}
+
+func (segment TCFCAV1PublisherPurposesSegment) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(segment)
+}
+
+func (segment *TCFCAV1PublisherPurposesSegment) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, segment)
+}
+
+func (tcfcav1 TCFCAV1) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(tcfcav1)
+}
+
+func (tcfcav1 *TCFCAV1) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, tcfcav1)
+}
@@ -0,0 +1,167 @@
+package tcfcav1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+	"github.com/stretchr/testify/assert"
+)
+
+type tcfcav1TestData struct {
+	description string
+	gppString   string
+	expected    TCFCAV1
+}
+
+func bitField(size int, set ...int) []bool {
+	field := make([]bool, size)
+	for _, i := range set {
+		field[i-1] = true
+	}
+	return field
+}
+
+func TestTCFCAV1(t *testing.T) {
+	testData := []tcfcav1TestData{
+		{
+			description: "should populate TCFCAV1 segments correctly",
+			gppString:   "CPpcCoAPpcCoAPoABABGCyCQAEAAAEAAAAEFABAEEAN8AEAN4A.YAAAAAAAAAA",
+			expected: TCFCAV1{
+				CoreSegment: TCFCAV1CoreSegment{
+					Version:                      2,
+					Created:                      time.Date(2023, time.March, 30, 0, 0, 0, 0, time.UTC),
+					LastUpdated:                  time.Date(2023, time.March, 30, 0, 0, 0, 0, time.UTC),
+					CmpID:                        1000,
+					CmpVersion:                   1,
+					ConsentScreen:                0,
+					ConsentLanguage:              "BG",
+					VendorListVersion:            178,
+					TcfPolicyVersion:             2,
+					UseNonStandardTexts:          false,
+					SpecialFeatureExpressConsent: bitField(12, 1),
+					PurposesExpressConsent:       bitField(24, 3),
+					PurposesImpliedConsent:       bitField(24, 3),
+					VendorExpressConsent: sections.OptimizedRange{
+						MaxID:           130,
+						IsRangeEncoding: true,
+						Range:           &util.IntRange{Size: 1, Range: []util.IRange{{StartID: 130, EndID: 130}}, Max: 130},
+					},
+					VendorImpliedConsent: sections.OptimizedRange{
+						MaxID:           111,
+						IsRangeEncoding: true,
+						Range:           &util.IntRange{Size: 1, Range: []util.IRange{{StartID: 111, EndID: 111}}, Max: 111},
+					},
+				},
+				PublisherPurposesSegment: &TCFCAV1PublisherPurposesSegment{
+					SegmentType:                  SegmentTypePublisherPurposes,
+					PubPurposesExpressConsent:    bitField(24),
+					PubPurposesImpliedConsent:    bitField(24),
+					CustomPurposesExpressConsent: []bool{},
+					CustomPurposesImpliedConsent: []bool{},
+				},
+				SectionID: constants.SectionTCFCAV1,
+				Value:     "CPpcCoAPpcCoAPoABABGCyCQAEAAAEAAAAEFABAEEAN8AEAN4A.YAAAAAAAAAA",
+			},
+		},
+	}
+
+	for _, test := range testData {
+		result, err := NewTCFCAV1(test.gppString)
+		encodedString := string(test.expected.Encode(true))
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, result)
+		assert.Equal(t, constants.SectionTCFCAV1, result.GetID())
+		assert.Equal(t, test.gppString, result.GetValue())
+		assert.Equal(t, test.gppString, encodedString)
+
+		strictEncoded, err := test.expected.EncodeStrict(true)
+		assert.Nil(t, err)
+		assert.Equal(t, test.gppString, string(strictEncoded))
+	}
+}
+
+func TestTCFCAV1OptionalSegments(t *testing.T) {
+	tcf := TCFCAV1{
+		SectionID: constants.SectionTCFCAV1,
+		CoreSegment: TCFCAV1CoreSegment{
+			Version:                      2,
+			Created:                      time.Date(2023, time.March, 30, 0, 0, 0, 0, time.UTC),
+			LastUpdated:                  time.Date(2023, time.March, 30, 0, 0, 0, 0, time.UTC),
+			CmpID:                        1000,
+			CmpVersion:                   1,
+			ConsentLanguage:              "FR",
+			VendorListVersion:            12,
+			TcfPolicyVersion:             2,
+			SpecialFeatureExpressConsent: bitField(12),
+			PurposesExpressConsent:       bitField(24, 1, 2),
+			PurposesImpliedConsent:       bitField(24, 10),
+			VendorExpressConsent:         sections.OptimizedRange{MaxID: 3, BitField: bitField(3, 1, 3)},
+			VendorImpliedConsent:         sections.OptimizedRange{BitField: []bool{}},
+		},
+		PublisherPurposesSegment: &TCFCAV1PublisherPurposesSegment{
+			SegmentType:                  SegmentTypePublisherPurposes,
+			PubPurposesExpressConsent:    bitField(24, 1),
+			PubPurposesImpliedConsent:    bitField(24, 2),
+			NumCustomPurposes:            3,
+			CustomPurposesExpressConsent: bitField(3, 1),
+			CustomPurposesImpliedConsent: bitField(3, 2, 3),
+		},
+		DisclosedVendorsSegment: &TCFCAV1DisclosedVendorsSegment{
+			SegmentType: SegmentTypeDisclosedVendors,
+			DisclosedVendors: sections.OptimizedRange{
+				MaxID:           300,
+				IsRangeEncoding: true,
+				Range:           &util.IntRange{Size: 1, Range: []util.IRange{{StartID: 1, EndID: 300}}, Max: 300},
+			},
+		},
+	}
+
+	encoded := string(tcf.Encode(true))
+	result, err := NewTCFCAV1(encoded)
+
+	assert.Nil(t, err)
+	tcf.Value = encoded
+	assert.Equal(t, tcf, result)
+	assert.True(t, result.CoreSegment.VendorExpressConsent.IsSet(3))
+	assert.False(t, result.CoreSegment.VendorExpressConsent.IsSet(2))
+	assert.True(t, result.DisclosedVendorsSegment.DisclosedVendors.IsSet(150))
+}
+
+func TestTCFCAV1InvalidSegmentType(t *testing.T) {
+	_, err := NewTCFCAV1("CPpcCoAPpcCoAPoABABGCyCQAEAAAEAAAAEFABAEEAN8AEAN4A.QAAA")
+
+	assert.EqualError(t, err, "invalid segment type 2 for TCF CA v1 section")
+}
+
+func TestTCFCAV1EncodeStrict(t *testing.T) {
+	tcf := TCFCAV1{
+		SectionID: constants.SectionTCFCAV1,
+		CoreSegment: TCFCAV1CoreSegment{
+			Version:                      2,
+			Created:                      time.Date(2023, time.March, 30, 0, 0, 0, 0, time.UTC),
+			LastUpdated:                  time.Date(2023, time.March, 30, 0, 0, 0, 0, time.UTC),
+			ConsentLanguage:              "FR",
+			SpecialFeatureExpressConsent: bitField(12),
+			PurposesExpressConsent:       bitField(24),
+			PurposesImpliedConsent:       bitField(24),
+			VendorExpressConsent:         sections.OptimizedRange{BitField: []bool{}},
+			VendorImpliedConsent:         sections.OptimizedRange{BitField: []bool{}},
+		},
+		DisclosedVendorsSegment: &TCFCAV1DisclosedVendorsSegment{
+			SegmentType:      SegmentTypeDisclosedVendors,
+			DisclosedVendors: sections.OptimizedRange{MaxID: 2, BitField: bitField(1)},
+		},
+	}
+
+	_, err := tcf.EncodeStrict(true)
+	assert.EqualError(t, err, "unable to encode field DisclosedVendorsSegment.DisclosedVendors.BitField: has 1 values, should have 2")
+
+	tcf.DisclosedVendorsSegment.DisclosedVendors.BitField = bitField(2, 2)
+	encoded, err := tcf.EncodeStrict(true)
+	assert.Nil(t, err)
+	assert.Equal(t, tcf.Encode(true), encoded)
+}
@@ -0,0 +1,566 @@
+package tcfeu2
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+)
+
+// Segment types of the optional TCF EU v2 segments. The core segment has no type field.
+const (
+	SegmentTypeDisclosedVendors byte = 1
+	SegmentTypeAllowedVendors   byte = 2
+	SegmentTypePublisherTC      byte = 3
+)
+
+type TCFEU2CoreSegment struct {
+	Version                    byte
+	Created                    time.Time
+	LastUpdated                time.Time
+	CmpID                      uint16
+	CmpVersion                 uint16
+	ConsentScreen              byte
+	ConsentLanguage            string
+	VendorListVersion          uint16
+	TcfPolicyVersion           byte
+	IsServiceSpecific          bool
+	UseNonStandardTexts        bool
+	SpecialFeatureOptIns       []bool
+	PurposeConsents            []bool
+	PurposeLegitimateInterests []bool
+	PurposeOneTreatment        bool
+	PublisherCC                string
+	VendorConsents             sections.OptimizedRange
+	VendorLegitimateInterests  sections.OptimizedRange
+	PublisherRestrictions      []PublisherRestriction
+}
+
+type PublisherRestriction struct {
+	PurposeID       byte
+	RestrictionType byte
+	Vendors         *util.IntRange
+}
+
+// TCFEU2VendorsSegment is the layout shared by the disclosed vendors and allowed vendors segments.
+type TCFEU2VendorsSegment struct {
+	SegmentType byte
+	Vendors     sections.OptimizedRange
+}
+
+type TCFEU2PublisherTCSegment struct {
+	SegmentType                       byte
+	PubPurposesConsent                []bool
+	PubPurposesLegitimateInterests    []bool
+	NumCustomPurposes                 byte
+	CustomPurposesConsent             []bool
+	CustomPurposesLegitimateInterests []bool
+}
+
+// TCFEU2 holds the core segment and whichever optional segments were present in the consent string.
+// Optional segments which were not present are nil. SegmentOrder lists the types of the optional segments
+// in the order they were decoded, which the TCF specification leaves free, so that Encode writes them back
+// in the same order.
+type TCFEU2 struct {
+	SectionID               constants.SectionID
+	Value                   string
+	CoreSegment             TCFEU2CoreSegment
+	DisclosedVendorsSegment *TCFEU2VendorsSegment
+	AllowedVendorsSegment   *TCFEU2VendorsSegment
+	PublisherTCSegment      *TCFEU2PublisherTCSegment
+	SegmentOrder            []byte
+}
+
+func NewTCFEU2CoreSegment(bs *util.BitStream) (TCFEU2CoreSegment, error) {
+	var tcfCore TCFEU2CoreSegment
+	var err error
+
+	tcfCore.Version, err = bs.ReadByte6()
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.Version", err)
+	}
+
+	tcfCore.Created, err = bs.ReadDatetime()
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.Created", err)
+	}
+
+	tcfCore.LastUpdated, err = bs.ReadDatetime()
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.LastUpdated", err)
+	}
+
+	tcfCore.CmpID, err = bs.ReadUInt12()
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.CmpID", err)
+	}
+
+	tcfCore.CmpVersion, err = bs.ReadUInt12()
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.CmpVersion", err)
+	}
+
+	tcfCore.ConsentScreen, err = bs.ReadByte6()
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.ConsentScreen", err)
+	}
+
+	tcfCore.ConsentLanguage, err = bs.ReadString6(2)
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.ConsentLanguage", err)
+	}
+
+	tcfCore.VendorListVersion, err = bs.ReadUInt12()
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.VendorListVersion", err)
+	}
+
+	tcfCore.TcfPolicyVersion, err = bs.ReadByte6()
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.TcfPolicyVersion", err)
+	}
+
+	tcfCore.IsServiceSpecific, err = sections.ReadBool(bs)
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.IsServiceSpecific", err)
+	}
+
+	tcfCore.UseNonStandardTexts, err = sections.ReadBool(bs)
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.UseNonStandardTexts", err)
+	}
+
+	tcfCore.SpecialFeatureOptIns, err = sections.ReadBitField(bs, 12)
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.SpecialFeatureOptIns", err)
+	}
+
+	tcfCore.PurposeConsents, err = sections.ReadBitField(bs, 24)
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.PurposeConsents", err)
+	}
+
+	tcfCore.PurposeLegitimateInterests, err = sections.ReadBitField(bs, 24)
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.PurposeLegitimateInterests", err)
+	}
+
+	tcfCore.PurposeOneTreatment, err = sections.ReadBool(bs)
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.PurposeOneTreatment", err)
+	}
+
+	tcfCore.PublisherCC, err = bs.ReadString6(2)
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.PublisherCC", err)
+	}
+
+	tcfCore.VendorConsents, err = sections.NewOptimizedRange(bs)
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.VendorConsents", err)
+	}
+
+	tcfCore.VendorLegitimateInterests, err = sections.NewOptimizedRange(bs)
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.VendorLegitimateInterests", err)
+	}
+
+	tcfCore.PublisherRestrictions, err = newPublisherRestrictions(bs)
+	if err != nil {
+		return tcfCore, sections.ErrorHelper("CoreSegment.PublisherRestrictions", err)
+	}
+
+	return tcfCore, nil
+}
+
+func newPublisherRestrictions(bs *util.BitStream) ([]PublisherRestriction, error) {
+	numRestrictions, err := bs.ReadUInt12()
+	if err != nil {
+		return nil, fmt.Errorf("error reading number of publisher restrictions: %w", err)
+	}
+
+	restrictions := make([]PublisherRestriction, numRestrictions)
+	for i := range restrictions {
+		restrictions[i].PurposeID, err = bs.ReadByte6()
+		if err != nil {
+			return nil, fmt.Errorf("error reading purpose ID of publisher restriction(%d): %w", i, err)
+		}
+		restrictions[i].RestrictionType, err = bs.ReadByte2()
+		if err != nil {
+			return nil, fmt.Errorf("error reading restriction type of publisher restriction(%d): %w", i, err)
+		}
+		restrictions[i].Vendors, err = bs.ReadIntRange()
+		if err != nil {
+			return nil, fmt.Errorf("error reading vendors of publisher restriction(%d): %w", i, err)
+		}
+	}
+
+	return restrictions, nil
+}
+
+func (segment TCFEU2CoreSegment) Encode(bs *util.BitStream) {
+	bs.WriteByte6(segment.Version)
+	bs.WriteDatetime(segment.Created)
+	bs.WriteDatetime(segment.LastUpdated)
+	bs.WriteUInt12(segment.CmpID)
+	bs.WriteUInt12(segment.CmpVersion)
+	bs.WriteByte6(segment.ConsentScreen)
+	bs.WriteString6Unchecked(segment.ConsentLanguage)
+	bs.WriteUInt12(segment.VendorListVersion)
+	bs.WriteByte6(segment.TcfPolicyVersion)
+	sections.WriteBool(bs, segment.IsServiceSpecific)
+	sections.WriteBool(bs, segment.UseNonStandardTexts)
+	sections.WriteBitField(bs, segment.SpecialFeatureOptIns)
+	sections.WriteBitField(bs, segment.PurposeConsents)
+	sections.WriteBitField(bs, segment.PurposeLegitimateInterests)
+	sections.WriteBool(bs, segment.PurposeOneTreatment)
+	bs.WriteString6Unchecked(segment.PublisherCC)
+	segment.VendorConsents.Encode(bs)
+	segment.VendorLegitimateInterests.Encode(bs)
+	bs.WriteUInt12(uint16(len(segment.PublisherRestrictions)))
+	for _, restriction := range segment.PublisherRestrictions {
+		bs.WriteByte6(restriction.PurposeID)
+		bs.WriteByte2(restriction.RestrictionType)
+		vendors := restriction.Vendors
+		if vendors == nil {
+			vendors = &util.IntRange{}
+		}
+		bs.WriteFixedIntRange(vendors)
+	}
+}
+
+// Check returns a sections.EncodeError if the segment cannot be encoded as it is.
+func (segment TCFEU2CoreSegment) Check() error {
+	err := sections.FirstError(
+		sections.CheckVersion("CoreSegment.Version", segment.Version, 2),
+		sections.CheckDatetime("CoreSegment.Created", segment.Created),
+		sections.CheckDatetime("CoreSegment.LastUpdated", segment.LastUpdated),
+		sections.CheckWidth("CoreSegment.CmpID", uint64(segment.CmpID), 12),
+		sections.CheckWidth("CoreSegment.CmpVersion", uint64(segment.CmpVersion), 12),
+		sections.CheckWidth("CoreSegment.ConsentScreen", uint64(segment.ConsentScreen), 6),
+		sections.CheckString6("CoreSegment.ConsentLanguage", segment.ConsentLanguage, 2),
+		sections.CheckWidth("CoreSegment.VendorListVersion", uint64(segment.VendorListVersion), 12),
+		sections.CheckWidth("CoreSegment.TcfPolicyVersion", uint64(segment.TcfPolicyVersion), 6),
+		sections.CheckLength("CoreSegment.SpecialFeatureOptIns", len(segment.SpecialFeatureOptIns), 12),
+		sections.CheckLength("CoreSegment.PurposeConsents", len(segment.PurposeConsents), 24),
+		sections.CheckLength("CoreSegment.PurposeLegitimateInterests", len(segment.PurposeLegitimateInterests), 24),
+		sections.CheckString6("CoreSegment.PublisherCC", segment.PublisherCC, 2),
+		segment.VendorConsents.Check("CoreSegment.VendorConsents"),
+		segment.VendorLegitimateInterests.Check("CoreSegment.VendorLegitimateInterests"),
+		sections.CheckWidth("CoreSegment.PublisherRestrictions", uint64(len(segment.PublisherRestrictions)), 12),
+	)
+	if err != nil {
+		return err
+	}
+	for i, restriction := range segment.PublisherRestrictions {
+		field := fmt.Sprintf("CoreSegment.PublisherRestrictions[%d]", i)
+		err := sections.FirstError(
+			sections.CheckWidth(field+".PurposeID", uint64(restriction.PurposeID), 6),
+			sections.CheckWidth(field+".RestrictionType", uint64(restriction.RestrictionType), 2),
+			sections.CheckIntRange(field+".Vendors", restriction.Vendors),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func NewTCFEU2VendorsSegment(bs *util.BitStream) (TCFEU2VendorsSegment, error) {
+	var vendorsSegment TCFEU2VendorsSegment
+	var err error
+
+	vendorsSegment.SegmentType, err = sections.ReadSegmentType(bs)
+	if err != nil {
+		return vendorsSegment, sections.ErrorHelper("VendorsSegment.SegmentType", err)
+	}
+
+	vendorsSegment.Vendors, err = sections.NewOptimizedRange(bs)
+	if err != nil {
+		return vendorsSegment, sections.ErrorHelper("VendorsSegment.Vendors", err)
+	}
+
+	return vendorsSegment, nil
+}
+
+func (segment TCFEU2VendorsSegment) Encode(bs *util.BitStream) {
+	sections.WriteSegmentType(bs, segment.SegmentType)
+	segment.Vendors.Encode(bs)
+}
+
+// Check returns a sections.EncodeError if the segment cannot be encoded as it is. segmentType is the type
+// of the segment it is used as, SegmentTypeDisclosedVendors or SegmentTypeAllowedVendors.
+func (segment TCFEU2VendorsSegment) Check(field string, segmentType byte) error {
+	return sections.FirstError(
+		sections.CheckValue(field+".SegmentType", segment.SegmentType, segmentType),
+		segment.Vendors.Check(field+".Vendors"),
+	)
+}
+
+func NewTCFEU2PublisherTCSegment(bs *util.BitStream) (TCFEU2PublisherTCSegment, error) {
+	var publisherSegment TCFEU2PublisherTCSegment
+	var err error
+
+	publisherSegment.SegmentType, err = sections.ReadSegmentType(bs)
+	if err != nil {
+		return publisherSegment, sections.ErrorHelper("PublisherTCSegment.SegmentType", err)
+	}
+
+	publisherSegment.PubPurposesConsent, err = sections.ReadBitField(bs, 24)
+	if err != nil {
+		return publisherSegment, sections.ErrorHelper("PublisherTCSegment.PubPurposesConsent", err)
+	}
+
+	publisherSegment.PubPurposesLegitimateInterests, err = sections.ReadBitField(bs, 24)
+	if err != nil {
+		return publisherSegment, sections.ErrorHelper("PublisherTCSegment.PubPurposesLegitimateInterests", err)
+	}
+
+	publisherSegment.NumCustomPurposes, err = bs.ReadByte6()
+	if err != nil {
+		return publisherSegment, sections.ErrorHelper("PublisherTCSegment.NumCustomPurposes", err)
+	}
+
+	publisherSegment.CustomPurposesConsent, err = sections.ReadBitField(bs, int(publisherSegment.NumCustomPurposes))
+	if err != nil {
+		return publisherSegment, sections.ErrorHelper("PublisherTCSegment.CustomPurposesConsent", err)
+	}
+
+	publisherSegment.CustomPurposesLegitimateInterests, err = sections.ReadBitField(bs, int(publisherSegment.NumCustomPurposes))
+	if err != nil {
+		return publisherSegment, sections.ErrorHelper("PublisherTCSegment.CustomPurposesLegitimateInterests", err)
+	}
+
+	return publisherSegment, nil
+}
+
+func (segment TCFEU2PublisherTCSegment) Encode(bs *util.BitStream) {
+	sections.WriteSegmentType(bs, segment.SegmentType)
+	sections.WriteBitField(bs, segment.PubPurposesConsent)
+	sections.WriteBitField(bs, segment.PubPurposesLegitimateInterests)
+	bs.WriteByte6(segment.NumCustomPurposes)
+	sections.WriteBitField(bs, segment.CustomPurposesConsent)
+	sections.WriteBitField(bs, segment.CustomPurposesLegitimateInterests)
+}
+
+// Check returns a sections.EncodeError if the segment cannot be encoded as it is.
+func (segment TCFEU2PublisherTCSegment) Check() error {
+	return sections.FirstError(
+		sections.CheckValue("PublisherTCSegment.SegmentType", segment.SegmentType, SegmentTypePublisherTC),
+		sections.CheckLength("PublisherTCSegment.PubPurposesConsent", len(segment.PubPurposesConsent), 24),
+		sections.CheckLength("PublisherTCSegment.PubPurposesLegitimateInterests", len(segment.PubPurposesLegitimateInterests), 24),
+		sections.CheckWidth("PublisherTCSegment.NumCustomPurposes", uint64(segment.NumCustomPurposes), 6),
+		sections.CheckLength("PublisherTCSegment.CustomPurposesConsent", len(segment.CustomPurposesConsent), int(segment.NumCustomPurposes)),
+		sections.CheckLength("PublisherTCSegment.CustomPurposesLegitimateInterests", len(segment.CustomPurposesLegitimateInterests), int(segment.NumCustomPurposes)),
+	)
+}
+
+func NewTCFEU2(encoded string) (TCFEU2, error) {
+	tcfeu2 := TCFEU2{}
+
+	segments := strings.Split(encoded, ".")
+
+	coreBitStream, err := util.NewBitStreamFromBase64(segments[0])
+	if err != nil {
+		return tcfeu2, err
+	}
+
+	coreSegment, err := NewTCFEU2CoreSegment(coreBitStream)
+	if err != nil {
+		return tcfeu2, err
+	}
+
+	tcfeu2 = TCFEU2{
+		SectionID:   constants.SectionTCFEU2,
+		Value:       encoded,
+		CoreSegment: coreSegment,
+	}
+
+	for _, segment := range segments[1:] {
+		bs, err := util.NewBitStreamFromBase64(segment)
+		if err != nil {
+			return tcfeu2, err
+		}
+
+		// The segment type is the first 3 bits of every optional segment. Peek at it and let the segment
+		// constructor read it again.
+		segmentType, err := sections.ReadSegmentType(bs)
+		if err != nil {
+			return tcfeu2, sections.ErrorHelper("SegmentType", err)
+		}
+		bs.SetPosition(0)
+
+		switch segmentType {
+		case SegmentTypeDisclosedVendors:
+			disclosedVendors, err := NewTCFEU2VendorsSegment(bs)
+			if err != nil {
+				return tcfeu2, err
+			}
+			tcfeu2.DisclosedVendorsSegment = &disclosedVendors
+		case SegmentTypeAllowedVendors:
+			allowedVendors, err := NewTCFEU2VendorsSegment(bs)
+			if err != nil {
+				return tcfeu2, err
+			}
+			tcfeu2.AllowedVendorsSegment = &allowedVendors
+		case SegmentTypePublisherTC:
+			publisherTC, err := NewTCFEU2PublisherTCSegment(bs)
+			if err != nil {
+				return tcfeu2, err
+			}
+			tcfeu2.PublisherTCSegment = &publisherTC
+		default:
+			return tcfeu2, fmt.Errorf("invalid segment type %d for TCF EU v2 section", segmentType)
+		}
+		if !hasSegmentType(tcfeu2.SegmentOrder, segmentType) {
+			tcfeu2.SegmentOrder = append(tcfeu2.SegmentOrder, segmentType)
+		}
+	}
+
+	return tcfeu2, nil
+}
+
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (tcfeu2 TCFEU2) HasNonZeroPadding() bool {
+	return sections.HasNonZeroPadding(tcfeu2.Value, func(i int, bs *util.BitStream) error {
+		if i == 0 {
+			_, err := NewTCFEU2CoreSegment(bs)
+			return err
+		}
+		segmentType, err := sections.ReadSegmentType(bs)
+		if err != nil {
+			return err
+		}
+		bs.SetPosition(0)
+		if segmentType == SegmentTypePublisherTC {
+			_, err = NewTCFEU2PublisherTCSegment(bs)
+		} else {
+			_, err = NewTCFEU2VendorsSegment(bs)
+		}
+		return err
+	})
+}
+
+// Encode writes the core segment followed by the optional segments which are present, in the order of
+// SegmentOrder. Segments missing from SegmentOrder come after it, in the order publisher TC, allowed vendors
+// and disclosed vendors used by the IAB reference implementation. The GPC flag does not apply to TCF and is
+// ignored.
+func (tcfeu2 TCFEU2) Encode(bool) []byte {
+	bs := util.NewBitStreamForWrite()
+	tcfeu2.CoreSegment.Encode(bs)
+	sections.PadTraditionalBase64(bs)
+	res := bs.Base64Encode()
+
+	order := append([]byte{}, tcfeu2.SegmentOrder...)
+	for _, segmentType := range []byte{SegmentTypePublisherTC, SegmentTypeAllowedVendors, SegmentTypeDisclosedVendors} {
+		if !hasSegmentType(order, segmentType) {
+			order = append(order, segmentType)
+		}
+	}
+	for _, segmentType := range order {
+		bs.Reset()
+		switch {
+		case segmentType == SegmentTypePublisherTC && tcfeu2.PublisherTCSegment != nil:
+			tcfeu2.PublisherTCSegment.Encode(bs)
+		case segmentType == SegmentTypeAllowedVendors && tcfeu2.AllowedVendorsSegment != nil:
+			tcfeu2.AllowedVendorsSegment.Encode(bs)
+		case segmentType == SegmentTypeDisclosedVendors && tcfeu2.DisclosedVendorsSegment != nil:
+			tcfeu2.DisclosedVendorsSegment.Encode(bs)
+		default:
+			continue
+		}
+		res = appendSegment(res, bs)
+	}
+
+	return res
+}
+
+func hasSegmentType(order []byte, segmentType byte) bool {
+	for _, t := range order {
+		if t == segmentType {
+			return true
+		}
+	}
+	return false
+}
+
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (tcfeu2 TCFEU2) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := tcfeu2.CoreSegment.Check(); err != nil {
+		return nil, err
+	}
+	if tcfeu2.PublisherTCSegment != nil {
+		if err := tcfeu2.PublisherTCSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	if tcfeu2.AllowedVendorsSegment != nil {
+		if err := tcfeu2.AllowedVendorsSegment.Check("AllowedVendorsSegment", SegmentTypeAllowedVendors); err != nil {
+			return nil, err
+		}
+	}
+	if tcfeu2.DisclosedVendorsSegment != nil {
+		if err := tcfeu2.DisclosedVendorsSegment.Check("DisclosedVendorsSegment", SegmentTypeDisclosedVendors); err != nil {
+			return nil, err
+		}
+	}
+	return tcfeu2.Encode(gpcIncluded), nil
+}
+
+func appendSegment(res []byte, bs *util.BitStream) []byte {
+	sections.PadTraditionalBase64(bs)
+	res = append(res, '.')
+	return append(res, bs.Base64Encode()...)
+}
+
+func (tcfeu2 TCFEU2) GetID() constants.SectionID {
+	return tcfeu2.SectionID
+}
+
+func (tcfeu2 TCFEU2) GetValue() string {
+	return tcfeu2.Value
+}
+
+func (segment TCFEU2CoreSegment) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(segment)
+}
+
+func (segment *TCFEU2CoreSegment) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, segment)
+}
+
+func (restriction PublisherRestriction) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(restriction)
+}
+
+func (restriction *PublisherRestriction) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, restriction)
+}
+
+func (segment TCFEU2VendorsSegment) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(segment)
+}
+
+func (segment *TCFEU2VendorsSegment) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, segment)
+}
+
+func (segment TCFEU2PublisherTCSegment) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(segment)
+}
+
+func (segment *TCFEU2PublisherTCSegment) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, segment)
+}
+
+func (tcfeu2 TCFEU2) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(tcfeu2)
+}
+
+func (tcfeu2 *TCFEU2) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, tcfeu2)
+}
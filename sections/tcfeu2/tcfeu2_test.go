@@ -0,0 +1,313 @@
+package tcfeu2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+	"github.com/stretchr/testify/assert"
+)
+
+type tcfeu2TestData struct {
+	description string
+	gppString   string
+	expected    TCFEU2
+}
+
+func bitField(size int, set ...int) []bool {
+	field := make([]bool, size)
+	for _, i := range set {
+		field[i-1] = true
+	}
+	return field
+}
+
+func TestTCFEU2(t *testing.T) {
+	testData := []tcfeu2TestData{
+		{
+			description: "should populate TCFEU2 core segment correctly",
+			gppString:   "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA",
+			expected: TCFEU2{
+				CoreSegment: TCFEU2CoreSegment{
+					Version:                    2,
+					Created:                    time.Date(2022, time.April, 20, 22, 0, 0, 0, time.UTC),
+					LastUpdated:                time.Date(2022, time.April, 20, 22, 0, 0, 0, time.UTC),
+					CmpID:                      31,
+					CmpVersion:                 640,
+					ConsentScreen:              1,
+					ConsentLanguage:            "EN",
+					VendorListVersion:          126,
+					TcfPolicyVersion:           2,
+					IsServiceSpecific:          true,
+					UseNonStandardTexts:        false,
+					SpecialFeatureOptIns:       bitField(12),
+					PurposeConsents:            bitField(24),
+					PurposeLegitimateInterests: bitField(24),
+					PurposeOneTreatment:        false,
+					PublisherCC:                "DE",
+					VendorConsents:             sections.OptimizedRange{BitField: []bool{}},
+					VendorLegitimateInterests:  sections.OptimizedRange{BitField: []bool{}},
+					PublisherRestrictions:      []PublisherRestriction{},
+				},
+				SectionID: constants.SectionTCFEU2,
+				Value:     "CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA",
+			},
+		},
+		{
+			description: "should populate TCFEU2 optional segments correctly",
+			gppString:   "CPpcCoAPpcCoAPoABABGCyCUACAAACAAAAAAAVQAQAVABZABABYAAAAA.QADgIAAA.IABE",
+			expected: TCFEU2{
+				CoreSegment: TCFEU2CoreSegment{
+					Version:                    2,
+					Created:                    time.Date(2023, time.March, 30, 0, 0, 0, 0, time.UTC),
+					LastUpdated:                time.Date(2023, time.March, 30, 0, 0, 0, 0, time.UTC),
+					CmpID:                      1000,
+					CmpVersion:                 1,
+					ConsentScreen:              0,
+					ConsentLanguage:            "BG",
+					VendorListVersion:          178,
+					TcfPolicyVersion:           2,
+					IsServiceSpecific:          false,
+					UseNonStandardTexts:        true,
+					SpecialFeatureOptIns:       bitField(12, 2),
+					PurposeConsents:            bitField(24, 3),
+					PurposeLegitimateInterests: bitField(24, 3),
+					PurposeOneTreatment:        false,
+					PublisherCC:                "AA",
+					VendorConsents: sections.OptimizedRange{
+						MaxID:           42,
+						IsRangeEncoding: true,
+						Range:           &util.IntRange{Size: 1, Range: []util.IRange{{StartID: 42, EndID: 42}}, Max: 42},
+					},
+					VendorLegitimateInterests: sections.OptimizedRange{
+						MaxID:           44,
+						IsRangeEncoding: true,
+						Range:           &util.IntRange{Size: 1, Range: []util.IRange{{StartID: 44, EndID: 44}}, Max: 44},
+					},
+					PublisherRestrictions: []PublisherRestriction{},
+				},
+				AllowedVendorsSegment: &TCFEU2VendorsSegment{
+					SegmentType: SegmentTypeAllowedVendors,
+					Vendors:     sections.OptimizedRange{MaxID: 7, BitField: bitField(7, 7)},
+				},
+				DisclosedVendorsSegment: &TCFEU2VendorsSegment{
+					SegmentType: SegmentTypeDisclosedVendors,
+					Vendors:     sections.OptimizedRange{MaxID: 2, BitField: bitField(2, 2)},
+				},
+				SegmentOrder: []byte{SegmentTypeAllowedVendors, SegmentTypeDisclosedVendors},
+				SectionID:    constants.SectionTCFEU2,
+				Value:        "CPpcCoAPpcCoAPoABABGCyCUACAAACAAAAAAAVQAQAVABZABABYAAAAA.QADgIAAA.IABE",
+			},
+		},
+	}
+
+	for _, test := range testData {
+		result, err := NewTCFEU2(test.gppString)
+		encodedString := string(test.expected.Encode(true))
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, result)
+		assert.Equal(t, constants.SectionTCFEU2, result.GetID())
+		assert.Equal(t, test.gppString, result.GetValue())
+		assert.Equal(t, test.gppString, encodedString)
+
+		strictEncoded, err := test.expected.EncodeStrict(true)
+		assert.Nil(t, err)
+		assert.Equal(t, test.gppString, string(strictEncoded))
+	}
+}
+
+func TestTCFEU2PublisherSegments(t *testing.T) {
+	tcf := TCFEU2{
+		SectionID: constants.SectionTCFEU2,
+		CoreSegment: TCFEU2CoreSegment{
+			Version:                    2,
+			Created:                    time.Date(2023, time.March, 30, 0, 0, 0, 0, time.UTC),
+			LastUpdated:                time.Date(2023, time.March, 30, 0, 0, 0, 0, time.UTC),
+			CmpID:                      1000,
+			CmpVersion:                 1,
+			ConsentLanguage:            "EN",
+			VendorListVersion:          178,
+			TcfPolicyVersion:           4,
+			IsServiceSpecific:          true,
+			SpecialFeatureOptIns:       bitField(12, 1),
+			PurposeConsents:            bitField(24, 1, 2, 3),
+			PurposeLegitimateInterests: bitField(24, 2),
+			PublisherCC:                "FR",
+			VendorConsents:             sections.OptimizedRange{MaxID: 5, BitField: bitField(5, 1, 5)},
+			VendorLegitimateInterests:  sections.OptimizedRange{BitField: []bool{}},
+			PublisherRestrictions: []PublisherRestriction{
+				{
+					PurposeID:       2,
+					RestrictionType: 1,
+					Vendors:         &util.IntRange{Size: 2, Range: []util.IRange{{StartID: 3, EndID: 3}, {StartID: 10, EndID: 12}}, Max: 12},
+				},
+			},
+		},
+		PublisherTCSegment: &TCFEU2PublisherTCSegment{
+			SegmentType:                       SegmentTypePublisherTC,
+			PubPurposesConsent:                bitField(24, 1),
+			PubPurposesLegitimateInterests:    bitField(24, 2, 4),
+			NumCustomPurposes:                 2,
+			CustomPurposesConsent:             bitField(2, 2),
+			CustomPurposesLegitimateInterests: bitField(2, 1),
+		},
+	}
+
+	encoded := string(tcf.Encode(true))
+	result, err := NewTCFEU2(encoded)
+
+	assert.Nil(t, err)
+	tcf.Value = encoded
+	tcf.SegmentOrder = []byte{SegmentTypePublisherTC}
+	assert.Equal(t, tcf, result)
+	assert.True(t, result.CoreSegment.VendorConsents.IsSet(5))
+	assert.False(t, result.CoreSegment.VendorConsents.IsSet(4))
+	assert.True(t, result.CoreSegment.PublisherRestrictions[0].Vendors.IsSet(11))
+}
+
+func TestTCFEU2SegmentOrder(t *testing.T) {
+	// The TCF specification lets the optional segments come in any order, so the disclosed vendors segment
+	// before the allowed vendors one must round-trip unchanged.
+	reordered := "CPpcCoAPpcCoAPoABABGCyCUACAAACAAAAAAAVQAQAVABZABABYAAAAA.IABE.QADgIAAA"
+
+	result, err := NewTCFEU2(reordered)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{SegmentTypeDisclosedVendors, SegmentTypeAllowedVendors}, result.SegmentOrder)
+	assert.Equal(t, reordered, string(result.Encode(true)))
+
+	// Segments added after decoding come after those which were decoded.
+	result.PublisherTCSegment = &TCFEU2PublisherTCSegment{
+		SegmentType:                    SegmentTypePublisherTC,
+		PubPurposesConsent:             bitField(24),
+		PubPurposesLegitimateInterests: bitField(24),
+	}
+	assert.Equal(t, reordered+".YAAAAAAAAAAA", string(result.Encode(true)))
+}
+
+func TestTCFEU2LargeVendorBitFields(t *testing.T) {
+	// Two bitfields of 40000 vendors take the core segment past 8 KB, where 16-bit positions used to wrap.
+	tcf := TCFEU2{
+		SectionID: constants.SectionTCFEU2,
+		CoreSegment: TCFEU2CoreSegment{
+			Version:                    2,
+			Created:                    time.Date(2023, time.March, 30, 0, 0, 0, 0, time.UTC),
+			LastUpdated:                time.Date(2023, time.March, 30, 0, 0, 0, 0, time.UTC),
+			ConsentLanguage:            "EN",
+			SpecialFeatureOptIns:       bitField(12),
+			PurposeConsents:            bitField(24),
+			PurposeLegitimateInterests: bitField(24),
+			PublisherCC:                "FR",
+			VendorConsents:             sections.OptimizedRange{MaxID: 40000, BitField: bitField(40000, 1, 39999)},
+			VendorLegitimateInterests:  sections.OptimizedRange{MaxID: 40000, BitField: bitField(40000, 2, 40000)},
+			PublisherRestrictions:      []PublisherRestriction{},
+		},
+	}
+
+	encoded := string(tcf.Encode(true))
+	result, err := NewTCFEU2(encoded)
+
+	assert.Nil(t, err)
+	tcf.Value = encoded
+	assert.Equal(t, tcf, result)
+	assert.True(t, result.CoreSegment.VendorLegitimateInterests.IsSet(40000))
+	assert.False(t, result.CoreSegment.VendorLegitimateInterests.IsSet(39999))
+}
+
+func TestTCFEU2InvalidSegmentType(t *testing.T) {
+	_, err := NewTCFEU2("CPXxRfAPXxRfAAfKABENB-CgAAAAAAAAAAYgAAAAAAAA.AAAA")
+
+	assert.EqualError(t, err, "invalid segment type 0 for TCF EU v2 section")
+}
+
+func TestTCFEU2EncodeStrict(t *testing.T) {
+	valid := func() TCFEU2 {
+		return TCFEU2{
+			SectionID: constants.SectionTCFEU2,
+			CoreSegment: TCFEU2CoreSegment{
+				Version:                    2,
+				Created:                    time.Date(2023, time.March, 30, 0, 0, 0, 0, time.UTC),
+				LastUpdated:                time.Date(2023, time.March, 30, 0, 0, 0, 0, time.UTC),
+				ConsentLanguage:            "EN",
+				SpecialFeatureOptIns:       bitField(12),
+				PurposeConsents:            bitField(24),
+				PurposeLegitimateInterests: bitField(24),
+				PublisherCC:                "FR",
+				VendorConsents:             sections.OptimizedRange{BitField: []bool{}},
+				VendorLegitimateInterests:  sections.OptimizedRange{BitField: []bool{}},
+			},
+			PublisherTCSegment: &TCFEU2PublisherTCSegment{
+				SegmentType:                    SegmentTypePublisherTC,
+				PubPurposesConsent:             bitField(24),
+				PubPurposesLegitimateInterests: bitField(24),
+			},
+			AllowedVendorsSegment: &TCFEU2VendorsSegment{
+				SegmentType: SegmentTypeAllowedVendors,
+				Vendors:     sections.OptimizedRange{MaxID: 1, BitField: bitField(1, 1)},
+			},
+		}
+	}
+
+	testData := []struct {
+		description string
+		change      func(tcf *TCFEU2)
+		expectedErr string
+	}{
+		{
+			description: "should encode a valid section",
+			change:      func(tcf *TCFEU2) {},
+		},
+		{
+			description: "should reject an unsupported version",
+			change:      func(tcf *TCFEU2) { tcf.CoreSegment.Version = 3 },
+			expectedErr: "unable to encode field CoreSegment.Version: version 3 is not supported, should be 2",
+		},
+		{
+			description: "should reject a CMP ID wider than 12 bits",
+			change:      func(tcf *TCFEU2) { tcf.CoreSegment.CmpID = 5000 },
+			expectedErr: "unable to encode field CoreSegment.CmpID: value 5000 does not fit in 12 bits",
+		},
+		{
+			description: "should reject a lowercase language",
+			change:      func(tcf *TCFEU2) { tcf.CoreSegment.ConsentLanguage = "en" },
+			expectedErr: "unable to encode field CoreSegment.ConsentLanguage: character 'e' at 0, should be A to Z: invalid character",
+		},
+		{
+			description: "should reject purposes of the wrong length",
+			change:      func(tcf *TCFEU2) { tcf.CoreSegment.PurposeConsents = bitField(10) },
+			expectedErr: "unable to encode field CoreSegment.PurposeConsents: has 10 values, should have 24",
+		},
+		{
+			description: "should reject a restriction type wider than 2 bits",
+			change: func(tcf *TCFEU2) {
+				tcf.CoreSegment.PublisherRestrictions = []PublisherRestriction{{PurposeID: 1, RestrictionType: 4}}
+			},
+			expectedErr: "unable to encode field CoreSegment.PublisherRestrictions[0].RestrictionType: value 4 does not fit in 2 bits",
+		},
+		{
+			description: "should reject custom purposes which do not match their number",
+			change:      func(tcf *TCFEU2) { tcf.PublisherTCSegment.NumCustomPurposes = 2 },
+			expectedErr: "unable to encode field PublisherTCSegment.CustomPurposesConsent: has 0 values, should have 2",
+		},
+		{
+			description: "should reject a segment of the wrong type",
+			change:      func(tcf *TCFEU2) { tcf.AllowedVendorsSegment.SegmentType = SegmentTypeDisclosedVendors },
+			expectedErr: "unable to encode field AllowedVendorsSegment.SegmentType: value 1, should be 2",
+		},
+	}
+
+	for _, test := range testData {
+		tcf := valid()
+		test.change(&tcf)
+		encoded, err := tcf.EncodeStrict(true)
+		if test.expectedErr == "" {
+			assert.Nil(t, err, test.description)
+			assert.Equal(t, tcf.Encode(true), encoded, test.description)
+		} else {
+			assert.EqualError(t, err, test.expectedErr, test.description)
+		}
+	}
+}
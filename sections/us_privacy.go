@@ -0,0 +1,70 @@
+package sections
+
+// OptOutState is the value of a US opt-out field such as SaleOptOut.
+type OptOutState byte
+
+const (
+	OptOutNotApplicable OptOutState = 0
+	OptedOut            OptOutState = 1
+	DidNotOptOut        OptOutState = 2
+)
+
+// ConsentState is the value of a US consent field such as one of the KnownChildSensitiveDataConsents.
+// Sensitive data fields are opt-outs in some states and consents in others, but both use 1 for the
+// restrictive choice, so an opt-out is reported as NoConsent and the absence of one as Consent.
+type ConsentState byte
+
+const (
+	ConsentNotApplicable ConsentState = 0
+	NoConsent            ConsentState = 1
+	Consent              ConsentState = 2
+)
+
+// USPrivacySection is implemented by the US national and state sections, giving the same view of the
+// privacy signals whatever the layout of the section.
+type USPrivacySection interface {
+	// SaleOptOut reports whether the user opted out of the sale of their personal data.
+	SaleOptOut() OptOutState
+	// TargetedAdvertisingOptOut reports whether the user opted out of targeted advertising.
+	TargetedAdvertisingOptOut() OptOutState
+	// SharingOptOut reports whether the user opted out of the sharing of their personal data. It is
+	// OptOutNotApplicable for sections without a sharing opt-out.
+	SharingOptOut() OptOutState
+	// SaleOptOutNotice reports whether the user was given notice of their right to opt out of sale.
+	SaleOptOutNotice() NoticeState
+	// TargetedAdvertisingOptOutNotice reports whether the user was given notice of their right to opt out
+	// of targeted advertising.
+	TargetedAdvertisingOptOutNotice() NoticeState
+	// MspaServiceProviderMode reports whether the transaction is handled in MSPA service provider mode.
+	MspaServiceProviderMode() MspaMode
+	// SensitiveDataConsent reports the user's choice for a category of sensitive data. Categories are
+	// numbered from 1 in the order the section's specification lists them, which differs between states.
+	// Categories the section does not have are ConsentNotApplicable.
+	SensitiveDataConsent(category int) ConsentState
+	// SensitiveDataConsents reports the choices for all the sensitive data categories of the section, in
+	// category order.
+	SensitiveDataConsents() []ConsentState
+	// KnownChildSensitiveDataConsents reports the consents for processing the data of a known child, one
+	// for each age bracket of the section.
+	KnownChildSensitiveDataConsents() []ConsentState
+	// GPC reports whether the Global Privacy Control signal is set. It is false for sections without a
+	// GPC segment.
+	GPC() bool
+}
+
+// SensitiveDataConsent returns the consent for a 1-based category of a SensitiveDataProcessing field.
+func SensitiveDataConsent(sensitiveDataProcessing []byte, category int) ConsentState {
+	if category < 1 || category > len(sensitiveDataProcessing) {
+		return ConsentNotApplicable
+	}
+	return ConsentState(sensitiveDataProcessing[category-1])
+}
+
+// ConsentStates converts the values of a consent field to ConsentState.
+func ConsentStates(consents []byte) []ConsentState {
+	states := make([]ConsentState, len(consents))
+	for i, consent := range consents {
+		states[i] = ConsentState(consent)
+	}
+	return states
+}
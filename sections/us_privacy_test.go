@@ -0,0 +1,68 @@
+package sections_test
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/sections/uspca"
+	"github.com/prebid/go-gpp/sections/uspco"
+	"github.com/prebid/go-gpp/sections/uspct"
+	"github.com/prebid/go-gpp/sections/uspde"
+	"github.com/prebid/go-gpp/sections/uspfl"
+	"github.com/prebid/go-gpp/sections/uspia"
+	"github.com/prebid/go-gpp/sections/uspin"
+	"github.com/prebid/go-gpp/sections/uspky"
+	"github.com/prebid/go-gpp/sections/uspmd"
+	"github.com/prebid/go-gpp/sections/uspmn"
+	"github.com/prebid/go-gpp/sections/uspmt"
+	"github.com/prebid/go-gpp/sections/uspnat"
+	"github.com/prebid/go-gpp/sections/uspne"
+	"github.com/prebid/go-gpp/sections/uspnh"
+	"github.com/prebid/go-gpp/sections/uspnj"
+	"github.com/prebid/go-gpp/sections/uspor"
+	"github.com/prebid/go-gpp/sections/uspri"
+	"github.com/prebid/go-gpp/sections/usptn"
+	"github.com/prebid/go-gpp/sections/usptx"
+	"github.com/prebid/go-gpp/sections/usput"
+	"github.com/prebid/go-gpp/sections/uspva"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = []sections.USPrivacySection{
+	uspnat.USPNAT{},
+	uspca.USPCA{},
+	uspva.USPVA{},
+	uspco.USPCO{},
+	usput.USPUT{},
+	uspct.USPCT{},
+	uspfl.USPFL{},
+	uspmt.USPMT{},
+	uspor.USPOR{},
+	usptx.USPTX{},
+	uspde.USPDE{},
+	uspia.USPIA{},
+	uspne.USPNE{},
+	uspnh.USPNH{},
+	uspnj.USPNJ{},
+	usptn.USPTN{},
+	uspmn.USPMN{},
+	uspmd.USPMD{},
+	uspin.USPIN{},
+	uspky.USPKY{},
+	uspri.USPRI{},
+}
+
+func TestSensitiveDataConsent(t *testing.T) {
+	sensitiveDataProcessing := []byte{0, 1, 2}
+
+	assert.Equal(t, sections.ConsentNotApplicable, sections.SensitiveDataConsent(sensitiveDataProcessing, 0))
+	assert.Equal(t, sections.ConsentNotApplicable, sections.SensitiveDataConsent(sensitiveDataProcessing, 1))
+	assert.Equal(t, sections.NoConsent, sections.SensitiveDataConsent(sensitiveDataProcessing, 2))
+	assert.Equal(t, sections.Consent, sections.SensitiveDataConsent(sensitiveDataProcessing, 3))
+	assert.Equal(t, sections.ConsentNotApplicable, sections.SensitiveDataConsent(sensitiveDataProcessing, 4))
+}
+
+func TestConsentStates(t *testing.T) {
+	assert.Equal(t, []sections.ConsentState{sections.Consent, sections.NoConsent}, sections.ConsentStates([]byte{2, 1}))
+	assert.Equal(t, []sections.ConsentState{}, sections.ConsentStates(nil))
+}
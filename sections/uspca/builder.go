@@ -0,0 +1,104 @@
+package uspca
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+)
+
+// USPCABuilder builds a USPCA section. Every field starts as not applicable, except
+// MspaCoveredTransaction, which starts as MspaNo, and GPC, which starts unset. Setting a field to a
+// value outside its range, or a category the section does not have, makes Build return an error.
+type USPCABuilder struct {
+	section USPCA
+	err     error
+}
+
+// Builder returns a USPCABuilder for version 1 of the section.
+func Builder() *USPCABuilder {
+	return &USPCABuilder{section: USPCA{
+		SectionID: constants.SectionUSPCA,
+		CoreSegment: USPCACoreSegment{
+			Version:                         1,
+			SensitiveDataProcessing:         make([]byte, 9),
+			KnownChildSensitiveDataConsents: make([]byte, 2),
+			MspaCoveredTransaction:          byte(sections.MspaNo),
+		},
+		GPCSegment: sections.CommonUSGPCSegment{SubsectionType: 1},
+	}}
+}
+
+func (b *USPCABuilder) SaleOptOutNotice(value sections.NoticeState) *USPCABuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOutNotice", &b.section.CoreSegment.SaleOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPCABuilder) SharingOptOutNotice(value sections.NoticeState) *USPCABuilder {
+	sections.SetField(&b.err, "CoreSegment.SharingOptOutNotice", &b.section.CoreSegment.SharingOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPCABuilder) SensitiveDataLimitUseNotice(value sections.NoticeState) *USPCABuilder {
+	sections.SetField(&b.err, "CoreSegment.SensitiveDataLimitUseNotice", &b.section.CoreSegment.SensitiveDataLimitUseNotice, byte(value))
+	return b
+}
+
+func (b *USPCABuilder) SaleOptOut(value sections.OptOutState) *USPCABuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOut", &b.section.CoreSegment.SaleOptOut, byte(value))
+	return b
+}
+
+func (b *USPCABuilder) SharingOptOut(value sections.OptOutState) *USPCABuilder {
+	sections.SetField(&b.err, "CoreSegment.SharingOptOut", &b.section.CoreSegment.SharingOptOut, byte(value))
+	return b
+}
+
+// SensitiveData sets the consent for a category of sensitive data, numbered from 1 to 9 in the order of
+// the section's specification.
+func (b *USPCABuilder) SensitiveData(category int, state sections.ConsentState) *USPCABuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.SensitiveDataProcessing", b.section.CoreSegment.SensitiveDataProcessing, category, byte(state))
+	return b
+}
+
+// KnownChildSensitiveData sets the consent for processing the sensitive data of a known child, for an
+// age bracket numbered from 1 to 2.
+func (b *USPCABuilder) KnownChildSensitiveData(index int, state sections.ConsentState) *USPCABuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.KnownChildSensitiveDataConsents", b.section.CoreSegment.KnownChildSensitiveDataConsents, index, byte(state))
+	return b
+}
+
+func (b *USPCABuilder) PersonalDataConsents(value sections.ConsentState) *USPCABuilder {
+	sections.SetField(&b.err, "CoreSegment.PersonalDataConsents", &b.section.CoreSegment.PersonalDataConsents, byte(value))
+	return b
+}
+
+func (b *USPCABuilder) MspaCoveredTransaction(value sections.MspaMode) *USPCABuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaCoveredTransaction", &b.section.CoreSegment.MspaCoveredTransaction, byte(value))
+	return b
+}
+
+func (b *USPCABuilder) MspaOptOutOptionMode(value sections.MspaMode) *USPCABuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaOptOutOptionMode", &b.section.CoreSegment.MspaOptOutOptionMode, byte(value))
+	return b
+}
+
+func (b *USPCABuilder) MspaServiceProviderMode(value sections.MspaMode) *USPCABuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaServiceProviderMode", &b.section.CoreSegment.MspaServiceProviderMode, byte(value))
+	return b
+}
+
+func (b *USPCABuilder) GPC(gpc bool) *USPCABuilder {
+	b.section.GPCSegment.Gpc = gpc
+	return b
+}
+
+// Build returns the section, with its Value set to its encoding, or the first error of the setters.
+func (b *USPCABuilder) Build() (USPCA, error) {
+	if b.err != nil {
+		return USPCA{}, b.err
+	}
+	uspca := b.section
+	uspca.CoreSegment.SensitiveDataProcessing = append([]byte(nil), b.section.CoreSegment.SensitiveDataProcessing...)
+	uspca.CoreSegment.KnownChildSensitiveDataConsents = append([]byte(nil), b.section.CoreSegment.KnownChildSensitiveDataConsents...)
+	uspca.Value = string(uspca.Encode(true))
+	return uspca, nil
+}
@@ -145,6 +145,14 @@ func NewUSPCA(encoded string) (USPCA, error) {
 	return uspca, nil
 }
 
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (uspca USPCA) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(uspca.Value, func(bs *util.BitStream) error {
+		_, err := NewUSPCACoreSegment(bs)
+		return err
+	})
+}
+
 func (uspca USPCA) Encode(gpcIncluded bool) []byte {
 	bs := util.NewBitStreamForWrite()
 	uspca.CoreSegment.Encode(bs)
@@ -158,6 +166,20 @@ func (uspca USPCA) Encode(gpcIncluded bool) []byte {
 	return append(res, bs.Base64Encode()...)
 }
 
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (uspca USPCA) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(uspca.CoreSegment, 9, 2); err != nil {
+		return nil, err
+	}
+	if gpcIncluded {
+		if err := uspca.GPCSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return uspca.Encode(gpcIncluded), nil
+}
+
 func (uspca USPCA) GetID() constants.SectionID {
 	return uspca.SectionID
 }
@@ -165,3 +187,76 @@ func (uspca USPCA) GetID() constants.SectionID {
 func (uspca USPCA) GetValue() string {
 	return uspca.Value
 }
+
+// SaleOptOut implements sections.USPrivacySection.
+func (uspca USPCA) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(uspca.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection. California has no targeted advertising
+// opt-out, so the opt-out of sharing for cross-context behavioral advertising is reported instead.
+func (uspca USPCA) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspca.CoreSegment.SharingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection.
+func (uspca USPCA) SharingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspca.CoreSegment.SharingOptOut)
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (uspca USPCA) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspca.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection. California covers targeted
+// advertising through the sharing of personal data, so this is the SharingOptOutNotice field.
+func (uspca USPCA) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspca.CoreSegment.SharingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (uspca USPCA) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(uspca.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (uspca USPCA) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(uspca.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (uspca USPCA) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspca.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (uspca USPCA) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspca.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection.
+func (uspca USPCA) GPC() bool {
+	return uspca.GPCSegment.Gpc
+}
+
+func (segment USPCACoreSegment) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(segment)
+}
+
+func (segment *USPCACoreSegment) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, segment)
+}
+
+func (uspca USPCA) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(uspca)
+}
+
+func (uspca *USPCA) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, uspca)
+}
+
+// Validate implements sections.Validator.
+func (uspca USPCA) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(uspca.CoreSegment, 9, 2)
+}
@@ -62,3 +62,32 @@ func TestUSPCA(t *testing.T) {
 		assert.Equal(t, test.gppString, encodedString)
 	}
 }
+
+func TestUSPCAPrivacySignals(t *testing.T) {
+	uspca, err := NewUSPCA("xlgWEYCY.YA")
+	assert.Nil(t, err)
+
+	var signals sections.USPrivacySection = uspca
+	assert.Equal(t, sections.DidNotOptOut, signals.SaleOptOut())
+	assert.Equal(t, sections.OptOutNotApplicable, signals.TargetedAdvertisingOptOut())
+	assert.Equal(t, sections.ConsentNotApplicable, signals.SensitiveDataConsent(1))
+	assert.Equal(t, sections.NoConsent, signals.SensitiveDataConsent(2))
+	assert.Equal(t, sections.Consent, signals.SensitiveDataConsent(9))
+	assert.Equal(t, sections.ConsentNotApplicable, signals.SensitiveDataConsent(10))
+	assert.Equal(t, []sections.ConsentState{sections.ConsentNotApplicable, sections.ConsentNotApplicable}, signals.KnownChildSensitiveDataConsents())
+	assert.True(t, signals.GPC())
+}
+
+func TestUSPCAValidate(t *testing.T) {
+	section, err := Builder().SaleOptOutNotice(sections.NoticeProvided).SaleOptOut(sections.OptedOut).Build()
+	assert.Nil(t, err)
+	assert.Empty(t, section.Validate())
+
+	// The decoder accepts the reserved value 3, which Validate reports.
+	section.CoreSegment.SaleOptOut = 3
+	decoded, err := NewUSPCA(string(section.Encode(true)))
+	assert.Nil(t, err)
+	assert.Equal(t, []sections.ValidationIssue{
+		{Field: "CoreSegment.SaleOptOut", Severity: sections.SeverityError, Message: "invalid value 3"},
+	}, decoded.Validate())
+}
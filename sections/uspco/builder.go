@@ -0,0 +1,99 @@
+package uspco
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+)
+
+// USPCOBuilder builds a USPCO section. Every field starts as not applicable, except
+// MspaCoveredTransaction, which starts as MspaNo, and GPC, which starts unset. Setting a field to a
+// value outside its range, or a category the section does not have, makes Build return an error.
+type USPCOBuilder struct {
+	section USPCO
+	err     error
+}
+
+// Builder returns a USPCOBuilder for version 1 of the section.
+func Builder() *USPCOBuilder {
+	return &USPCOBuilder{section: USPCO{
+		SectionID: constants.SectionUSPCO,
+		CoreSegment: sections.CommonUSCoreSegment{
+			Version:                         1,
+			SensitiveDataProcessing:         make([]byte, 7),
+			KnownChildSensitiveDataConsents: make([]byte, 1),
+			MspaCoveredTransaction:          byte(sections.MspaNo),
+		},
+		GPCSegment: sections.CommonUSGPCSegment{SubsectionType: 1},
+	}}
+}
+
+func (b *USPCOBuilder) SharingNotice(value sections.NoticeState) *USPCOBuilder {
+	sections.SetField(&b.err, "CoreSegment.SharingNotice", &b.section.CoreSegment.SharingNotice, byte(value))
+	return b
+}
+
+func (b *USPCOBuilder) SaleOptOutNotice(value sections.NoticeState) *USPCOBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOutNotice", &b.section.CoreSegment.SaleOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPCOBuilder) TargetedAdvertisingOptOutNotice(value sections.NoticeState) *USPCOBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOutNotice", &b.section.CoreSegment.TargetedAdvertisingOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPCOBuilder) SaleOptOut(value sections.OptOutState) *USPCOBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOut", &b.section.CoreSegment.SaleOptOut, byte(value))
+	return b
+}
+
+func (b *USPCOBuilder) TargetedAdvertisingOptOut(value sections.OptOutState) *USPCOBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOut", &b.section.CoreSegment.TargetedAdvertisingOptOut, byte(value))
+	return b
+}
+
+// SensitiveData sets the consent for a category of sensitive data, numbered from 1 to 7 in the order of
+// the section's specification.
+func (b *USPCOBuilder) SensitiveData(category int, state sections.ConsentState) *USPCOBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.SensitiveDataProcessing", b.section.CoreSegment.SensitiveDataProcessing, category, byte(state))
+	return b
+}
+
+// KnownChildSensitiveData sets the consent for processing the sensitive data of a known child, for an
+// age bracket numbered from 1 to 1.
+func (b *USPCOBuilder) KnownChildSensitiveData(index int, state sections.ConsentState) *USPCOBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.KnownChildSensitiveDataConsents", b.section.CoreSegment.KnownChildSensitiveDataConsents, index, byte(state))
+	return b
+}
+
+func (b *USPCOBuilder) MspaCoveredTransaction(value sections.MspaMode) *USPCOBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaCoveredTransaction", &b.section.CoreSegment.MspaCoveredTransaction, byte(value))
+	return b
+}
+
+func (b *USPCOBuilder) MspaOptOutOptionMode(value sections.MspaMode) *USPCOBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaOptOutOptionMode", &b.section.CoreSegment.MspaOptOutOptionMode, byte(value))
+	return b
+}
+
+func (b *USPCOBuilder) MspaServiceProviderMode(value sections.MspaMode) *USPCOBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaServiceProviderMode", &b.section.CoreSegment.MspaServiceProviderMode, byte(value))
+	return b
+}
+
+func (b *USPCOBuilder) GPC(gpc bool) *USPCOBuilder {
+	b.section.GPCSegment.Gpc = gpc
+	return b
+}
+
+// Build returns the section, with its Value set to its encoding, or the first error of the setters.
+func (b *USPCOBuilder) Build() (USPCO, error) {
+	if b.err != nil {
+		return USPCO{}, b.err
+	}
+	uspco := b.section
+	uspco.CoreSegment.SensitiveDataProcessing = append([]byte(nil), b.section.CoreSegment.SensitiveDataProcessing...)
+	uspco.CoreSegment.KnownChildSensitiveDataConsents = append([]byte(nil), b.section.CoreSegment.KnownChildSensitiveDataConsents...)
+	uspco.Value = string(uspco.Encode(true))
+	return uspco, nil
+}
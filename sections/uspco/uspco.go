@@ -48,6 +48,14 @@ func NewUSPCO(encoded string) (USPCO, error) {
 	return uspco, nil
 }
 
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (uspco USPCO) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(uspco.Value, func(bs *util.BitStream) error {
+		_, err := sections.NewCommonUSCoreSegment(7, 1, bs)
+		return err
+	})
+}
+
 func (uspco USPCO) Encode(gpcIncluded bool) []byte {
 	bs := util.NewBitStreamForWrite()
 	uspco.CoreSegment.Encode(bs)
@@ -61,6 +69,20 @@ func (uspco USPCO) Encode(gpcIncluded bool) []byte {
 	return append(res, bs.Base64Encode()...)
 }
 
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (uspco USPCO) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(uspco.CoreSegment, 7, 1); err != nil {
+		return nil, err
+	}
+	if gpcIncluded {
+		if err := uspco.GPCSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return uspco.Encode(gpcIncluded), nil
+}
+
 func (uspco USPCO) GetID() constants.SectionID {
 	return uspco.SectionID
 }
@@ -68,3 +90,67 @@ func (uspco USPCO) GetID() constants.SectionID {
 func (uspco USPCO) GetValue() string {
 	return uspco.Value
 }
+
+// SaleOptOut implements sections.USPrivacySection.
+func (uspco USPCO) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(uspco.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (uspco USPCO) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspco.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection. The section has no sharing opt-out, so it is
+// always OptOutNotApplicable.
+func (uspco USPCO) SharingOptOut() sections.OptOutState {
+	return sections.OptOutNotApplicable
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (uspco USPCO) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspco.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (uspco USPCO) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspco.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (uspco USPCO) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(uspco.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (uspco USPCO) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(uspco.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (uspco USPCO) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspco.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (uspco USPCO) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspco.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection.
+func (uspco USPCO) GPC() bool {
+	return uspco.GPCSegment.Gpc
+}
+
+func (uspco USPCO) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(uspco)
+}
+
+func (uspco *USPCO) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, uspco)
+}
+
+// Validate implements sections.Validator.
+func (uspco USPCO) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(uspco.CoreSegment, 7, 1)
+}
@@ -0,0 +1,99 @@
+package uspct
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+)
+
+// USPCTBuilder builds a USPCT section. Every field starts as not applicable, except
+// MspaCoveredTransaction, which starts as MspaNo, and GPC, which starts unset. Setting a field to a
+// value outside its range, or a category the section does not have, makes Build return an error.
+type USPCTBuilder struct {
+	section USPCT
+	err     error
+}
+
+// Builder returns a USPCTBuilder for version 1 of the section.
+func Builder() *USPCTBuilder {
+	return &USPCTBuilder{section: USPCT{
+		SectionID: constants.SectionUSPCT,
+		CoreSegment: sections.CommonUSCoreSegment{
+			Version:                         1,
+			SensitiveDataProcessing:         make([]byte, 8),
+			KnownChildSensitiveDataConsents: make([]byte, 3),
+			MspaCoveredTransaction:          byte(sections.MspaNo),
+		},
+		GPCSegment: sections.CommonUSGPCSegment{SubsectionType: 1},
+	}}
+}
+
+func (b *USPCTBuilder) SharingNotice(value sections.NoticeState) *USPCTBuilder {
+	sections.SetField(&b.err, "CoreSegment.SharingNotice", &b.section.CoreSegment.SharingNotice, byte(value))
+	return b
+}
+
+func (b *USPCTBuilder) SaleOptOutNotice(value sections.NoticeState) *USPCTBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOutNotice", &b.section.CoreSegment.SaleOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPCTBuilder) TargetedAdvertisingOptOutNotice(value sections.NoticeState) *USPCTBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOutNotice", &b.section.CoreSegment.TargetedAdvertisingOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPCTBuilder) SaleOptOut(value sections.OptOutState) *USPCTBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOut", &b.section.CoreSegment.SaleOptOut, byte(value))
+	return b
+}
+
+func (b *USPCTBuilder) TargetedAdvertisingOptOut(value sections.OptOutState) *USPCTBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOut", &b.section.CoreSegment.TargetedAdvertisingOptOut, byte(value))
+	return b
+}
+
+// SensitiveData sets the consent for a category of sensitive data, numbered from 1 to 8 in the order of
+// the section's specification.
+func (b *USPCTBuilder) SensitiveData(category int, state sections.ConsentState) *USPCTBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.SensitiveDataProcessing", b.section.CoreSegment.SensitiveDataProcessing, category, byte(state))
+	return b
+}
+
+// KnownChildSensitiveData sets the consent for processing the sensitive data of a known child, for an
+// age bracket numbered from 1 to 3.
+func (b *USPCTBuilder) KnownChildSensitiveData(index int, state sections.ConsentState) *USPCTBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.KnownChildSensitiveDataConsents", b.section.CoreSegment.KnownChildSensitiveDataConsents, index, byte(state))
+	return b
+}
+
+func (b *USPCTBuilder) MspaCoveredTransaction(value sections.MspaMode) *USPCTBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaCoveredTransaction", &b.section.CoreSegment.MspaCoveredTransaction, byte(value))
+	return b
+}
+
+func (b *USPCTBuilder) MspaOptOutOptionMode(value sections.MspaMode) *USPCTBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaOptOutOptionMode", &b.section.CoreSegment.MspaOptOutOptionMode, byte(value))
+	return b
+}
+
+func (b *USPCTBuilder) MspaServiceProviderMode(value sections.MspaMode) *USPCTBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaServiceProviderMode", &b.section.CoreSegment.MspaServiceProviderMode, byte(value))
+	return b
+}
+
+func (b *USPCTBuilder) GPC(gpc bool) *USPCTBuilder {
+	b.section.GPCSegment.Gpc = gpc
+	return b
+}
+
+// Build returns the section, with its Value set to its encoding, or the first error of the setters.
+func (b *USPCTBuilder) Build() (USPCT, error) {
+	if b.err != nil {
+		return USPCT{}, b.err
+	}
+	uspct := b.section
+	uspct.CoreSegment.SensitiveDataProcessing = append([]byte(nil), b.section.CoreSegment.SensitiveDataProcessing...)
+	uspct.CoreSegment.KnownChildSensitiveDataConsents = append([]byte(nil), b.section.CoreSegment.KnownChildSensitiveDataConsents...)
+	uspct.Value = string(uspct.Encode(true))
+	return uspct, nil
+}
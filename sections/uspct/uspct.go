@@ -48,6 +48,14 @@ func NewUSPCT(encoded string) (USPCT, error) {
 	return uspct, nil
 }
 
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (uspct USPCT) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(uspct.Value, func(bs *util.BitStream) error {
+		_, err := sections.NewCommonUSCoreSegment(8, 3, bs)
+		return err
+	})
+}
+
 func (uspct USPCT) Encode(gpcIncluded bool) []byte {
 	bs := util.NewBitStreamForWrite()
 	uspct.CoreSegment.Encode(bs)
@@ -61,6 +69,20 @@ func (uspct USPCT) Encode(gpcIncluded bool) []byte {
 	return append(res, bs.Base64Encode()...)
 }
 
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (uspct USPCT) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(uspct.CoreSegment, 8, 3); err != nil {
+		return nil, err
+	}
+	if gpcIncluded {
+		if err := uspct.GPCSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return uspct.Encode(gpcIncluded), nil
+}
+
 func (uspct USPCT) GetID() constants.SectionID {
 	return uspct.SectionID
 }
@@ -68,3 +90,67 @@ func (uspct USPCT) GetID() constants.SectionID {
 func (uspct USPCT) GetValue() string {
 	return uspct.Value
 }
+
+// SaleOptOut implements sections.USPrivacySection.
+func (uspct USPCT) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(uspct.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (uspct USPCT) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspct.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection. The section has no sharing opt-out, so it is
+// always OptOutNotApplicable.
+func (uspct USPCT) SharingOptOut() sections.OptOutState {
+	return sections.OptOutNotApplicable
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (uspct USPCT) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspct.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (uspct USPCT) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspct.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (uspct USPCT) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(uspct.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (uspct USPCT) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(uspct.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (uspct USPCT) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspct.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (uspct USPCT) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspct.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection.
+func (uspct USPCT) GPC() bool {
+	return uspct.GPCSegment.Gpc
+}
+
+func (uspct USPCT) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(uspct)
+}
+
+func (uspct *USPCT) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, uspct)
+}
+
+// Validate implements sections.Validator.
+func (uspct USPCT) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(uspct.CoreSegment, 8, 3)
+}
@@ -0,0 +1,104 @@
+package uspde
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+)
+
+// USPDEBuilder builds a USPDE section. Every field starts as not applicable, except
+// MspaCoveredTransaction, which starts as MspaNo, and GPC, which starts unset. Setting a field to a
+// value outside its range, or a category the section does not have, makes Build return an error.
+type USPDEBuilder struct {
+	section USPDE
+	err     error
+}
+
+// Builder returns a USPDEBuilder for version 1 of the section.
+func Builder() *USPDEBuilder {
+	return &USPDEBuilder{section: USPDE{
+		SectionID: constants.SectionUSPDE,
+		CoreSegment: sections.CommonUSProcessingCoreSegment{
+			Version:                         1,
+			SensitiveDataProcessing:         make([]byte, sensitiveDataCount),
+			KnownChildSensitiveDataConsents: make([]byte, knownChildCount),
+			MspaCoveredTransaction:          byte(sections.MspaNo),
+		},
+		GPCSegment: sections.CommonUSGPCSegment{SubsectionType: 1},
+	}}
+}
+
+func (b *USPDEBuilder) ProcessingNotice(value sections.NoticeState) *USPDEBuilder {
+	sections.SetField(&b.err, "CoreSegment.ProcessingNotice", &b.section.CoreSegment.ProcessingNotice, byte(value))
+	return b
+}
+
+func (b *USPDEBuilder) SaleOptOutNotice(value sections.NoticeState) *USPDEBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOutNotice", &b.section.CoreSegment.SaleOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPDEBuilder) TargetedAdvertisingOptOutNotice(value sections.NoticeState) *USPDEBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOutNotice", &b.section.CoreSegment.TargetedAdvertisingOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPDEBuilder) SaleOptOut(value sections.OptOutState) *USPDEBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOut", &b.section.CoreSegment.SaleOptOut, byte(value))
+	return b
+}
+
+func (b *USPDEBuilder) TargetedAdvertisingOptOut(value sections.OptOutState) *USPDEBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOut", &b.section.CoreSegment.TargetedAdvertisingOptOut, byte(value))
+	return b
+}
+
+// SensitiveData sets the consent for a category of sensitive data, numbered from 1 to 9 in the order of
+// the section's specification.
+func (b *USPDEBuilder) SensitiveData(category int, state sections.ConsentState) *USPDEBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.SensitiveDataProcessing", b.section.CoreSegment.SensitiveDataProcessing, category, byte(state))
+	return b
+}
+
+// KnownChildSensitiveData sets the consent for processing the sensitive data of a known child, for an
+// age bracket numbered from 1 to 5.
+func (b *USPDEBuilder) KnownChildSensitiveData(index int, state sections.ConsentState) *USPDEBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.KnownChildSensitiveDataConsents", b.section.CoreSegment.KnownChildSensitiveDataConsents, index, byte(state))
+	return b
+}
+
+func (b *USPDEBuilder) AdditionalDataProcessingConsent(value sections.ConsentState) *USPDEBuilder {
+	sections.SetField(&b.err, "CoreSegment.AdditionalDataProcessingConsent", &b.section.CoreSegment.AdditionalDataProcessingConsent, byte(value))
+	return b
+}
+
+func (b *USPDEBuilder) MspaCoveredTransaction(value sections.MspaMode) *USPDEBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaCoveredTransaction", &b.section.CoreSegment.MspaCoveredTransaction, byte(value))
+	return b
+}
+
+func (b *USPDEBuilder) MspaOptOutOptionMode(value sections.MspaMode) *USPDEBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaOptOutOptionMode", &b.section.CoreSegment.MspaOptOutOptionMode, byte(value))
+	return b
+}
+
+func (b *USPDEBuilder) MspaServiceProviderMode(value sections.MspaMode) *USPDEBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaServiceProviderMode", &b.section.CoreSegment.MspaServiceProviderMode, byte(value))
+	return b
+}
+
+func (b *USPDEBuilder) GPC(gpc bool) *USPDEBuilder {
+	b.section.GPCSegment.Gpc = gpc
+	return b
+}
+
+// Build returns the section, with its Value set to its encoding, or the first error of the setters.
+func (b *USPDEBuilder) Build() (USPDE, error) {
+	if b.err != nil {
+		return USPDE{}, b.err
+	}
+	uspde := b.section
+	uspde.CoreSegment.SensitiveDataProcessing = append([]byte(nil), b.section.CoreSegment.SensitiveDataProcessing...)
+	uspde.CoreSegment.KnownChildSensitiveDataConsents = append([]byte(nil), b.section.CoreSegment.KnownChildSensitiveDataConsents...)
+	uspde.Value = string(uspde.Encode(true))
+	return uspde, nil
+}
@@ -0,0 +1,162 @@
+package uspde
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+)
+
+// Numbers of sensitive data categories and of known child age brackets in the section.
+const (
+	sensitiveDataCount = 9
+	knownChildCount    = 5
+)
+
+type USPDE struct {
+	SectionID   constants.SectionID
+	Value       string
+	CoreSegment sections.CommonUSProcessingCoreSegment
+	GPCSegment  sections.CommonUSGPCSegment
+}
+
+func NewUSPDE(encoded string) (USPDE, error) {
+	uspde := USPDE{}
+
+	coreBitStream, gpcBitStream, err := sections.CreateBitStreams(encoded, true)
+	if err != nil {
+		return uspde, err
+	}
+
+	coreSegment, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, coreBitStream)
+	if err != nil {
+		return uspde, err
+	}
+
+	gpcSegment := sections.CommonUSGPCSegment{
+		SubsectionType: 1,
+		Gpc:            false,
+	}
+
+	if gpcBitStream != nil {
+		gpcSegment, err = sections.NewCommonUSGPCSegment(gpcBitStream)
+		if err != nil {
+			return uspde, err
+		}
+	}
+
+	uspde = USPDE{
+		SectionID:   constants.SectionUSPDE,
+		Value:       encoded,
+		CoreSegment: coreSegment,
+		GPCSegment:  gpcSegment,
+	}
+
+	return uspde, nil
+}
+
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (uspde USPDE) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(uspde.Value, func(bs *util.BitStream) error {
+		_, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, bs)
+		return err
+	})
+}
+
+func (uspde USPDE) Encode(gpcIncluded bool) []byte {
+	bs := util.NewBitStreamForWrite()
+	uspde.CoreSegment.Encode(bs)
+	res := bs.Base64Encode()
+	if !gpcIncluded {
+		return res
+	}
+	bs.Reset()
+	res = append(res, '.')
+	uspde.GPCSegment.Encode(bs)
+	return append(res, bs.Base64Encode()...)
+}
+
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (uspde USPDE) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(uspde.CoreSegment, sensitiveDataCount, knownChildCount); err != nil {
+		return nil, err
+	}
+	if gpcIncluded {
+		if err := uspde.GPCSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return uspde.Encode(gpcIncluded), nil
+}
+
+func (uspde USPDE) GetID() constants.SectionID {
+	return uspde.SectionID
+}
+
+func (uspde USPDE) GetValue() string {
+	return uspde.Value
+}
+
+// SaleOptOut implements sections.USPrivacySection.
+func (uspde USPDE) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(uspde.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (uspde USPDE) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspde.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection. The section has no sharing opt-out, so it is
+// always OptOutNotApplicable.
+func (uspde USPDE) SharingOptOut() sections.OptOutState {
+	return sections.OptOutNotApplicable
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (uspde USPDE) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspde.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (uspde USPDE) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspde.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (uspde USPDE) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(uspde.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (uspde USPDE) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(uspde.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (uspde USPDE) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspde.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (uspde USPDE) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspde.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection.
+func (uspde USPDE) GPC() bool {
+	return uspde.GPCSegment.Gpc
+}
+
+func (uspde USPDE) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(uspde)
+}
+
+func (uspde *USPDE) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, uspde)
+}
+
+// Validate implements sections.Validator.
+func (uspde USPDE) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(uspde.CoreSegment, sensitiveDataCount, knownChildCount)
+}
@@ -0,0 +1,62 @@
+package uspde
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/stretchr/testify/assert"
+)
+
+type uspdeTestData struct {
+	description string
+	gppString   string
+	expected    USPDE
+}
+
+func TestUSPDE(t *testing.T) {
+	testData := []uspdeTestData{
+		{
+			description: "should populate USPDE segments correctly",
+			gppString:   "BZZhhiSVYA.YA",
+			/*
+				000001 01 10 01 01 10 011000011000011000 1001001001 01 01 01 10 01 1
+			*/
+			expected: USPDE{
+				CoreSegment: sections.CommonUSProcessingCoreSegment{
+					Version:                         1,
+					ProcessingNotice:                1,
+					SaleOptOutNotice:                2,
+					TargetedAdvertisingOptOutNotice: 1,
+					SaleOptOut:                      1,
+					TargetedAdvertisingOptOut:       2,
+					SensitiveDataProcessing: []byte{
+						1, 2, 0, 1, 2, 0, 1, 2, 0,
+					},
+					KnownChildSensitiveDataConsents: []byte{2, 1, 0, 2, 1},
+					AdditionalDataProcessingConsent: 1,
+					MspaCoveredTransaction:          1,
+					MspaOptOutOptionMode:            1,
+					MspaServiceProviderMode:         2,
+				},
+				GPCSegment: sections.CommonUSGPCSegment{
+					SubsectionType: 1,
+					Gpc:            true,
+				},
+				SectionID: constants.SectionUSPDE,
+				Value:     "BZZhhiSVYA.YA",
+			},
+		},
+	}
+
+	for _, test := range testData {
+		result, err := NewUSPDE(test.gppString)
+		encodedString := string(test.expected.Encode(true))
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, result)
+		assert.Equal(t, constants.SectionUSPDE, result.GetID())
+		assert.Equal(t, test.gppString, result.GetValue())
+		assert.Equal(t, test.gppString, encodedString)
+	}
+}
@@ -0,0 +1,98 @@
+package uspfl
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+)
+
+// USPFLBuilder builds a USPFL section. Every field starts as not applicable, except
+// MspaCoveredTransaction, which starts as MspaNo. Setting a field to a value outside its range, or a
+// category the section does not have, makes Build return an error.
+type USPFLBuilder struct {
+	section USPFL
+	err     error
+}
+
+// Builder returns a USPFLBuilder for version 1 of the section.
+func Builder() *USPFLBuilder {
+	return &USPFLBuilder{section: USPFL{
+		SectionID: constants.SectionUSPFL,
+		CoreSegment: sections.CommonUSProcessingCoreSegment{
+			Version:                         1,
+			SensitiveDataProcessing:         make([]byte, sensitiveDataCount),
+			KnownChildSensitiveDataConsents: make([]byte, knownChildCount),
+			MspaCoveredTransaction:          byte(sections.MspaNo),
+		},
+	}}
+}
+
+func (b *USPFLBuilder) ProcessingNotice(value sections.NoticeState) *USPFLBuilder {
+	sections.SetField(&b.err, "CoreSegment.ProcessingNotice", &b.section.CoreSegment.ProcessingNotice, byte(value))
+	return b
+}
+
+func (b *USPFLBuilder) SaleOptOutNotice(value sections.NoticeState) *USPFLBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOutNotice", &b.section.CoreSegment.SaleOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPFLBuilder) TargetedAdvertisingOptOutNotice(value sections.NoticeState) *USPFLBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOutNotice", &b.section.CoreSegment.TargetedAdvertisingOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPFLBuilder) SaleOptOut(value sections.OptOutState) *USPFLBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOut", &b.section.CoreSegment.SaleOptOut, byte(value))
+	return b
+}
+
+func (b *USPFLBuilder) TargetedAdvertisingOptOut(value sections.OptOutState) *USPFLBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOut", &b.section.CoreSegment.TargetedAdvertisingOptOut, byte(value))
+	return b
+}
+
+// SensitiveData sets the consent for a category of sensitive data, numbered from 1 to 8 in the order of
+// the section's specification.
+func (b *USPFLBuilder) SensitiveData(category int, state sections.ConsentState) *USPFLBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.SensitiveDataProcessing", b.section.CoreSegment.SensitiveDataProcessing, category, byte(state))
+	return b
+}
+
+// KnownChildSensitiveData sets the consent for processing the sensitive data of a known child, for an
+// age bracket numbered from 1 to 3.
+func (b *USPFLBuilder) KnownChildSensitiveData(index int, state sections.ConsentState) *USPFLBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.KnownChildSensitiveDataConsents", b.section.CoreSegment.KnownChildSensitiveDataConsents, index, byte(state))
+	return b
+}
+
+func (b *USPFLBuilder) AdditionalDataProcessingConsent(value sections.ConsentState) *USPFLBuilder {
+	sections.SetField(&b.err, "CoreSegment.AdditionalDataProcessingConsent", &b.section.CoreSegment.AdditionalDataProcessingConsent, byte(value))
+	return b
+}
+
+func (b *USPFLBuilder) MspaCoveredTransaction(value sections.MspaMode) *USPFLBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaCoveredTransaction", &b.section.CoreSegment.MspaCoveredTransaction, byte(value))
+	return b
+}
+
+func (b *USPFLBuilder) MspaOptOutOptionMode(value sections.MspaMode) *USPFLBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaOptOutOptionMode", &b.section.CoreSegment.MspaOptOutOptionMode, byte(value))
+	return b
+}
+
+func (b *USPFLBuilder) MspaServiceProviderMode(value sections.MspaMode) *USPFLBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaServiceProviderMode", &b.section.CoreSegment.MspaServiceProviderMode, byte(value))
+	return b
+}
+
+// Build returns the section, with its Value set to its encoding, or the first error of the setters.
+func (b *USPFLBuilder) Build() (USPFL, error) {
+	if b.err != nil {
+		return USPFL{}, b.err
+	}
+	uspfl := b.section
+	uspfl.CoreSegment.SensitiveDataProcessing = append([]byte(nil), b.section.CoreSegment.SensitiveDataProcessing...)
+	uspfl.CoreSegment.KnownChildSensitiveDataConsents = append([]byte(nil), b.section.CoreSegment.KnownChildSensitiveDataConsents...)
+	uspfl.Value = string(uspfl.Encode(true))
+	return uspfl, nil
+}
@@ -0,0 +1,137 @@
+package uspfl
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+)
+
+// Numbers of sensitive data categories and of known child age brackets in the section.
+const (
+	sensitiveDataCount = 8
+	knownChildCount    = 3
+)
+
+// USPFL has no GPC segment, so the GPC flag passed to Encode is ignored.
+type USPFL struct {
+	SectionID   constants.SectionID
+	Value       string
+	CoreSegment sections.CommonUSProcessingCoreSegment
+}
+
+func NewUSPFL(encoded string) (USPFL, error) {
+	uspfl := USPFL{}
+
+	bitStream, err := util.NewBitStreamFromBase64(encoded)
+	if err != nil {
+		return uspfl, err
+	}
+
+	coreSegment, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, bitStream)
+	if err != nil {
+		return uspfl, err
+	}
+
+	uspfl = USPFL{
+		SectionID:   constants.SectionUSPFL,
+		Value:       encoded,
+		CoreSegment: coreSegment,
+	}
+
+	return uspfl, nil
+}
+
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (uspfl USPFL) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(uspfl.Value, func(bs *util.BitStream) error {
+		_, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, bs)
+		return err
+	})
+}
+
+func (uspfl USPFL) Encode(bool) []byte {
+	bs := util.NewBitStreamForWrite()
+	uspfl.CoreSegment.Encode(bs)
+	return bs.Base64Encode()
+}
+
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (uspfl USPFL) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(uspfl.CoreSegment, sensitiveDataCount, knownChildCount); err != nil {
+		return nil, err
+	}
+	return uspfl.Encode(gpcIncluded), nil
+}
+
+func (uspfl USPFL) GetID() constants.SectionID {
+	return uspfl.SectionID
+}
+
+func (uspfl USPFL) GetValue() string {
+	return uspfl.Value
+}
+
+// SaleOptOut implements sections.USPrivacySection.
+func (uspfl USPFL) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(uspfl.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (uspfl USPFL) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspfl.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection. The section has no sharing opt-out, so it is
+// always OptOutNotApplicable.
+func (uspfl USPFL) SharingOptOut() sections.OptOutState {
+	return sections.OptOutNotApplicable
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (uspfl USPFL) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspfl.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (uspfl USPFL) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspfl.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (uspfl USPFL) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(uspfl.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (uspfl USPFL) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(uspfl.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (uspfl USPFL) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspfl.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (uspfl USPFL) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspfl.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection. The section has no GPC segment, so it is always false.
+func (uspfl USPFL) GPC() bool {
+	return false
+}
+
+func (uspfl USPFL) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(uspfl)
+}
+
+func (uspfl *USPFL) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, uspfl)
+}
+
+// Validate implements sections.Validator.
+func (uspfl USPFL) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(uspfl.CoreSegment, sensitiveDataCount, knownChildCount)
+}
@@ -0,0 +1,58 @@
+package uspfl
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/stretchr/testify/assert"
+)
+
+type uspflTestData struct {
+	description string
+	gppString   string
+	expected    USPFL
+}
+
+func TestUSPFL(t *testing.T) {
+	testData := []uspflTestData{
+		{
+			description: "should populate USPFL segments correctly",
+			gppString:   "BZYYYUlY",
+			/*
+				000001 01 10 01 01 10 0001100001100001 010010 01 01 01 10
+			*/
+			expected: USPFL{
+				CoreSegment: sections.CommonUSProcessingCoreSegment{
+					Version:                         1,
+					ProcessingNotice:                1,
+					SaleOptOutNotice:                2,
+					TargetedAdvertisingOptOutNotice: 1,
+					SaleOptOut:                      1,
+					TargetedAdvertisingOptOut:       2,
+					SensitiveDataProcessing: []byte{
+						0, 1, 2, 0, 1, 2, 0, 1,
+					},
+					KnownChildSensitiveDataConsents: []byte{1, 0, 2},
+					AdditionalDataProcessingConsent: 1,
+					MspaCoveredTransaction:          1,
+					MspaOptOutOptionMode:            1,
+					MspaServiceProviderMode:         2,
+				},
+				SectionID: constants.SectionUSPFL,
+				Value:     "BZYYYUlY",
+			},
+		},
+	}
+
+	for _, test := range testData {
+		result, err := NewUSPFL(test.gppString)
+		encodedString := string(test.expected.Encode(false))
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, result)
+		assert.Equal(t, constants.SectionUSPFL, result.GetID())
+		assert.Equal(t, test.gppString, result.GetValue())
+		assert.Equal(t, test.gppString, encodedString)
+	}
+}
@@ -0,0 +1,104 @@
+package uspia
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+)
+
+// USPIABuilder builds a USPIA section. Every field starts as not applicable, except
+// MspaCoveredTransaction, which starts as MspaNo, and GPC, which starts unset. Setting a field to a
+// value outside its range, or a category the section does not have, makes Build return an error.
+type USPIABuilder struct {
+	section USPIA
+	err     error
+}
+
+// Builder returns a USPIABuilder for version 1 of the section.
+func Builder() *USPIABuilder {
+	return &USPIABuilder{section: USPIA{
+		SectionID: constants.SectionUSPIA,
+		CoreSegment: USPIACoreSegment{
+			Version:                         1,
+			SensitiveDataProcessing:         make([]byte, sensitiveDataCount),
+			KnownChildSensitiveDataConsents: make([]byte, knownChildCount),
+			MspaCoveredTransaction:          byte(sections.MspaNo),
+		},
+		GPCSegment: sections.CommonUSGPCSegment{SubsectionType: 1},
+	}}
+}
+
+func (b *USPIABuilder) ProcessingNotice(value sections.NoticeState) *USPIABuilder {
+	sections.SetField(&b.err, "CoreSegment.ProcessingNotice", &b.section.CoreSegment.ProcessingNotice, byte(value))
+	return b
+}
+
+func (b *USPIABuilder) SaleOptOutNotice(value sections.NoticeState) *USPIABuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOutNotice", &b.section.CoreSegment.SaleOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPIABuilder) TargetedAdvertisingOptOutNotice(value sections.NoticeState) *USPIABuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOutNotice", &b.section.CoreSegment.TargetedAdvertisingOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPIABuilder) SensitiveDataOptOutNotice(value sections.NoticeState) *USPIABuilder {
+	sections.SetField(&b.err, "CoreSegment.SensitiveDataOptOutNotice", &b.section.CoreSegment.SensitiveDataOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPIABuilder) SaleOptOut(value sections.OptOutState) *USPIABuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOut", &b.section.CoreSegment.SaleOptOut, byte(value))
+	return b
+}
+
+func (b *USPIABuilder) TargetedAdvertisingOptOut(value sections.OptOutState) *USPIABuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOut", &b.section.CoreSegment.TargetedAdvertisingOptOut, byte(value))
+	return b
+}
+
+// SensitiveData sets the consent for a category of sensitive data, numbered from 1 to 8 in the order of
+// the section's specification.
+func (b *USPIABuilder) SensitiveData(category int, state sections.ConsentState) *USPIABuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.SensitiveDataProcessing", b.section.CoreSegment.SensitiveDataProcessing, category, byte(state))
+	return b
+}
+
+// KnownChildSensitiveData sets the consent for processing the sensitive data of a known child, for an
+// age bracket numbered from 1 to 1.
+func (b *USPIABuilder) KnownChildSensitiveData(index int, state sections.ConsentState) *USPIABuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.KnownChildSensitiveDataConsents", b.section.CoreSegment.KnownChildSensitiveDataConsents, index, byte(state))
+	return b
+}
+
+func (b *USPIABuilder) MspaCoveredTransaction(value sections.MspaMode) *USPIABuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaCoveredTransaction", &b.section.CoreSegment.MspaCoveredTransaction, byte(value))
+	return b
+}
+
+func (b *USPIABuilder) MspaOptOutOptionMode(value sections.MspaMode) *USPIABuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaOptOutOptionMode", &b.section.CoreSegment.MspaOptOutOptionMode, byte(value))
+	return b
+}
+
+func (b *USPIABuilder) MspaServiceProviderMode(value sections.MspaMode) *USPIABuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaServiceProviderMode", &b.section.CoreSegment.MspaServiceProviderMode, byte(value))
+	return b
+}
+
+func (b *USPIABuilder) GPC(gpc bool) *USPIABuilder {
+	b.section.GPCSegment.Gpc = gpc
+	return b
+}
+
+// Build returns the section, with its Value set to its encoding, or the first error of the setters.
+func (b *USPIABuilder) Build() (USPIA, error) {
+	if b.err != nil {
+		return USPIA{}, b.err
+	}
+	uspia := b.section
+	uspia.CoreSegment.SensitiveDataProcessing = append([]byte(nil), b.section.CoreSegment.SensitiveDataProcessing...)
+	uspia.CoreSegment.KnownChildSensitiveDataConsents = append([]byte(nil), b.section.CoreSegment.KnownChildSensitiveDataConsents...)
+	uspia.Value = string(uspia.Encode(true))
+	return uspia, nil
+}
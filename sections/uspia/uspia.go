@@ -0,0 +1,267 @@
+package uspia
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+)
+
+// Numbers of sensitive data categories and of known child age brackets in the section.
+const (
+	sensitiveDataCount = 8
+	knownChildCount    = 1
+)
+
+type USPIACoreSegment struct {
+	Version                         byte
+	ProcessingNotice                byte
+	SaleOptOutNotice                byte
+	TargetedAdvertisingOptOutNotice byte
+	SensitiveDataOptOutNotice       byte
+	SaleOptOut                      byte
+	TargetedAdvertisingOptOut       byte
+	SensitiveDataProcessing         []byte
+	KnownChildSensitiveDataConsents []byte
+	MspaCoveredTransaction          byte
+	MspaOptOutOptionMode            byte
+	MspaServiceProviderMode         byte
+}
+
+type USPIA struct {
+	SectionID   constants.SectionID
+	Value       string
+	CoreSegment USPIACoreSegment
+	GPCSegment  sections.CommonUSGPCSegment
+}
+
+func NewUSPIACoreSegment(bs *util.BitStream) (USPIACoreSegment, error) {
+	var uspiaCore USPIACoreSegment
+	var err error
+
+	uspiaCore.Version, err = bs.ReadByte6()
+	if err != nil {
+		return uspiaCore, sections.ErrorHelper("CoreSegment.Version", err)
+	}
+
+	uspiaCore.ProcessingNotice, err = bs.ReadByte2()
+	if err != nil {
+		return uspiaCore, sections.ErrorHelper("CoreSegment.ProcessingNotice", err)
+	}
+
+	uspiaCore.SaleOptOutNotice, err = bs.ReadByte2()
+	if err != nil {
+		return uspiaCore, sections.ErrorHelper("CoreSegment.SaleOptOutNotice", err)
+	}
+
+	uspiaCore.TargetedAdvertisingOptOutNotice, err = bs.ReadByte2()
+	if err != nil {
+		return uspiaCore, sections.ErrorHelper("CoreSegment.TargetedAdvertisingOptOutNotice", err)
+	}
+
+	uspiaCore.SensitiveDataOptOutNotice, err = bs.ReadByte2()
+	if err != nil {
+		return uspiaCore, sections.ErrorHelper("CoreSegment.SensitiveDataOptOutNotice", err)
+	}
+
+	uspiaCore.SaleOptOut, err = bs.ReadByte2()
+	if err != nil {
+		return uspiaCore, sections.ErrorHelper("CoreSegment.SaleOptOut", err)
+	}
+
+	uspiaCore.TargetedAdvertisingOptOut, err = bs.ReadByte2()
+	if err != nil {
+		return uspiaCore, sections.ErrorHelper("CoreSegment.TargetedAdvertisingOptOut", err)
+	}
+
+	uspiaCore.SensitiveDataProcessing, err = bs.ReadTwoBitField(sensitiveDataCount)
+	if err != nil {
+		return uspiaCore, sections.ErrorHelper("CoreSegment.SensitiveDataProcessing", err)
+	}
+
+	uspiaCore.KnownChildSensitiveDataConsents, err = bs.ReadTwoBitField(knownChildCount)
+	if err != nil {
+		return uspiaCore, sections.ErrorHelper("CoreSegment.KnownChildSensitiveDataConsents", err)
+	}
+
+	uspiaCore.MspaCoveredTransaction, err = bs.ReadByte2()
+	if err != nil {
+		return uspiaCore, sections.ErrorHelper("CoreSegment.MspaCoveredTransaction", err)
+	}
+
+	uspiaCore.MspaOptOutOptionMode, err = bs.ReadByte2()
+	if err != nil {
+		return uspiaCore, sections.ErrorHelper("CoreSegment.MspaOptOutOptionMode", err)
+	}
+
+	uspiaCore.MspaServiceProviderMode, err = bs.ReadByte2()
+	if err != nil {
+		return uspiaCore, sections.ErrorHelper("CoreSegment.MspaServiceProviderMode", err)
+	}
+
+	return uspiaCore, nil
+}
+
+func (segment USPIACoreSegment) Encode(bs *util.BitStream) {
+	bs.WriteByte6(segment.Version)
+	bs.WriteByte2(segment.ProcessingNotice)
+	bs.WriteByte2(segment.SaleOptOutNotice)
+	bs.WriteByte2(segment.TargetedAdvertisingOptOutNotice)
+	bs.WriteByte2(segment.SensitiveDataOptOutNotice)
+	bs.WriteByte2(segment.SaleOptOut)
+	bs.WriteByte2(segment.TargetedAdvertisingOptOut)
+	bs.WriteTwoBitField(segment.SensitiveDataProcessing)
+	bs.WriteTwoBitField(segment.KnownChildSensitiveDataConsents)
+	bs.WriteByte2(segment.MspaCoveredTransaction)
+	bs.WriteByte2(segment.MspaOptOutOptionMode)
+	bs.WriteByte2(segment.MspaServiceProviderMode)
+}
+
+func NewUSPIA(encoded string) (USPIA, error) {
+	uspia := USPIA{}
+
+	coreBitStream, gpcBitStream, err := sections.CreateBitStreams(encoded, true)
+	if err != nil {
+		return uspia, err
+	}
+
+	coreSegment, err := NewUSPIACoreSegment(coreBitStream)
+	if err != nil {
+		return uspia, err
+	}
+
+	gpcSegment := sections.CommonUSGPCSegment{
+		SubsectionType: 1,
+		Gpc:            false,
+	}
+
+	if gpcBitStream != nil {
+		gpcSegment, err = sections.NewCommonUSGPCSegment(gpcBitStream)
+		if err != nil {
+			return uspia, err
+		}
+	}
+
+	uspia = USPIA{
+		SectionID:   constants.SectionUSPIA,
+		Value:       encoded,
+		CoreSegment: coreSegment,
+		GPCSegment:  gpcSegment,
+	}
+
+	return uspia, nil
+}
+
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (uspia USPIA) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(uspia.Value, func(bs *util.BitStream) error {
+		_, err := NewUSPIACoreSegment(bs)
+		return err
+	})
+}
+
+func (uspia USPIA) Encode(gpcIncluded bool) []byte {
+	bs := util.NewBitStreamForWrite()
+	uspia.CoreSegment.Encode(bs)
+	res := bs.Base64Encode()
+	if !gpcIncluded {
+		return res
+	}
+	bs.Reset()
+	res = append(res, '.')
+	uspia.GPCSegment.Encode(bs)
+	return append(res, bs.Base64Encode()...)
+}
+
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (uspia USPIA) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(uspia.CoreSegment, sensitiveDataCount, knownChildCount); err != nil {
+		return nil, err
+	}
+	if gpcIncluded {
+		if err := uspia.GPCSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return uspia.Encode(gpcIncluded), nil
+}
+
+func (uspia USPIA) GetID() constants.SectionID {
+	return uspia.SectionID
+}
+
+func (uspia USPIA) GetValue() string {
+	return uspia.Value
+}
+
+// SaleOptOut implements sections.USPrivacySection.
+func (uspia USPIA) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(uspia.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (uspia USPIA) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspia.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection. The section has no sharing opt-out, so it is
+// always OptOutNotApplicable.
+func (uspia USPIA) SharingOptOut() sections.OptOutState {
+	return sections.OptOutNotApplicable
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (uspia USPIA) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspia.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (uspia USPIA) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspia.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (uspia USPIA) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(uspia.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (uspia USPIA) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(uspia.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (uspia USPIA) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspia.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (uspia USPIA) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspia.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection.
+func (uspia USPIA) GPC() bool {
+	return uspia.GPCSegment.Gpc
+}
+
+func (segment USPIACoreSegment) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(segment)
+}
+
+func (segment *USPIACoreSegment) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, segment)
+}
+
+func (uspia USPIA) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(uspia)
+}
+
+func (uspia *USPIA) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, uspia)
+}
+
+// Validate implements sections.Validator.
+func (uspia USPIA) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(uspia.CoreSegment, sensitiveDataCount, knownChildCount)
+}
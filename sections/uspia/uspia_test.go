@@ -0,0 +1,62 @@
+package uspia
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/stretchr/testify/assert"
+)
+
+type uspiaTestData struct {
+	description string
+	gppString   string
+	expected    USPIA
+}
+
+func TestUSPIA(t *testing.T) {
+	testData := []uspiaTestData{
+		{
+			description: "should populate USPIA segments correctly",
+			gppString:   "BWWGGGWA.YA",
+			/*
+				000001 01 01 10 01 01 10 0001100001100001 10 01 01 10 01 1
+			*/
+			expected: USPIA{
+				CoreSegment: USPIACoreSegment{
+					Version:                         1,
+					ProcessingNotice:                1,
+					SaleOptOutNotice:                1,
+					TargetedAdvertisingOptOutNotice: 2,
+					SensitiveDataOptOutNotice:       1,
+					SaleOptOut:                      1,
+					TargetedAdvertisingOptOut:       2,
+					SensitiveDataProcessing: []byte{
+						0, 1, 2, 0, 1, 2, 0, 1,
+					},
+					KnownChildSensitiveDataConsents: []byte{2},
+					MspaCoveredTransaction:          1,
+					MspaOptOutOptionMode:            1,
+					MspaServiceProviderMode:         2,
+				},
+				GPCSegment: sections.CommonUSGPCSegment{
+					SubsectionType: 1,
+					Gpc:            true,
+				},
+				SectionID: constants.SectionUSPIA,
+				Value:     "BWWGGGWA.YA",
+			},
+		},
+	}
+
+	for _, test := range testData {
+		result, err := NewUSPIA(test.gppString)
+		encodedString := string(test.expected.Encode(true))
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, result)
+		assert.Equal(t, constants.SectionUSPIA, result.GetID())
+		assert.Equal(t, test.gppString, result.GetValue())
+		assert.Equal(t, test.gppString, encodedString)
+	}
+}
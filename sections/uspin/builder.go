@@ -0,0 +1,104 @@
+package uspin
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+)
+
+// USPINBuilder builds a USPIN section. Every field starts as not applicable, except
+// MspaCoveredTransaction, which starts as MspaNo, and GPC, which starts unset. Setting a field to a
+// value outside its range, or a category the section does not have, makes Build return an error.
+type USPINBuilder struct {
+	section USPIN
+	err     error
+}
+
+// Builder returns a USPINBuilder for version 1 of the section.
+func Builder() *USPINBuilder {
+	return &USPINBuilder{section: USPIN{
+		SectionID: constants.SectionUSPIN,
+		CoreSegment: sections.CommonUSProcessingCoreSegment{
+			Version:                         1,
+			SensitiveDataProcessing:         make([]byte, sensitiveDataCount),
+			KnownChildSensitiveDataConsents: make([]byte, knownChildCount),
+			MspaCoveredTransaction:          byte(sections.MspaNo),
+		},
+		GPCSegment: sections.CommonUSGPCSegment{SubsectionType: 1},
+	}}
+}
+
+func (b *USPINBuilder) ProcessingNotice(value sections.NoticeState) *USPINBuilder {
+	sections.SetField(&b.err, "CoreSegment.ProcessingNotice", &b.section.CoreSegment.ProcessingNotice, byte(value))
+	return b
+}
+
+func (b *USPINBuilder) SaleOptOutNotice(value sections.NoticeState) *USPINBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOutNotice", &b.section.CoreSegment.SaleOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPINBuilder) TargetedAdvertisingOptOutNotice(value sections.NoticeState) *USPINBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOutNotice", &b.section.CoreSegment.TargetedAdvertisingOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPINBuilder) SaleOptOut(value sections.OptOutState) *USPINBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOut", &b.section.CoreSegment.SaleOptOut, byte(value))
+	return b
+}
+
+func (b *USPINBuilder) TargetedAdvertisingOptOut(value sections.OptOutState) *USPINBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOut", &b.section.CoreSegment.TargetedAdvertisingOptOut, byte(value))
+	return b
+}
+
+// SensitiveData sets the consent for a category of sensitive data, numbered from 1 to 8 in the order of
+// the section's specification.
+func (b *USPINBuilder) SensitiveData(category int, state sections.ConsentState) *USPINBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.SensitiveDataProcessing", b.section.CoreSegment.SensitiveDataProcessing, category, byte(state))
+	return b
+}
+
+// KnownChildSensitiveData sets the consent for processing the sensitive data of a known child, for an
+// age bracket numbered from 1 to 1.
+func (b *USPINBuilder) KnownChildSensitiveData(index int, state sections.ConsentState) *USPINBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.KnownChildSensitiveDataConsents", b.section.CoreSegment.KnownChildSensitiveDataConsents, index, byte(state))
+	return b
+}
+
+func (b *USPINBuilder) AdditionalDataProcessingConsent(value sections.ConsentState) *USPINBuilder {
+	sections.SetField(&b.err, "CoreSegment.AdditionalDataProcessingConsent", &b.section.CoreSegment.AdditionalDataProcessingConsent, byte(value))
+	return b
+}
+
+func (b *USPINBuilder) MspaCoveredTransaction(value sections.MspaMode) *USPINBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaCoveredTransaction", &b.section.CoreSegment.MspaCoveredTransaction, byte(value))
+	return b
+}
+
+func (b *USPINBuilder) MspaOptOutOptionMode(value sections.MspaMode) *USPINBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaOptOutOptionMode", &b.section.CoreSegment.MspaOptOutOptionMode, byte(value))
+	return b
+}
+
+func (b *USPINBuilder) MspaServiceProviderMode(value sections.MspaMode) *USPINBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaServiceProviderMode", &b.section.CoreSegment.MspaServiceProviderMode, byte(value))
+	return b
+}
+
+func (b *USPINBuilder) GPC(gpc bool) *USPINBuilder {
+	b.section.GPCSegment.Gpc = gpc
+	return b
+}
+
+// Build returns the section, with its Value set to its encoding, or the first error of the setters.
+func (b *USPINBuilder) Build() (USPIN, error) {
+	if b.err != nil {
+		return USPIN{}, b.err
+	}
+	uspin := b.section
+	uspin.CoreSegment.SensitiveDataProcessing = append([]byte(nil), b.section.CoreSegment.SensitiveDataProcessing...)
+	uspin.CoreSegment.KnownChildSensitiveDataConsents = append([]byte(nil), b.section.CoreSegment.KnownChildSensitiveDataConsents...)
+	uspin.Value = string(uspin.Encode(true))
+	return uspin, nil
+}
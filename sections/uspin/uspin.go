@@ -0,0 +1,162 @@
+package uspin
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+)
+
+// Numbers of sensitive data categories and of known child age brackets in the section.
+const (
+	sensitiveDataCount = 8
+	knownChildCount    = 1
+)
+
+type USPIN struct {
+	SectionID   constants.SectionID
+	Value       string
+	CoreSegment sections.CommonUSProcessingCoreSegment
+	GPCSegment  sections.CommonUSGPCSegment
+}
+
+func NewUSPIN(encoded string) (USPIN, error) {
+	uspin := USPIN{}
+
+	coreBitStream, gpcBitStream, err := sections.CreateBitStreams(encoded, true)
+	if err != nil {
+		return uspin, err
+	}
+
+	coreSegment, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, coreBitStream)
+	if err != nil {
+		return uspin, err
+	}
+
+	gpcSegment := sections.CommonUSGPCSegment{
+		SubsectionType: 1,
+		Gpc:            false,
+	}
+
+	if gpcBitStream != nil {
+		gpcSegment, err = sections.NewCommonUSGPCSegment(gpcBitStream)
+		if err != nil {
+			return uspin, err
+		}
+	}
+
+	uspin = USPIN{
+		SectionID:   constants.SectionUSPIN,
+		Value:       encoded,
+		CoreSegment: coreSegment,
+		GPCSegment:  gpcSegment,
+	}
+
+	return uspin, nil
+}
+
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (uspin USPIN) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(uspin.Value, func(bs *util.BitStream) error {
+		_, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, bs)
+		return err
+	})
+}
+
+func (uspin USPIN) Encode(gpcIncluded bool) []byte {
+	bs := util.NewBitStreamForWrite()
+	uspin.CoreSegment.Encode(bs)
+	res := bs.Base64Encode()
+	if !gpcIncluded {
+		return res
+	}
+	bs.Reset()
+	res = append(res, '.')
+	uspin.GPCSegment.Encode(bs)
+	return append(res, bs.Base64Encode()...)
+}
+
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (uspin USPIN) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(uspin.CoreSegment, sensitiveDataCount, knownChildCount); err != nil {
+		return nil, err
+	}
+	if gpcIncluded {
+		if err := uspin.GPCSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return uspin.Encode(gpcIncluded), nil
+}
+
+func (uspin USPIN) GetID() constants.SectionID {
+	return uspin.SectionID
+}
+
+func (uspin USPIN) GetValue() string {
+	return uspin.Value
+}
+
+// SaleOptOut implements sections.USPrivacySection.
+func (uspin USPIN) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(uspin.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (uspin USPIN) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspin.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection. The section has no sharing opt-out, so it is
+// always OptOutNotApplicable.
+func (uspin USPIN) SharingOptOut() sections.OptOutState {
+	return sections.OptOutNotApplicable
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (uspin USPIN) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspin.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (uspin USPIN) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspin.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (uspin USPIN) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(uspin.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (uspin USPIN) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(uspin.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (uspin USPIN) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspin.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (uspin USPIN) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspin.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection.
+func (uspin USPIN) GPC() bool {
+	return uspin.GPCSegment.Gpc
+}
+
+func (uspin USPIN) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(uspin)
+}
+
+func (uspin *USPIN) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, uspin)
+}
+
+// Validate implements sections.Validator.
+func (uspin USPIN) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(uspin.CoreSegment, sensitiveDataCount, knownChildCount)
+}
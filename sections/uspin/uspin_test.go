@@ -0,0 +1,62 @@
+package uspin
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/stretchr/testify/assert"
+)
+
+type uspinTestData struct {
+	description string
+	gppString   string
+	expected    USPIN
+}
+
+func TestUSPIN(t *testing.T) {
+	testData := []uspinTestData{
+		{
+			description: "should populate USPIN segments correctly",
+			gppString:   "BmmGGCZA.YA",
+			/*
+				000001 10 01 10 10 01 1000011000011000 00 10 01 10 01 01 1
+			*/
+			expected: USPIN{
+				CoreSegment: sections.CommonUSProcessingCoreSegment{
+					Version:                         1,
+					ProcessingNotice:                2,
+					SaleOptOutNotice:                1,
+					TargetedAdvertisingOptOutNotice: 2,
+					SaleOptOut:                      2,
+					TargetedAdvertisingOptOut:       1,
+					SensitiveDataProcessing: []byte{
+						2, 0, 1, 2, 0, 1, 2, 0,
+					},
+					KnownChildSensitiveDataConsents: []byte{0},
+					AdditionalDataProcessingConsent: 2,
+					MspaCoveredTransaction:          1,
+					MspaOptOutOptionMode:            2,
+					MspaServiceProviderMode:         1,
+				},
+				GPCSegment: sections.CommonUSGPCSegment{
+					SubsectionType: 1,
+					Gpc:            true,
+				},
+				SectionID: constants.SectionUSPIN,
+				Value:     "BmmGGCZA.YA",
+			},
+		},
+	}
+
+	for _, test := range testData {
+		result, err := NewUSPIN(test.gppString)
+		encodedString := string(test.expected.Encode(true))
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, result)
+		assert.Equal(t, constants.SectionUSPIN, result.GetID())
+		assert.Equal(t, test.gppString, result.GetValue())
+		assert.Equal(t, test.gppString, encodedString)
+	}
+}
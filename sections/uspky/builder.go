@@ -0,0 +1,104 @@
+package uspky
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+)
+
+// USPKYBuilder builds a USPKY section. Every field starts as not applicable, except
+// MspaCoveredTransaction, which starts as MspaNo, and GPC, which starts unset. Setting a field to a
+// value outside its range, or a category the section does not have, makes Build return an error.
+type USPKYBuilder struct {
+	section USPKY
+	err     error
+}
+
+// Builder returns a USPKYBuilder for version 1 of the section.
+func Builder() *USPKYBuilder {
+	return &USPKYBuilder{section: USPKY{
+		SectionID: constants.SectionUSPKY,
+		CoreSegment: sections.CommonUSProcessingCoreSegment{
+			Version:                         1,
+			SensitiveDataProcessing:         make([]byte, sensitiveDataCount),
+			KnownChildSensitiveDataConsents: make([]byte, knownChildCount),
+			MspaCoveredTransaction:          byte(sections.MspaNo),
+		},
+		GPCSegment: sections.CommonUSGPCSegment{SubsectionType: 1},
+	}}
+}
+
+func (b *USPKYBuilder) ProcessingNotice(value sections.NoticeState) *USPKYBuilder {
+	sections.SetField(&b.err, "CoreSegment.ProcessingNotice", &b.section.CoreSegment.ProcessingNotice, byte(value))
+	return b
+}
+
+func (b *USPKYBuilder) SaleOptOutNotice(value sections.NoticeState) *USPKYBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOutNotice", &b.section.CoreSegment.SaleOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPKYBuilder) TargetedAdvertisingOptOutNotice(value sections.NoticeState) *USPKYBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOutNotice", &b.section.CoreSegment.TargetedAdvertisingOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPKYBuilder) SaleOptOut(value sections.OptOutState) *USPKYBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOut", &b.section.CoreSegment.SaleOptOut, byte(value))
+	return b
+}
+
+func (b *USPKYBuilder) TargetedAdvertisingOptOut(value sections.OptOutState) *USPKYBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOut", &b.section.CoreSegment.TargetedAdvertisingOptOut, byte(value))
+	return b
+}
+
+// SensitiveData sets the consent for a category of sensitive data, numbered from 1 to 8 in the order of
+// the section's specification.
+func (b *USPKYBuilder) SensitiveData(category int, state sections.ConsentState) *USPKYBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.SensitiveDataProcessing", b.section.CoreSegment.SensitiveDataProcessing, category, byte(state))
+	return b
+}
+
+// KnownChildSensitiveData sets the consent for processing the sensitive data of a known child, for an
+// age bracket numbered from 1 to 1.
+func (b *USPKYBuilder) KnownChildSensitiveData(index int, state sections.ConsentState) *USPKYBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.KnownChildSensitiveDataConsents", b.section.CoreSegment.KnownChildSensitiveDataConsents, index, byte(state))
+	return b
+}
+
+func (b *USPKYBuilder) AdditionalDataProcessingConsent(value sections.ConsentState) *USPKYBuilder {
+	sections.SetField(&b.err, "CoreSegment.AdditionalDataProcessingConsent", &b.section.CoreSegment.AdditionalDataProcessingConsent, byte(value))
+	return b
+}
+
+func (b *USPKYBuilder) MspaCoveredTransaction(value sections.MspaMode) *USPKYBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaCoveredTransaction", &b.section.CoreSegment.MspaCoveredTransaction, byte(value))
+	return b
+}
+
+func (b *USPKYBuilder) MspaOptOutOptionMode(value sections.MspaMode) *USPKYBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaOptOutOptionMode", &b.section.CoreSegment.MspaOptOutOptionMode, byte(value))
+	return b
+}
+
+func (b *USPKYBuilder) MspaServiceProviderMode(value sections.MspaMode) *USPKYBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaServiceProviderMode", &b.section.CoreSegment.MspaServiceProviderMode, byte(value))
+	return b
+}
+
+func (b *USPKYBuilder) GPC(gpc bool) *USPKYBuilder {
+	b.section.GPCSegment.Gpc = gpc
+	return b
+}
+
+// Build returns the section, with its Value set to its encoding, or the first error of the setters.
+func (b *USPKYBuilder) Build() (USPKY, error) {
+	if b.err != nil {
+		return USPKY{}, b.err
+	}
+	uspky := b.section
+	uspky.CoreSegment.SensitiveDataProcessing = append([]byte(nil), b.section.CoreSegment.SensitiveDataProcessing...)
+	uspky.CoreSegment.KnownChildSensitiveDataConsents = append([]byte(nil), b.section.CoreSegment.KnownChildSensitiveDataConsents...)
+	uspky.Value = string(uspky.Encode(true))
+	return uspky, nil
+}
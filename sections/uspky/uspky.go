@@ -0,0 +1,162 @@
+package uspky
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+)
+
+// Numbers of sensitive data categories and of known child age brackets in the section.
+const (
+	sensitiveDataCount = 8
+	knownChildCount    = 1
+)
+
+type USPKY struct {
+	SectionID   constants.SectionID
+	Value       string
+	CoreSegment sections.CommonUSProcessingCoreSegment
+	GPCSegment  sections.CommonUSGPCSegment
+}
+
+func NewUSPKY(encoded string) (USPKY, error) {
+	uspky := USPKY{}
+
+	coreBitStream, gpcBitStream, err := sections.CreateBitStreams(encoded, true)
+	if err != nil {
+		return uspky, err
+	}
+
+	coreSegment, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, coreBitStream)
+	if err != nil {
+		return uspky, err
+	}
+
+	gpcSegment := sections.CommonUSGPCSegment{
+		SubsectionType: 1,
+		Gpc:            false,
+	}
+
+	if gpcBitStream != nil {
+		gpcSegment, err = sections.NewCommonUSGPCSegment(gpcBitStream)
+		if err != nil {
+			return uspky, err
+		}
+	}
+
+	uspky = USPKY{
+		SectionID:   constants.SectionUSPKY,
+		Value:       encoded,
+		CoreSegment: coreSegment,
+		GPCSegment:  gpcSegment,
+	}
+
+	return uspky, nil
+}
+
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (uspky USPKY) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(uspky.Value, func(bs *util.BitStream) error {
+		_, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, bs)
+		return err
+	})
+}
+
+func (uspky USPKY) Encode(gpcIncluded bool) []byte {
+	bs := util.NewBitStreamForWrite()
+	uspky.CoreSegment.Encode(bs)
+	res := bs.Base64Encode()
+	if !gpcIncluded {
+		return res
+	}
+	bs.Reset()
+	res = append(res, '.')
+	uspky.GPCSegment.Encode(bs)
+	return append(res, bs.Base64Encode()...)
+}
+
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (uspky USPKY) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(uspky.CoreSegment, sensitiveDataCount, knownChildCount); err != nil {
+		return nil, err
+	}
+	if gpcIncluded {
+		if err := uspky.GPCSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return uspky.Encode(gpcIncluded), nil
+}
+
+func (uspky USPKY) GetID() constants.SectionID {
+	return uspky.SectionID
+}
+
+func (uspky USPKY) GetValue() string {
+	return uspky.Value
+}
+
+// SaleOptOut implements sections.USPrivacySection.
+func (uspky USPKY) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(uspky.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (uspky USPKY) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspky.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection. The section has no sharing opt-out, so it is
+// always OptOutNotApplicable.
+func (uspky USPKY) SharingOptOut() sections.OptOutState {
+	return sections.OptOutNotApplicable
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (uspky USPKY) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspky.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (uspky USPKY) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspky.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (uspky USPKY) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(uspky.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (uspky USPKY) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(uspky.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (uspky USPKY) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspky.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (uspky USPKY) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspky.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection.
+func (uspky USPKY) GPC() bool {
+	return uspky.GPCSegment.Gpc
+}
+
+func (uspky USPKY) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(uspky)
+}
+
+func (uspky *USPKY) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, uspky)
+}
+
+// Validate implements sections.Validator.
+func (uspky USPKY) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(uspky.CoreSegment, sensitiveDataCount, knownChildCount)
+}
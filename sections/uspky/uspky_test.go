@@ -0,0 +1,62 @@
+package uspky
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/stretchr/testify/assert"
+)
+
+type uspkyTestData struct {
+	description string
+	gppString   string
+	expected    USPKY
+}
+
+func TestUSPKY(t *testing.T) {
+	testData := []uspkyTestData{
+		{
+			description: "should populate USPKY segments correctly",
+			gppString:   "BZYYYVWA.QA",
+			/*
+				000001 01 10 01 01 10 0001100001100001 01 01 01 01 10 01 0
+			*/
+			expected: USPKY{
+				CoreSegment: sections.CommonUSProcessingCoreSegment{
+					Version:                         1,
+					ProcessingNotice:                1,
+					SaleOptOutNotice:                2,
+					TargetedAdvertisingOptOutNotice: 1,
+					SaleOptOut:                      1,
+					TargetedAdvertisingOptOut:       2,
+					SensitiveDataProcessing: []byte{
+						0, 1, 2, 0, 1, 2, 0, 1,
+					},
+					KnownChildSensitiveDataConsents: []byte{1},
+					AdditionalDataProcessingConsent: 1,
+					MspaCoveredTransaction:          1,
+					MspaOptOutOptionMode:            1,
+					MspaServiceProviderMode:         2,
+				},
+				GPCSegment: sections.CommonUSGPCSegment{
+					SubsectionType: 1,
+					Gpc:            false,
+				},
+				SectionID: constants.SectionUSPKY,
+				Value:     "BZYYYVWA.QA",
+			},
+		},
+	}
+
+	for _, test := range testData {
+		result, err := NewUSPKY(test.gppString)
+		encodedString := string(test.expected.Encode(true))
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, result)
+		assert.Equal(t, constants.SectionUSPKY, result.GetID())
+		assert.Equal(t, test.gppString, result.GetValue())
+		assert.Equal(t, test.gppString, encodedString)
+	}
+}
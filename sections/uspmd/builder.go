@@ -0,0 +1,104 @@
+package uspmd
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+)
+
+// USPMDBuilder builds a USPMD section. Every field starts as not applicable, except
+// MspaCoveredTransaction, which starts as MspaNo, and GPC, which starts unset. Setting a field to a
+// value outside its range, or a category the section does not have, makes Build return an error.
+type USPMDBuilder struct {
+	section USPMD
+	err     error
+}
+
+// Builder returns a USPMDBuilder for version 1 of the section.
+func Builder() *USPMDBuilder {
+	return &USPMDBuilder{section: USPMD{
+		SectionID: constants.SectionUSPMD,
+		CoreSegment: sections.CommonUSProcessingCoreSegment{
+			Version:                         1,
+			SensitiveDataProcessing:         make([]byte, sensitiveDataCount),
+			KnownChildSensitiveDataConsents: make([]byte, knownChildCount),
+			MspaCoveredTransaction:          byte(sections.MspaNo),
+		},
+		GPCSegment: sections.CommonUSGPCSegment{SubsectionType: 1},
+	}}
+}
+
+func (b *USPMDBuilder) ProcessingNotice(value sections.NoticeState) *USPMDBuilder {
+	sections.SetField(&b.err, "CoreSegment.ProcessingNotice", &b.section.CoreSegment.ProcessingNotice, byte(value))
+	return b
+}
+
+func (b *USPMDBuilder) SaleOptOutNotice(value sections.NoticeState) *USPMDBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOutNotice", &b.section.CoreSegment.SaleOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPMDBuilder) TargetedAdvertisingOptOutNotice(value sections.NoticeState) *USPMDBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOutNotice", &b.section.CoreSegment.TargetedAdvertisingOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPMDBuilder) SaleOptOut(value sections.OptOutState) *USPMDBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOut", &b.section.CoreSegment.SaleOptOut, byte(value))
+	return b
+}
+
+func (b *USPMDBuilder) TargetedAdvertisingOptOut(value sections.OptOutState) *USPMDBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOut", &b.section.CoreSegment.TargetedAdvertisingOptOut, byte(value))
+	return b
+}
+
+// SensitiveData sets the consent for a category of sensitive data, numbered from 1 to 8 in the order of
+// the section's specification.
+func (b *USPMDBuilder) SensitiveData(category int, state sections.ConsentState) *USPMDBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.SensitiveDataProcessing", b.section.CoreSegment.SensitiveDataProcessing, category, byte(state))
+	return b
+}
+
+// KnownChildSensitiveData sets the consent for processing the sensitive data of a known child, for an
+// age bracket numbered from 1 to 3.
+func (b *USPMDBuilder) KnownChildSensitiveData(index int, state sections.ConsentState) *USPMDBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.KnownChildSensitiveDataConsents", b.section.CoreSegment.KnownChildSensitiveDataConsents, index, byte(state))
+	return b
+}
+
+func (b *USPMDBuilder) AdditionalDataProcessingConsent(value sections.ConsentState) *USPMDBuilder {
+	sections.SetField(&b.err, "CoreSegment.AdditionalDataProcessingConsent", &b.section.CoreSegment.AdditionalDataProcessingConsent, byte(value))
+	return b
+}
+
+func (b *USPMDBuilder) MspaCoveredTransaction(value sections.MspaMode) *USPMDBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaCoveredTransaction", &b.section.CoreSegment.MspaCoveredTransaction, byte(value))
+	return b
+}
+
+func (b *USPMDBuilder) MspaOptOutOptionMode(value sections.MspaMode) *USPMDBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaOptOutOptionMode", &b.section.CoreSegment.MspaOptOutOptionMode, byte(value))
+	return b
+}
+
+func (b *USPMDBuilder) MspaServiceProviderMode(value sections.MspaMode) *USPMDBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaServiceProviderMode", &b.section.CoreSegment.MspaServiceProviderMode, byte(value))
+	return b
+}
+
+func (b *USPMDBuilder) GPC(gpc bool) *USPMDBuilder {
+	b.section.GPCSegment.Gpc = gpc
+	return b
+}
+
+// Build returns the section, with its Value set to its encoding, or the first error of the setters.
+func (b *USPMDBuilder) Build() (USPMD, error) {
+	if b.err != nil {
+		return USPMD{}, b.err
+	}
+	uspmd := b.section
+	uspmd.CoreSegment.SensitiveDataProcessing = append([]byte(nil), b.section.CoreSegment.SensitiveDataProcessing...)
+	uspmd.CoreSegment.KnownChildSensitiveDataConsents = append([]byte(nil), b.section.CoreSegment.KnownChildSensitiveDataConsents...)
+	uspmd.Value = string(uspmd.Encode(true))
+	return uspmd, nil
+}
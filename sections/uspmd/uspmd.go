@@ -0,0 +1,162 @@
+package uspmd
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+)
+
+// Numbers of sensitive data categories and of known child age brackets in the section.
+const (
+	sensitiveDataCount = 8
+	knownChildCount    = 3
+)
+
+type USPMD struct {
+	SectionID   constants.SectionID
+	Value       string
+	CoreSegment sections.CommonUSProcessingCoreSegment
+	GPCSegment  sections.CommonUSGPCSegment
+}
+
+func NewUSPMD(encoded string) (USPMD, error) {
+	uspmd := USPMD{}
+
+	coreBitStream, gpcBitStream, err := sections.CreateBitStreams(encoded, true)
+	if err != nil {
+		return uspmd, err
+	}
+
+	coreSegment, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, coreBitStream)
+	if err != nil {
+		return uspmd, err
+	}
+
+	gpcSegment := sections.CommonUSGPCSegment{
+		SubsectionType: 1,
+		Gpc:            false,
+	}
+
+	if gpcBitStream != nil {
+		gpcSegment, err = sections.NewCommonUSGPCSegment(gpcBitStream)
+		if err != nil {
+			return uspmd, err
+		}
+	}
+
+	uspmd = USPMD{
+		SectionID:   constants.SectionUSPMD,
+		Value:       encoded,
+		CoreSegment: coreSegment,
+		GPCSegment:  gpcSegment,
+	}
+
+	return uspmd, nil
+}
+
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (uspmd USPMD) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(uspmd.Value, func(bs *util.BitStream) error {
+		_, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, bs)
+		return err
+	})
+}
+
+func (uspmd USPMD) Encode(gpcIncluded bool) []byte {
+	bs := util.NewBitStreamForWrite()
+	uspmd.CoreSegment.Encode(bs)
+	res := bs.Base64Encode()
+	if !gpcIncluded {
+		return res
+	}
+	bs.Reset()
+	res = append(res, '.')
+	uspmd.GPCSegment.Encode(bs)
+	return append(res, bs.Base64Encode()...)
+}
+
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (uspmd USPMD) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(uspmd.CoreSegment, sensitiveDataCount, knownChildCount); err != nil {
+		return nil, err
+	}
+	if gpcIncluded {
+		if err := uspmd.GPCSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return uspmd.Encode(gpcIncluded), nil
+}
+
+func (uspmd USPMD) GetID() constants.SectionID {
+	return uspmd.SectionID
+}
+
+func (uspmd USPMD) GetValue() string {
+	return uspmd.Value
+}
+
+// SaleOptOut implements sections.USPrivacySection.
+func (uspmd USPMD) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(uspmd.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (uspmd USPMD) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspmd.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection. The section has no sharing opt-out, so it is
+// always OptOutNotApplicable.
+func (uspmd USPMD) SharingOptOut() sections.OptOutState {
+	return sections.OptOutNotApplicable
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (uspmd USPMD) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspmd.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (uspmd USPMD) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspmd.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (uspmd USPMD) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(uspmd.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (uspmd USPMD) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(uspmd.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (uspmd USPMD) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspmd.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (uspmd USPMD) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspmd.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection.
+func (uspmd USPMD) GPC() bool {
+	return uspmd.GPCSegment.Gpc
+}
+
+func (uspmd USPMD) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(uspmd)
+}
+
+func (uspmd *USPMD) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, uspmd)
+}
+
+// Validate implements sections.Validator.
+func (uspmd USPMD) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(uspmd.CoreSegment, sensitiveDataCount, knownChildCount)
+}
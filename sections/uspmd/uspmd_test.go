@@ -0,0 +1,62 @@
+package uspmd
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/stretchr/testify/assert"
+)
+
+type uspmdTestData struct {
+	description string
+	gppString   string
+	expected    USPMD
+}
+
+func TestUSPMD(t *testing.T) {
+	testData := []uspmdTestData{
+		{
+			description: "should populate USPMD segments correctly",
+			gppString:   "BZZhhpFY.YA",
+			/*
+				000001 01 10 01 01 10 0110000110000110 100100 01 01 01 10 01 1
+			*/
+			expected: USPMD{
+				CoreSegment: sections.CommonUSProcessingCoreSegment{
+					Version:                         1,
+					ProcessingNotice:                1,
+					SaleOptOutNotice:                2,
+					TargetedAdvertisingOptOutNotice: 1,
+					SaleOptOut:                      1,
+					TargetedAdvertisingOptOut:       2,
+					SensitiveDataProcessing: []byte{
+						1, 2, 0, 1, 2, 0, 1, 2,
+					},
+					KnownChildSensitiveDataConsents: []byte{2, 1, 0},
+					AdditionalDataProcessingConsent: 1,
+					MspaCoveredTransaction:          1,
+					MspaOptOutOptionMode:            1,
+					MspaServiceProviderMode:         2,
+				},
+				GPCSegment: sections.CommonUSGPCSegment{
+					SubsectionType: 1,
+					Gpc:            true,
+				},
+				SectionID: constants.SectionUSPMD,
+				Value:     "BZZhhpFY.YA",
+			},
+		},
+	}
+
+	for _, test := range testData {
+		result, err := NewUSPMD(test.gppString)
+		encodedString := string(test.expected.Encode(true))
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, result)
+		assert.Equal(t, constants.SectionUSPMD, result.GetID())
+		assert.Equal(t, test.gppString, result.GetValue())
+		assert.Equal(t, test.gppString, encodedString)
+	}
+}
@@ -0,0 +1,162 @@
+package uspmn
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+)
+
+// Numbers of sensitive data categories and of known child age brackets in the section.
+const (
+	sensitiveDataCount = 8
+	knownChildCount    = 1
+)
+
+type USPMN struct {
+	SectionID   constants.SectionID
+	Value       string
+	CoreSegment sections.CommonUSProcessingCoreSegment
+	GPCSegment  sections.CommonUSGPCSegment
+}
+
+func NewUSPMN(encoded string) (USPMN, error) {
+	uspmn := USPMN{}
+
+	coreBitStream, gpcBitStream, err := sections.CreateBitStreams(encoded, true)
+	if err != nil {
+		return uspmn, err
+	}
+
+	coreSegment, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, coreBitStream)
+	if err != nil {
+		return uspmn, err
+	}
+
+	gpcSegment := sections.CommonUSGPCSegment{
+		SubsectionType: 1,
+		Gpc:            false,
+	}
+
+	if gpcBitStream != nil {
+		gpcSegment, err = sections.NewCommonUSGPCSegment(gpcBitStream)
+		if err != nil {
+			return uspmn, err
+		}
+	}
+
+	uspmn = USPMN{
+		SectionID:   constants.SectionUSPMN,
+		Value:       encoded,
+		CoreSegment: coreSegment,
+		GPCSegment:  gpcSegment,
+	}
+
+	return uspmn, nil
+}
+
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (uspmn USPMN) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(uspmn.Value, func(bs *util.BitStream) error {
+		_, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, bs)
+		return err
+	})
+}
+
+func (uspmn USPMN) Encode(gpcIncluded bool) []byte {
+	bs := util.NewBitStreamForWrite()
+	uspmn.CoreSegment.Encode(bs)
+	res := bs.Base64Encode()
+	if !gpcIncluded {
+		return res
+	}
+	bs.Reset()
+	res = append(res, '.')
+	uspmn.GPCSegment.Encode(bs)
+	return append(res, bs.Base64Encode()...)
+}
+
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (uspmn USPMN) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(uspmn.CoreSegment, sensitiveDataCount, knownChildCount); err != nil {
+		return nil, err
+	}
+	if gpcIncluded {
+		if err := uspmn.GPCSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return uspmn.Encode(gpcIncluded), nil
+}
+
+func (uspmn USPMN) GetID() constants.SectionID {
+	return uspmn.SectionID
+}
+
+func (uspmn USPMN) GetValue() string {
+	return uspmn.Value
+}
+
+// SaleOptOut implements sections.USPrivacySection.
+func (uspmn USPMN) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(uspmn.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (uspmn USPMN) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspmn.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection. The section has no sharing opt-out, so it is
+// always OptOutNotApplicable.
+func (uspmn USPMN) SharingOptOut() sections.OptOutState {
+	return sections.OptOutNotApplicable
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (uspmn USPMN) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspmn.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (uspmn USPMN) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspmn.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (uspmn USPMN) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(uspmn.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (uspmn USPMN) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(uspmn.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (uspmn USPMN) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspmn.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (uspmn USPMN) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspmn.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection.
+func (uspmn USPMN) GPC() bool {
+	return uspmn.GPCSegment.Gpc
+}
+
+func (uspmn USPMN) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(uspmn)
+}
+
+func (uspmn *USPMN) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, uspmn)
+}
+
+// Validate implements sections.Validator.
+func (uspmn USPMN) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(uspmn.CoreSegment, sensitiveDataCount, knownChildCount)
+}
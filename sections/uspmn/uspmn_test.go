@@ -0,0 +1,62 @@
+package uspmn
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/stretchr/testify/assert"
+)
+
+type uspmnTestData struct {
+	description string
+	gppString   string
+	expected    USPMN
+}
+
+func TestUSPMN(t *testing.T) {
+	testData := []uspmnTestData{
+		{
+			description: "should populate USPMN segments correctly",
+			gppString:   "BmkYYWZA.QA",
+			/*
+				000001 10 01 10 10 01 0001100001100001 01 10 01 10 01 01 0
+			*/
+			expected: USPMN{
+				CoreSegment: sections.CommonUSProcessingCoreSegment{
+					Version:                         1,
+					ProcessingNotice:                2,
+					SaleOptOutNotice:                1,
+					TargetedAdvertisingOptOutNotice: 2,
+					SaleOptOut:                      2,
+					TargetedAdvertisingOptOut:       1,
+					SensitiveDataProcessing: []byte{
+						0, 1, 2, 0, 1, 2, 0, 1,
+					},
+					KnownChildSensitiveDataConsents: []byte{1},
+					AdditionalDataProcessingConsent: 2,
+					MspaCoveredTransaction:          1,
+					MspaOptOutOptionMode:            2,
+					MspaServiceProviderMode:         1,
+				},
+				GPCSegment: sections.CommonUSGPCSegment{
+					SubsectionType: 1,
+					Gpc:            false,
+				},
+				SectionID: constants.SectionUSPMN,
+				Value:     "BmkYYWZA.QA",
+			},
+		},
+	}
+
+	for _, test := range testData {
+		result, err := NewUSPMN(test.gppString)
+		encodedString := string(test.expected.Encode(true))
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, result)
+		assert.Equal(t, constants.SectionUSPMN, result.GetID())
+		assert.Equal(t, test.gppString, result.GetValue())
+		assert.Equal(t, test.gppString, encodedString)
+	}
+}
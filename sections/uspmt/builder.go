@@ -0,0 +1,104 @@
+package uspmt
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+)
+
+// USPMTBuilder builds a USPMT section. Every field starts as not applicable, except
+// MspaCoveredTransaction, which starts as MspaNo, and GPC, which starts unset. Setting a field to a
+// value outside its range, or a category the section does not have, makes Build return an error.
+type USPMTBuilder struct {
+	section USPMT
+	err     error
+}
+
+// Builder returns a USPMTBuilder for version 1 of the section.
+func Builder() *USPMTBuilder {
+	return &USPMTBuilder{section: USPMT{
+		SectionID: constants.SectionUSPMT,
+		CoreSegment: USPMTCoreSegment{
+			Version:                         1,
+			SensitiveDataProcessing:         make([]byte, sensitiveDataCount),
+			KnownChildSensitiveDataConsents: make([]byte, knownChildCount),
+			MspaCoveredTransaction:          byte(sections.MspaNo),
+		},
+		GPCSegment: sections.CommonUSGPCSegment{SubsectionType: 1},
+	}}
+}
+
+func (b *USPMTBuilder) SharingNotice(value sections.NoticeState) *USPMTBuilder {
+	sections.SetField(&b.err, "CoreSegment.SharingNotice", &b.section.CoreSegment.SharingNotice, byte(value))
+	return b
+}
+
+func (b *USPMTBuilder) SaleOptOutNotice(value sections.NoticeState) *USPMTBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOutNotice", &b.section.CoreSegment.SaleOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPMTBuilder) TargetedAdvertisingOptOutNotice(value sections.NoticeState) *USPMTBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOutNotice", &b.section.CoreSegment.TargetedAdvertisingOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPMTBuilder) SaleOptOut(value sections.OptOutState) *USPMTBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOut", &b.section.CoreSegment.SaleOptOut, byte(value))
+	return b
+}
+
+func (b *USPMTBuilder) TargetedAdvertisingOptOut(value sections.OptOutState) *USPMTBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOut", &b.section.CoreSegment.TargetedAdvertisingOptOut, byte(value))
+	return b
+}
+
+// SensitiveData sets the consent for a category of sensitive data, numbered from 1 to 8 in the order of
+// the section's specification.
+func (b *USPMTBuilder) SensitiveData(category int, state sections.ConsentState) *USPMTBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.SensitiveDataProcessing", b.section.CoreSegment.SensitiveDataProcessing, category, byte(state))
+	return b
+}
+
+// KnownChildSensitiveData sets the consent for processing the sensitive data of a known child, for an
+// age bracket numbered from 1 to 3.
+func (b *USPMTBuilder) KnownChildSensitiveData(index int, state sections.ConsentState) *USPMTBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.KnownChildSensitiveDataConsents", b.section.CoreSegment.KnownChildSensitiveDataConsents, index, byte(state))
+	return b
+}
+
+func (b *USPMTBuilder) AdditionalDataProcessingConsent(value sections.ConsentState) *USPMTBuilder {
+	sections.SetField(&b.err, "CoreSegment.AdditionalDataProcessingConsent", &b.section.CoreSegment.AdditionalDataProcessingConsent, byte(value))
+	return b
+}
+
+func (b *USPMTBuilder) MspaCoveredTransaction(value sections.MspaMode) *USPMTBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaCoveredTransaction", &b.section.CoreSegment.MspaCoveredTransaction, byte(value))
+	return b
+}
+
+func (b *USPMTBuilder) MspaOptOutOptionMode(value sections.MspaMode) *USPMTBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaOptOutOptionMode", &b.section.CoreSegment.MspaOptOutOptionMode, byte(value))
+	return b
+}
+
+func (b *USPMTBuilder) MspaServiceProviderMode(value sections.MspaMode) *USPMTBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaServiceProviderMode", &b.section.CoreSegment.MspaServiceProviderMode, byte(value))
+	return b
+}
+
+func (b *USPMTBuilder) GPC(gpc bool) *USPMTBuilder {
+	b.section.GPCSegment.Gpc = gpc
+	return b
+}
+
+// Build returns the section, with its Value set to its encoding, or the first error of the setters.
+func (b *USPMTBuilder) Build() (USPMT, error) {
+	if b.err != nil {
+		return USPMT{}, b.err
+	}
+	uspmt := b.section
+	uspmt.CoreSegment.SensitiveDataProcessing = append([]byte(nil), b.section.CoreSegment.SensitiveDataProcessing...)
+	uspmt.CoreSegment.KnownChildSensitiveDataConsents = append([]byte(nil), b.section.CoreSegment.KnownChildSensitiveDataConsents...)
+	uspmt.Value = string(uspmt.Encode(true))
+	return uspmt, nil
+}
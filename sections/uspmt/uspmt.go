@@ -0,0 +1,269 @@
+package uspmt
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+)
+
+// Numbers of sensitive data categories and of known child age brackets in the section.
+const (
+	sensitiveDataCount = 8
+	knownChildCount    = 3
+)
+
+// USPMTCoreSegment has the layout of sections.CommonUSProcessingCoreSegment, but names its first notice
+// SharingNotice as the Montana specification does.
+type USPMTCoreSegment struct {
+	Version                         byte
+	SharingNotice                   byte
+	SaleOptOutNotice                byte
+	TargetedAdvertisingOptOutNotice byte
+	SaleOptOut                      byte
+	TargetedAdvertisingOptOut       byte
+	SensitiveDataProcessing         []byte
+	KnownChildSensitiveDataConsents []byte
+	AdditionalDataProcessingConsent byte
+	MspaCoveredTransaction          byte
+	MspaOptOutOptionMode            byte
+	MspaServiceProviderMode         byte
+}
+
+type USPMT struct {
+	SectionID   constants.SectionID
+	Value       string
+	CoreSegment USPMTCoreSegment
+	GPCSegment  sections.CommonUSGPCSegment
+}
+
+func NewUSPMTCoreSegment(bs *util.BitStream) (USPMTCoreSegment, error) {
+	var uspmtCore USPMTCoreSegment
+	var err error
+
+	uspmtCore.Version, err = bs.ReadByte6()
+	if err != nil {
+		return uspmtCore, sections.ErrorHelper("CoreSegment.Version", err)
+	}
+
+	uspmtCore.SharingNotice, err = bs.ReadByte2()
+	if err != nil {
+		return uspmtCore, sections.ErrorHelper("CoreSegment.SharingNotice", err)
+	}
+
+	uspmtCore.SaleOptOutNotice, err = bs.ReadByte2()
+	if err != nil {
+		return uspmtCore, sections.ErrorHelper("CoreSegment.SaleOptOutNotice", err)
+	}
+
+	uspmtCore.TargetedAdvertisingOptOutNotice, err = bs.ReadByte2()
+	if err != nil {
+		return uspmtCore, sections.ErrorHelper("CoreSegment.TargetedAdvertisingOptOutNotice", err)
+	}
+
+	uspmtCore.SaleOptOut, err = bs.ReadByte2()
+	if err != nil {
+		return uspmtCore, sections.ErrorHelper("CoreSegment.SaleOptOut", err)
+	}
+
+	uspmtCore.TargetedAdvertisingOptOut, err = bs.ReadByte2()
+	if err != nil {
+		return uspmtCore, sections.ErrorHelper("CoreSegment.TargetedAdvertisingOptOut", err)
+	}
+
+	uspmtCore.SensitiveDataProcessing, err = bs.ReadTwoBitField(sensitiveDataCount)
+	if err != nil {
+		return uspmtCore, sections.ErrorHelper("CoreSegment.SensitiveDataProcessing", err)
+	}
+
+	uspmtCore.KnownChildSensitiveDataConsents, err = bs.ReadTwoBitField(knownChildCount)
+	if err != nil {
+		return uspmtCore, sections.ErrorHelper("CoreSegment.KnownChildSensitiveDataConsents", err)
+	}
+
+	uspmtCore.AdditionalDataProcessingConsent, err = bs.ReadByte2()
+	if err != nil {
+		return uspmtCore, sections.ErrorHelper("CoreSegment.AdditionalDataProcessingConsent", err)
+	}
+
+	uspmtCore.MspaCoveredTransaction, err = bs.ReadByte2()
+	if err != nil {
+		return uspmtCore, sections.ErrorHelper("CoreSegment.MspaCoveredTransaction", err)
+	}
+
+	uspmtCore.MspaOptOutOptionMode, err = bs.ReadByte2()
+	if err != nil {
+		return uspmtCore, sections.ErrorHelper("CoreSegment.MspaOptOutOptionMode", err)
+	}
+
+	uspmtCore.MspaServiceProviderMode, err = bs.ReadByte2()
+	if err != nil {
+		return uspmtCore, sections.ErrorHelper("CoreSegment.MspaServiceProviderMode", err)
+	}
+
+	return uspmtCore, nil
+}
+
+func (segment USPMTCoreSegment) Encode(bs *util.BitStream) {
+	bs.WriteByte6(segment.Version)
+	bs.WriteByte2(segment.SharingNotice)
+	bs.WriteByte2(segment.SaleOptOutNotice)
+	bs.WriteByte2(segment.TargetedAdvertisingOptOutNotice)
+	bs.WriteByte2(segment.SaleOptOut)
+	bs.WriteByte2(segment.TargetedAdvertisingOptOut)
+	bs.WriteTwoBitField(segment.SensitiveDataProcessing)
+	bs.WriteTwoBitField(segment.KnownChildSensitiveDataConsents)
+	bs.WriteByte2(segment.AdditionalDataProcessingConsent)
+	bs.WriteByte2(segment.MspaCoveredTransaction)
+	bs.WriteByte2(segment.MspaOptOutOptionMode)
+	bs.WriteByte2(segment.MspaServiceProviderMode)
+}
+
+func NewUSPMT(encoded string) (USPMT, error) {
+	uspmt := USPMT{}
+
+	coreBitStream, gpcBitStream, err := sections.CreateBitStreams(encoded, true)
+	if err != nil {
+		return uspmt, err
+	}
+
+	coreSegment, err := NewUSPMTCoreSegment(coreBitStream)
+	if err != nil {
+		return uspmt, err
+	}
+
+	gpcSegment := sections.CommonUSGPCSegment{
+		SubsectionType: 1,
+		Gpc:            false,
+	}
+
+	if gpcBitStream != nil {
+		gpcSegment, err = sections.NewCommonUSGPCSegment(gpcBitStream)
+		if err != nil {
+			return uspmt, err
+		}
+	}
+
+	uspmt = USPMT{
+		SectionID:   constants.SectionUSPMT,
+		Value:       encoded,
+		CoreSegment: coreSegment,
+		GPCSegment:  gpcSegment,
+	}
+
+	return uspmt, nil
+}
+
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (uspmt USPMT) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(uspmt.Value, func(bs *util.BitStream) error {
+		_, err := NewUSPMTCoreSegment(bs)
+		return err
+	})
+}
+
+func (uspmt USPMT) Encode(gpcIncluded bool) []byte {
+	bs := util.NewBitStreamForWrite()
+	uspmt.CoreSegment.Encode(bs)
+	res := bs.Base64Encode()
+	if !gpcIncluded {
+		return res
+	}
+	bs.Reset()
+	res = append(res, '.')
+	uspmt.GPCSegment.Encode(bs)
+	return append(res, bs.Base64Encode()...)
+}
+
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (uspmt USPMT) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(uspmt.CoreSegment, sensitiveDataCount, knownChildCount); err != nil {
+		return nil, err
+	}
+	if gpcIncluded {
+		if err := uspmt.GPCSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return uspmt.Encode(gpcIncluded), nil
+}
+
+func (uspmt USPMT) GetID() constants.SectionID {
+	return uspmt.SectionID
+}
+
+func (uspmt USPMT) GetValue() string {
+	return uspmt.Value
+}
+
+// SaleOptOut implements sections.USPrivacySection.
+func (uspmt USPMT) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(uspmt.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (uspmt USPMT) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspmt.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection. The section has no sharing opt-out, so it is
+// always OptOutNotApplicable.
+func (uspmt USPMT) SharingOptOut() sections.OptOutState {
+	return sections.OptOutNotApplicable
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (uspmt USPMT) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspmt.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (uspmt USPMT) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspmt.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (uspmt USPMT) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(uspmt.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (uspmt USPMT) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(uspmt.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (uspmt USPMT) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspmt.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (uspmt USPMT) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspmt.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection.
+func (uspmt USPMT) GPC() bool {
+	return uspmt.GPCSegment.Gpc
+}
+
+func (segment USPMTCoreSegment) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(segment)
+}
+
+func (segment *USPMTCoreSegment) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, segment)
+}
+
+func (uspmt USPMT) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(uspmt)
+}
+
+func (uspmt *USPMT) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, uspmt)
+}
+
+// Validate implements sections.Validator.
+func (uspmt USPMT) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(uspmt.CoreSegment, sensitiveDataCount, knownChildCount)
+}
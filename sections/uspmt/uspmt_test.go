@@ -0,0 +1,78 @@
+package uspmt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/stretchr/testify/assert"
+)
+
+type uspmtTestData struct {
+	description string
+	gppString   string
+	expected    USPMT
+}
+
+func TestUSPMT(t *testing.T) {
+	testData := []uspmtTestData{
+		{
+			description: "should populate USPMT segments correctly",
+			gppString:   "BmlhhpJk.YA",
+			/*
+				000001 10 01 10 10 01 0110000110000110 100100 10 01 10 01 01 1
+			*/
+			expected: USPMT{
+				CoreSegment: USPMTCoreSegment{
+					Version:                         1,
+					SharingNotice:                   2,
+					SaleOptOutNotice:                1,
+					TargetedAdvertisingOptOutNotice: 2,
+					SaleOptOut:                      2,
+					TargetedAdvertisingOptOut:       1,
+					SensitiveDataProcessing: []byte{
+						1, 2, 0, 1, 2, 0, 1, 2,
+					},
+					KnownChildSensitiveDataConsents: []byte{2, 1, 0},
+					AdditionalDataProcessingConsent: 2,
+					MspaCoveredTransaction:          1,
+					MspaOptOutOptionMode:            2,
+					MspaServiceProviderMode:         1,
+				},
+				GPCSegment: sections.CommonUSGPCSegment{
+					SubsectionType: 1,
+					Gpc:            true,
+				},
+				SectionID: constants.SectionUSPMT,
+				Value:     "BmlhhpJk.YA",
+			},
+		},
+	}
+
+	for _, test := range testData {
+		result, err := NewUSPMT(test.gppString)
+		encodedString := string(test.expected.Encode(true))
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, result)
+		assert.Equal(t, constants.SectionUSPMT, result.GetID())
+		assert.Equal(t, test.gppString, result.GetValue())
+		assert.Equal(t, test.gppString, encodedString)
+	}
+}
+
+func TestUSPMTSharingNotice(t *testing.T) {
+	section, err := Builder().SharingNotice(sections.NoticeProvided).Build()
+	assert.Nil(t, err)
+	assert.Equal(t, byte(1), section.CoreSegment.SharingNotice)
+
+	data, err := json.Marshal(section)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"sharing_notice":"provided"`)
+	assert.NotContains(t, string(data), "processing_notice")
+
+	var decoded USPMT
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, section, decoded)
+}
@@ -0,0 +1,124 @@
+package uspnat
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+)
+
+// USPNATBuilder builds a USPNAT section. Every field starts as not applicable, except
+// MspaCoveredTransaction, which starts as MspaNo, and GPC, which starts unset. Setting a field to a
+// value outside its range, or a category the section does not have, makes Build return an error.
+type USPNATBuilder struct {
+	section USPNAT
+	err     error
+}
+
+// Builder returns a USPNATBuilder for version 1 of the section.
+func Builder() *USPNATBuilder {
+	return &USPNATBuilder{section: USPNAT{
+		SectionID: constants.SectionUSPNAT,
+		CoreSegment: USPNATCoreSegment{
+			Version:                         1,
+			SensitiveDataProcessing:         make([]byte, 12),
+			KnownChildSensitiveDataConsents: make([]byte, 2),
+			MspaCoveredTransaction:          byte(sections.MspaNo),
+		},
+		GPCSegment: sections.CommonUSGPCSegment{SubsectionType: 1},
+	}}
+}
+
+func (b *USPNATBuilder) SharingNotice(value sections.NoticeState) *USPNATBuilder {
+	sections.SetField(&b.err, "CoreSegment.SharingNotice", &b.section.CoreSegment.SharingNotice, byte(value))
+	return b
+}
+
+func (b *USPNATBuilder) SaleOptOutNotice(value sections.NoticeState) *USPNATBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOutNotice", &b.section.CoreSegment.SaleOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPNATBuilder) SharingOptOutNotice(value sections.NoticeState) *USPNATBuilder {
+	sections.SetField(&b.err, "CoreSegment.SharingOptOutNotice", &b.section.CoreSegment.SharingOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPNATBuilder) TargetedAdvertisingOptOutNotice(value sections.NoticeState) *USPNATBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOutNotice", &b.section.CoreSegment.TargetedAdvertisingOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPNATBuilder) SensitiveDataProcessingOptOutNotice(value sections.NoticeState) *USPNATBuilder {
+	sections.SetField(&b.err, "CoreSegment.SensitiveDataProcessingOptOutNotice", &b.section.CoreSegment.SensitiveDataProcessingOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPNATBuilder) SensitiveDataLimitUseNotice(value sections.NoticeState) *USPNATBuilder {
+	sections.SetField(&b.err, "CoreSegment.SensitiveDataLimitUseNotice", &b.section.CoreSegment.SensitiveDataLimitUseNotice, byte(value))
+	return b
+}
+
+func (b *USPNATBuilder) SaleOptOut(value sections.OptOutState) *USPNATBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOut", &b.section.CoreSegment.SaleOptOut, byte(value))
+	return b
+}
+
+func (b *USPNATBuilder) SharingOptOut(value sections.OptOutState) *USPNATBuilder {
+	sections.SetField(&b.err, "CoreSegment.SharingOptOut", &b.section.CoreSegment.SharingOptOut, byte(value))
+	return b
+}
+
+func (b *USPNATBuilder) TargetedAdvertisingOptOut(value sections.OptOutState) *USPNATBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOut", &b.section.CoreSegment.TargetedAdvertisingOptOut, byte(value))
+	return b
+}
+
+// SensitiveData sets the consent for a category of sensitive data, numbered from 1 to 12 in the order of
+// the section's specification.
+func (b *USPNATBuilder) SensitiveData(category int, state sections.ConsentState) *USPNATBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.SensitiveDataProcessing", b.section.CoreSegment.SensitiveDataProcessing, category, byte(state))
+	return b
+}
+
+// KnownChildSensitiveData sets the consent for processing the sensitive data of a known child, for an
+// age bracket numbered from 1 to 2.
+func (b *USPNATBuilder) KnownChildSensitiveData(index int, state sections.ConsentState) *USPNATBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.KnownChildSensitiveDataConsents", b.section.CoreSegment.KnownChildSensitiveDataConsents, index, byte(state))
+	return b
+}
+
+func (b *USPNATBuilder) PersonalDataConsents(value sections.ConsentState) *USPNATBuilder {
+	sections.SetField(&b.err, "CoreSegment.PersonalDataConsents", &b.section.CoreSegment.PersonalDataConsents, byte(value))
+	return b
+}
+
+func (b *USPNATBuilder) MspaCoveredTransaction(value sections.MspaMode) *USPNATBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaCoveredTransaction", &b.section.CoreSegment.MspaCoveredTransaction, byte(value))
+	return b
+}
+
+func (b *USPNATBuilder) MspaOptOutOptionMode(value sections.MspaMode) *USPNATBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaOptOutOptionMode", &b.section.CoreSegment.MspaOptOutOptionMode, byte(value))
+	return b
+}
+
+func (b *USPNATBuilder) MspaServiceProviderMode(value sections.MspaMode) *USPNATBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaServiceProviderMode", &b.section.CoreSegment.MspaServiceProviderMode, byte(value))
+	return b
+}
+
+func (b *USPNATBuilder) GPC(gpc bool) *USPNATBuilder {
+	b.section.GPCSegment.Gpc = gpc
+	return b
+}
+
+// Build returns the section, with its Value set to its encoding, or the first error of the setters.
+func (b *USPNATBuilder) Build() (USPNAT, error) {
+	if b.err != nil {
+		return USPNAT{}, b.err
+	}
+	uspnat := b.section
+	uspnat.CoreSegment.SensitiveDataProcessing = append([]byte(nil), b.section.CoreSegment.SensitiveDataProcessing...)
+	uspnat.CoreSegment.KnownChildSensitiveDataConsents = append([]byte(nil), b.section.CoreSegment.KnownChildSensitiveDataConsents...)
+	uspnat.Value = string(uspnat.Encode(true))
+	return uspnat, nil
+}
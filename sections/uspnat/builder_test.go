@@ -0,0 +1,96 @@
+package uspnat
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUSPNATBuilder(t *testing.T) {
+	uspnat, err := Builder().
+		SharingNotice(sections.NoticeProvided).
+		SharingOptOutNotice(sections.NoticeNotProvided).
+		SensitiveDataProcessingOptOutNotice(sections.NoticeNotProvided).
+		SensitiveDataLimitUseNotice(sections.NoticeProvided).
+		SaleOptOut(sections.DidNotOptOut).
+		SensitiveData(1, sections.Consent).
+		SensitiveData(2, sections.NoConsent).
+		SensitiveData(3, sections.Consent).
+		SensitiveData(4, sections.Consent).
+		SensitiveData(5, sections.NoConsent).
+		SensitiveData(7, sections.Consent).
+		SensitiveData(8, sections.Consent).
+		SensitiveData(10, sections.NoConsent).
+		SensitiveData(11, sections.Consent).
+		SensitiveData(12, sections.NoConsent).
+		KnownChildSensitiveData(2, sections.Consent).
+		PersonalDataConsents(sections.NoConsent).
+		MspaCoveredTransaction(sections.MspaYes).
+		MspaServiceProviderMode(sections.MspaNo).
+		GPC(true).
+		Build()
+
+	assert.Nil(t, err)
+	assert.Equal(t, constants.SectionUSPNAT, uspnat.GetID())
+	assert.Equal(t, "BSJgmkoZJSA.YA", uspnat.GetValue())
+
+	parsed, err := NewUSPNAT(uspnat.GetValue())
+	assert.Nil(t, err)
+	assert.Equal(t, uspnat, parsed)
+}
+
+func TestUSPNATBuilderDefaults(t *testing.T) {
+	uspnat, err := Builder().Build()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, uspnat.CoreSegment.SensitiveDataProcessing)
+	assert.Equal(t, []byte{0, 0}, uspnat.CoreSegment.KnownChildSensitiveDataConsents)
+	assert.Equal(t, byte(sections.MspaNo), uspnat.CoreSegment.MspaCoveredTransaction)
+	assert.False(t, uspnat.GPC())
+}
+
+func TestUSPNATBuilderErrors(t *testing.T) {
+	testData := []struct {
+		description string
+		builder     *USPNATBuilder
+		expectedErr string
+	}{
+		{
+			description: "should reject an opt-out out of range",
+			builder:     Builder().SaleOptOut(3),
+			expectedErr: "invalid value 3 for field CoreSegment.SaleOptOut, should be 0 to 2: out of range",
+		},
+		{
+			description: "should reject a sensitive data category the section does not have",
+			builder:     Builder().SensitiveData(13, sections.Consent),
+			expectedErr: "invalid index 13 for field CoreSegment.SensitiveDataProcessing, should be 1 to 12: out of range",
+		},
+		{
+			description: "should reject a not applicable MspaCoveredTransaction",
+			builder:     Builder().MspaCoveredTransaction(sections.MspaNotApplicable),
+			expectedErr: "invalid value 0 for field CoreSegment.MspaCoveredTransaction, should be 1 to 2: out of range",
+		},
+		{
+			description: "should report the first error",
+			builder:     Builder().KnownChildSensitiveData(0, sections.Consent).SaleOptOut(3),
+			expectedErr: "invalid index 0 for field CoreSegment.KnownChildSensitiveDataConsents, should be 1 to 2: out of range",
+		},
+	}
+
+	for _, test := range testData {
+		_, err := test.builder.Build()
+		assert.EqualError(t, err, test.expectedErr, test.description)
+	}
+}
+
+func TestUSPNATBuilderReuse(t *testing.T) {
+	builder := Builder().SensitiveData(1, sections.Consent)
+	first, err := builder.Build()
+	assert.Nil(t, err)
+
+	_, err = builder.SensitiveData(1, sections.NoConsent).Build()
+	assert.Nil(t, err)
+	assert.Equal(t, sections.Consent, first.SensitiveDataConsent(1))
+}
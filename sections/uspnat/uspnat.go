@@ -173,6 +173,14 @@ func NewUSPNAT(encoded string) (USPNAT, error) {
 	return uspnat, nil
 }
 
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (uspnat USPNAT) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(uspnat.Value, func(bs *util.BitStream) error {
+		_, err := NewUSPNATCoreSegment(bs)
+		return err
+	})
+}
+
 func (uspnat USPNAT) Encode(gpcIncluded bool) []byte {
 	bs := util.NewBitStreamForWrite()
 	uspnat.CoreSegment.Encode(bs)
@@ -186,6 +194,20 @@ func (uspnat USPNAT) Encode(gpcIncluded bool) []byte {
 	return append(res, bs.Base64Encode()...)
 }
 
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (uspnat USPNAT) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(uspnat.CoreSegment, 12, 2); err != nil {
+		return nil, err
+	}
+	if gpcIncluded {
+		if err := uspnat.GPCSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return uspnat.Encode(gpcIncluded), nil
+}
+
 func (uspnat USPNAT) GetID() constants.SectionID {
 	return uspnat.SectionID
 }
@@ -193,3 +215,74 @@ func (uspnat USPNAT) GetID() constants.SectionID {
 func (uspnat USPNAT) GetValue() string {
 	return uspnat.Value
 }
+
+// SaleOptOut implements sections.USPrivacySection.
+func (uspnat USPNAT) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(uspnat.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (uspnat USPNAT) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspnat.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection.
+func (uspnat USPNAT) SharingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspnat.CoreSegment.SharingOptOut)
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (uspnat USPNAT) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspnat.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (uspnat USPNAT) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspnat.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (uspnat USPNAT) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(uspnat.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (uspnat USPNAT) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(uspnat.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (uspnat USPNAT) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspnat.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (uspnat USPNAT) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspnat.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection.
+func (uspnat USPNAT) GPC() bool {
+	return uspnat.GPCSegment.Gpc
+}
+
+func (segment USPNATCoreSegment) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(segment)
+}
+
+func (segment *USPNATCoreSegment) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, segment)
+}
+
+func (uspnat USPNAT) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(uspnat)
+}
+
+func (uspnat *USPNAT) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, uspnat)
+}
+
+// Validate implements sections.Validator.
+func (uspnat USPNAT) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(uspnat.CoreSegment, 12, 2)
+}
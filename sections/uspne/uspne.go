@@ -0,0 +1,162 @@
+package uspne
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+)
+
+// Numbers of sensitive data categories and of known child age brackets in the section.
+const (
+	sensitiveDataCount = 8
+	knownChildCount    = 1
+)
+
+type USPNE struct {
+	SectionID   constants.SectionID
+	Value       string
+	CoreSegment sections.CommonUSProcessingCoreSegment
+	GPCSegment  sections.CommonUSGPCSegment
+}
+
+func NewUSPNE(encoded string) (USPNE, error) {
+	uspne := USPNE{}
+
+	coreBitStream, gpcBitStream, err := sections.CreateBitStreams(encoded, true)
+	if err != nil {
+		return uspne, err
+	}
+
+	coreSegment, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, coreBitStream)
+	if err != nil {
+		return uspne, err
+	}
+
+	gpcSegment := sections.CommonUSGPCSegment{
+		SubsectionType: 1,
+		Gpc:            false,
+	}
+
+	if gpcBitStream != nil {
+		gpcSegment, err = sections.NewCommonUSGPCSegment(gpcBitStream)
+		if err != nil {
+			return uspne, err
+		}
+	}
+
+	uspne = USPNE{
+		SectionID:   constants.SectionUSPNE,
+		Value:       encoded,
+		CoreSegment: coreSegment,
+		GPCSegment:  gpcSegment,
+	}
+
+	return uspne, nil
+}
+
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (uspne USPNE) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(uspne.Value, func(bs *util.BitStream) error {
+		_, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, bs)
+		return err
+	})
+}
+
+func (uspne USPNE) Encode(gpcIncluded bool) []byte {
+	bs := util.NewBitStreamForWrite()
+	uspne.CoreSegment.Encode(bs)
+	res := bs.Base64Encode()
+	if !gpcIncluded {
+		return res
+	}
+	bs.Reset()
+	res = append(res, '.')
+	uspne.GPCSegment.Encode(bs)
+	return append(res, bs.Base64Encode()...)
+}
+
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (uspne USPNE) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(uspne.CoreSegment, sensitiveDataCount, knownChildCount); err != nil {
+		return nil, err
+	}
+	if gpcIncluded {
+		if err := uspne.GPCSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return uspne.Encode(gpcIncluded), nil
+}
+
+func (uspne USPNE) GetID() constants.SectionID {
+	return uspne.SectionID
+}
+
+func (uspne USPNE) GetValue() string {
+	return uspne.Value
+}
+
+// SaleOptOut implements sections.USPrivacySection.
+func (uspne USPNE) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(uspne.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (uspne USPNE) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspne.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection. The section has no sharing opt-out, so it is
+// always OptOutNotApplicable.
+func (uspne USPNE) SharingOptOut() sections.OptOutState {
+	return sections.OptOutNotApplicable
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (uspne USPNE) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspne.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (uspne USPNE) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspne.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (uspne USPNE) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(uspne.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (uspne USPNE) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(uspne.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (uspne USPNE) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspne.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (uspne USPNE) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspne.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection.
+func (uspne USPNE) GPC() bool {
+	return uspne.GPCSegment.Gpc
+}
+
+func (uspne USPNE) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(uspne)
+}
+
+func (uspne *USPNE) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, uspne)
+}
+
+// Validate implements sections.Validator.
+func (uspne USPNE) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(uspne.CoreSegment, sensitiveDataCount, knownChildCount)
+}
@@ -0,0 +1,162 @@
+package uspnh
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+)
+
+// Numbers of sensitive data categories and of known child age brackets in the section.
+const (
+	sensitiveDataCount = 8
+	knownChildCount    = 3
+)
+
+type USPNH struct {
+	SectionID   constants.SectionID
+	Value       string
+	CoreSegment sections.CommonUSProcessingCoreSegment
+	GPCSegment  sections.CommonUSGPCSegment
+}
+
+func NewUSPNH(encoded string) (USPNH, error) {
+	uspnh := USPNH{}
+
+	coreBitStream, gpcBitStream, err := sections.CreateBitStreams(encoded, true)
+	if err != nil {
+		return uspnh, err
+	}
+
+	coreSegment, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, coreBitStream)
+	if err != nil {
+		return uspnh, err
+	}
+
+	gpcSegment := sections.CommonUSGPCSegment{
+		SubsectionType: 1,
+		Gpc:            false,
+	}
+
+	if gpcBitStream != nil {
+		gpcSegment, err = sections.NewCommonUSGPCSegment(gpcBitStream)
+		if err != nil {
+			return uspnh, err
+		}
+	}
+
+	uspnh = USPNH{
+		SectionID:   constants.SectionUSPNH,
+		Value:       encoded,
+		CoreSegment: coreSegment,
+		GPCSegment:  gpcSegment,
+	}
+
+	return uspnh, nil
+}
+
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (uspnh USPNH) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(uspnh.Value, func(bs *util.BitStream) error {
+		_, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, bs)
+		return err
+	})
+}
+
+func (uspnh USPNH) Encode(gpcIncluded bool) []byte {
+	bs := util.NewBitStreamForWrite()
+	uspnh.CoreSegment.Encode(bs)
+	res := bs.Base64Encode()
+	if !gpcIncluded {
+		return res
+	}
+	bs.Reset()
+	res = append(res, '.')
+	uspnh.GPCSegment.Encode(bs)
+	return append(res, bs.Base64Encode()...)
+}
+
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (uspnh USPNH) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(uspnh.CoreSegment, sensitiveDataCount, knownChildCount); err != nil {
+		return nil, err
+	}
+	if gpcIncluded {
+		if err := uspnh.GPCSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return uspnh.Encode(gpcIncluded), nil
+}
+
+func (uspnh USPNH) GetID() constants.SectionID {
+	return uspnh.SectionID
+}
+
+func (uspnh USPNH) GetValue() string {
+	return uspnh.Value
+}
+
+// SaleOptOut implements sections.USPrivacySection.
+func (uspnh USPNH) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(uspnh.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (uspnh USPNH) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspnh.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection. The section has no sharing opt-out, so it is
+// always OptOutNotApplicable.
+func (uspnh USPNH) SharingOptOut() sections.OptOutState {
+	return sections.OptOutNotApplicable
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (uspnh USPNH) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspnh.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (uspnh USPNH) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspnh.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (uspnh USPNH) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(uspnh.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (uspnh USPNH) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(uspnh.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (uspnh USPNH) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspnh.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (uspnh USPNH) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspnh.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection.
+func (uspnh USPNH) GPC() bool {
+	return uspnh.GPCSegment.Gpc
+}
+
+func (uspnh USPNH) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(uspnh)
+}
+
+func (uspnh *USPNH) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, uspnh)
+}
+
+// Validate implements sections.Validator.
+func (uspnh USPNH) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(uspnh.CoreSegment, sensitiveDataCount, knownChildCount)
+}
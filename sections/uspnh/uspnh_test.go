@@ -0,0 +1,62 @@
+package uspnh
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/stretchr/testify/assert"
+)
+
+type uspnhTestData struct {
+	description string
+	gppString   string
+	expected    USPNH
+}
+
+func TestUSPNH(t *testing.T) {
+	testData := []uspnhTestData{
+		{
+			description: "should populate USPNH segments correctly",
+			gppString:   "BZYYYUlY.QA",
+			/*
+				000001 01 10 01 01 10 0001100001100001 010010 01 01 01 10 01 0
+			*/
+			expected: USPNH{
+				CoreSegment: sections.CommonUSProcessingCoreSegment{
+					Version:                         1,
+					ProcessingNotice:                1,
+					SaleOptOutNotice:                2,
+					TargetedAdvertisingOptOutNotice: 1,
+					SaleOptOut:                      1,
+					TargetedAdvertisingOptOut:       2,
+					SensitiveDataProcessing: []byte{
+						0, 1, 2, 0, 1, 2, 0, 1,
+					},
+					KnownChildSensitiveDataConsents: []byte{1, 0, 2},
+					AdditionalDataProcessingConsent: 1,
+					MspaCoveredTransaction:          1,
+					MspaOptOutOptionMode:            1,
+					MspaServiceProviderMode:         2,
+				},
+				GPCSegment: sections.CommonUSGPCSegment{
+					SubsectionType: 1,
+					Gpc:            false,
+				},
+				SectionID: constants.SectionUSPNH,
+				Value:     "BZYYYUlY.QA",
+			},
+		},
+	}
+
+	for _, test := range testData {
+		result, err := NewUSPNH(test.gppString)
+		encodedString := string(test.expected.Encode(true))
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, result)
+		assert.Equal(t, constants.SectionUSPNH, result.GetID())
+		assert.Equal(t, test.gppString, result.GetValue())
+		assert.Equal(t, test.gppString, encodedString)
+	}
+}
@@ -0,0 +1,104 @@
+package uspnj
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+)
+
+// USPNJBuilder builds a USPNJ section. Every field starts as not applicable, except
+// MspaCoveredTransaction, which starts as MspaNo, and GPC, which starts unset. Setting a field to a
+// value outside its range, or a category the section does not have, makes Build return an error.
+type USPNJBuilder struct {
+	section USPNJ
+	err     error
+}
+
+// Builder returns a USPNJBuilder for version 1 of the section.
+func Builder() *USPNJBuilder {
+	return &USPNJBuilder{section: USPNJ{
+		SectionID: constants.SectionUSPNJ,
+		CoreSegment: sections.CommonUSProcessingCoreSegment{
+			Version:                         1,
+			SensitiveDataProcessing:         make([]byte, sensitiveDataCount),
+			KnownChildSensitiveDataConsents: make([]byte, knownChildCount),
+			MspaCoveredTransaction:          byte(sections.MspaNo),
+		},
+		GPCSegment: sections.CommonUSGPCSegment{SubsectionType: 1},
+	}}
+}
+
+func (b *USPNJBuilder) ProcessingNotice(value sections.NoticeState) *USPNJBuilder {
+	sections.SetField(&b.err, "CoreSegment.ProcessingNotice", &b.section.CoreSegment.ProcessingNotice, byte(value))
+	return b
+}
+
+func (b *USPNJBuilder) SaleOptOutNotice(value sections.NoticeState) *USPNJBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOutNotice", &b.section.CoreSegment.SaleOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPNJBuilder) TargetedAdvertisingOptOutNotice(value sections.NoticeState) *USPNJBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOutNotice", &b.section.CoreSegment.TargetedAdvertisingOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPNJBuilder) SaleOptOut(value sections.OptOutState) *USPNJBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOut", &b.section.CoreSegment.SaleOptOut, byte(value))
+	return b
+}
+
+func (b *USPNJBuilder) TargetedAdvertisingOptOut(value sections.OptOutState) *USPNJBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOut", &b.section.CoreSegment.TargetedAdvertisingOptOut, byte(value))
+	return b
+}
+
+// SensitiveData sets the consent for a category of sensitive data, numbered from 1 to 10 in the order of
+// the section's specification.
+func (b *USPNJBuilder) SensitiveData(category int, state sections.ConsentState) *USPNJBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.SensitiveDataProcessing", b.section.CoreSegment.SensitiveDataProcessing, category, byte(state))
+	return b
+}
+
+// KnownChildSensitiveData sets the consent for processing the sensitive data of a known child, for an
+// age bracket numbered from 1 to 5.
+func (b *USPNJBuilder) KnownChildSensitiveData(index int, state sections.ConsentState) *USPNJBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.KnownChildSensitiveDataConsents", b.section.CoreSegment.KnownChildSensitiveDataConsents, index, byte(state))
+	return b
+}
+
+func (b *USPNJBuilder) AdditionalDataProcessingConsent(value sections.ConsentState) *USPNJBuilder {
+	sections.SetField(&b.err, "CoreSegment.AdditionalDataProcessingConsent", &b.section.CoreSegment.AdditionalDataProcessingConsent, byte(value))
+	return b
+}
+
+func (b *USPNJBuilder) MspaCoveredTransaction(value sections.MspaMode) *USPNJBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaCoveredTransaction", &b.section.CoreSegment.MspaCoveredTransaction, byte(value))
+	return b
+}
+
+func (b *USPNJBuilder) MspaOptOutOptionMode(value sections.MspaMode) *USPNJBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaOptOutOptionMode", &b.section.CoreSegment.MspaOptOutOptionMode, byte(value))
+	return b
+}
+
+func (b *USPNJBuilder) MspaServiceProviderMode(value sections.MspaMode) *USPNJBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaServiceProviderMode", &b.section.CoreSegment.MspaServiceProviderMode, byte(value))
+	return b
+}
+
+func (b *USPNJBuilder) GPC(gpc bool) *USPNJBuilder {
+	b.section.GPCSegment.Gpc = gpc
+	return b
+}
+
+// Build returns the section, with its Value set to its encoding, or the first error of the setters.
+func (b *USPNJBuilder) Build() (USPNJ, error) {
+	if b.err != nil {
+		return USPNJ{}, b.err
+	}
+	uspnj := b.section
+	uspnj.CoreSegment.SensitiveDataProcessing = append([]byte(nil), b.section.CoreSegment.SensitiveDataProcessing...)
+	uspnj.CoreSegment.KnownChildSensitiveDataConsents = append([]byte(nil), b.section.CoreSegment.KnownChildSensitiveDataConsents...)
+	uspnj.Value = string(uspnj.Encode(true))
+	return uspnj, nil
+}
@@ -0,0 +1,162 @@
+package uspnj
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+)
+
+// Numbers of sensitive data categories and of known child age brackets in the section.
+const (
+	sensitiveDataCount = 10
+	knownChildCount    = 5
+)
+
+type USPNJ struct {
+	SectionID   constants.SectionID
+	Value       string
+	CoreSegment sections.CommonUSProcessingCoreSegment
+	GPCSegment  sections.CommonUSGPCSegment
+}
+
+func NewUSPNJ(encoded string) (USPNJ, error) {
+	uspnj := USPNJ{}
+
+	coreBitStream, gpcBitStream, err := sections.CreateBitStreams(encoded, true)
+	if err != nil {
+		return uspnj, err
+	}
+
+	coreSegment, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, coreBitStream)
+	if err != nil {
+		return uspnj, err
+	}
+
+	gpcSegment := sections.CommonUSGPCSegment{
+		SubsectionType: 1,
+		Gpc:            false,
+	}
+
+	if gpcBitStream != nil {
+		gpcSegment, err = sections.NewCommonUSGPCSegment(gpcBitStream)
+		if err != nil {
+			return uspnj, err
+		}
+	}
+
+	uspnj = USPNJ{
+		SectionID:   constants.SectionUSPNJ,
+		Value:       encoded,
+		CoreSegment: coreSegment,
+		GPCSegment:  gpcSegment,
+	}
+
+	return uspnj, nil
+}
+
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (uspnj USPNJ) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(uspnj.Value, func(bs *util.BitStream) error {
+		_, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, bs)
+		return err
+	})
+}
+
+func (uspnj USPNJ) Encode(gpcIncluded bool) []byte {
+	bs := util.NewBitStreamForWrite()
+	uspnj.CoreSegment.Encode(bs)
+	res := bs.Base64Encode()
+	if !gpcIncluded {
+		return res
+	}
+	bs.Reset()
+	res = append(res, '.')
+	uspnj.GPCSegment.Encode(bs)
+	return append(res, bs.Base64Encode()...)
+}
+
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (uspnj USPNJ) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(uspnj.CoreSegment, sensitiveDataCount, knownChildCount); err != nil {
+		return nil, err
+	}
+	if gpcIncluded {
+		if err := uspnj.GPCSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return uspnj.Encode(gpcIncluded), nil
+}
+
+func (uspnj USPNJ) GetID() constants.SectionID {
+	return uspnj.SectionID
+}
+
+func (uspnj USPNJ) GetValue() string {
+	return uspnj.Value
+}
+
+// SaleOptOut implements sections.USPrivacySection.
+func (uspnj USPNJ) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(uspnj.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (uspnj USPNJ) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspnj.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection. The section has no sharing opt-out, so it is
+// always OptOutNotApplicable.
+func (uspnj USPNJ) SharingOptOut() sections.OptOutState {
+	return sections.OptOutNotApplicable
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (uspnj USPNJ) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspnj.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (uspnj USPNJ) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspnj.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (uspnj USPNJ) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(uspnj.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (uspnj USPNJ) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(uspnj.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (uspnj USPNJ) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspnj.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (uspnj USPNJ) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspnj.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection.
+func (uspnj USPNJ) GPC() bool {
+	return uspnj.GPCSegment.Gpc
+}
+
+func (uspnj USPNJ) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(uspnj)
+}
+
+func (uspnj *USPNJ) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, uspnj)
+}
+
+// Validate implements sections.Validator.
+func (uspnj USPNJ) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(uspnj.CoreSegment, sensitiveDataCount, knownChildCount)
+}
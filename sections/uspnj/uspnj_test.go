@@ -0,0 +1,62 @@
+package uspnj
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/stretchr/testify/assert"
+)
+
+type uspnjTestData struct {
+	description string
+	gppString   string
+	expected    USPNJ
+}
+
+func TestUSPNJ(t *testing.T) {
+	testData := []uspnjTestData{
+		{
+			description: "should populate USPNJ segments correctly",
+			gppString:   "BmlhhhkmZA.YA",
+			/*
+				000001 10 01 10 10 01 01100001100001100001 1001001001 10 01 10 01 01 1
+			*/
+			expected: USPNJ{
+				CoreSegment: sections.CommonUSProcessingCoreSegment{
+					Version:                         1,
+					ProcessingNotice:                2,
+					SaleOptOutNotice:                1,
+					TargetedAdvertisingOptOutNotice: 2,
+					SaleOptOut:                      2,
+					TargetedAdvertisingOptOut:       1,
+					SensitiveDataProcessing: []byte{
+						1, 2, 0, 1, 2, 0, 1, 2, 0, 1,
+					},
+					KnownChildSensitiveDataConsents: []byte{2, 1, 0, 2, 1},
+					AdditionalDataProcessingConsent: 2,
+					MspaCoveredTransaction:          1,
+					MspaOptOutOptionMode:            2,
+					MspaServiceProviderMode:         1,
+				},
+				GPCSegment: sections.CommonUSGPCSegment{
+					SubsectionType: 1,
+					Gpc:            true,
+				},
+				SectionID: constants.SectionUSPNJ,
+				Value:     "BmlhhhkmZA.YA",
+			},
+		},
+	}
+
+	for _, test := range testData {
+		result, err := NewUSPNJ(test.gppString)
+		encodedString := string(test.expected.Encode(true))
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, result)
+		assert.Equal(t, constants.SectionUSPNJ, result.GetID())
+		assert.Equal(t, test.gppString, result.GetValue())
+		assert.Equal(t, test.gppString, encodedString)
+	}
+}
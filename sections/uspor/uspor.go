@@ -0,0 +1,162 @@
+package uspor
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+)
+
+// Numbers of sensitive data categories and of known child age brackets in the section.
+const (
+	sensitiveDataCount = 11
+	knownChildCount    = 3
+)
+
+type USPOR struct {
+	SectionID   constants.SectionID
+	Value       string
+	CoreSegment sections.CommonUSProcessingCoreSegment
+	GPCSegment  sections.CommonUSGPCSegment
+}
+
+func NewUSPOR(encoded string) (USPOR, error) {
+	uspor := USPOR{}
+
+	coreBitStream, gpcBitStream, err := sections.CreateBitStreams(encoded, true)
+	if err != nil {
+		return uspor, err
+	}
+
+	coreSegment, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, coreBitStream)
+	if err != nil {
+		return uspor, err
+	}
+
+	gpcSegment := sections.CommonUSGPCSegment{
+		SubsectionType: 1,
+		Gpc:            false,
+	}
+
+	if gpcBitStream != nil {
+		gpcSegment, err = sections.NewCommonUSGPCSegment(gpcBitStream)
+		if err != nil {
+			return uspor, err
+		}
+	}
+
+	uspor = USPOR{
+		SectionID:   constants.SectionUSPOR,
+		Value:       encoded,
+		CoreSegment: coreSegment,
+		GPCSegment:  gpcSegment,
+	}
+
+	return uspor, nil
+}
+
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (uspor USPOR) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(uspor.Value, func(bs *util.BitStream) error {
+		_, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, bs)
+		return err
+	})
+}
+
+func (uspor USPOR) Encode(gpcIncluded bool) []byte {
+	bs := util.NewBitStreamForWrite()
+	uspor.CoreSegment.Encode(bs)
+	res := bs.Base64Encode()
+	if !gpcIncluded {
+		return res
+	}
+	bs.Reset()
+	res = append(res, '.')
+	uspor.GPCSegment.Encode(bs)
+	return append(res, bs.Base64Encode()...)
+}
+
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (uspor USPOR) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(uspor.CoreSegment, sensitiveDataCount, knownChildCount); err != nil {
+		return nil, err
+	}
+	if gpcIncluded {
+		if err := uspor.GPCSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return uspor.Encode(gpcIncluded), nil
+}
+
+func (uspor USPOR) GetID() constants.SectionID {
+	return uspor.SectionID
+}
+
+func (uspor USPOR) GetValue() string {
+	return uspor.Value
+}
+
+// SaleOptOut implements sections.USPrivacySection.
+func (uspor USPOR) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(uspor.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (uspor USPOR) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspor.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection. The section has no sharing opt-out, so it is
+// always OptOutNotApplicable.
+func (uspor USPOR) SharingOptOut() sections.OptOutState {
+	return sections.OptOutNotApplicable
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (uspor USPOR) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspor.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (uspor USPOR) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspor.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (uspor USPOR) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(uspor.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (uspor USPOR) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(uspor.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (uspor USPOR) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspor.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (uspor USPOR) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspor.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection.
+func (uspor USPOR) GPC() bool {
+	return uspor.GPCSegment.Gpc
+}
+
+func (uspor USPOR) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(uspor)
+}
+
+func (uspor *USPOR) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, uspor)
+}
+
+// Validate implements sections.Validator.
+func (uspor USPOR) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(uspor.CoreSegment, sensitiveDataCount, knownChildCount)
+}
@@ -0,0 +1,62 @@
+package uspor
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/stretchr/testify/assert"
+)
+
+type usporTestData struct {
+	description string
+	gppString   string
+	expected    USPOR
+}
+
+func TestUSPOR(t *testing.T) {
+	testData := []usporTestData{
+		{
+			description: "should populate USPOR segments correctly",
+			gppString:   "BZaGGGCVYA.YA",
+			/*
+				000001 01 10 01 01 10 1000011000011000011000 001001 01 01 01 10 01 1
+			*/
+			expected: USPOR{
+				CoreSegment: sections.CommonUSProcessingCoreSegment{
+					Version:                         1,
+					ProcessingNotice:                1,
+					SaleOptOutNotice:                2,
+					TargetedAdvertisingOptOutNotice: 1,
+					SaleOptOut:                      1,
+					TargetedAdvertisingOptOut:       2,
+					SensitiveDataProcessing: []byte{
+						2, 0, 1, 2, 0, 1, 2, 0, 1, 2, 0,
+					},
+					KnownChildSensitiveDataConsents: []byte{0, 2, 1},
+					AdditionalDataProcessingConsent: 1,
+					MspaCoveredTransaction:          1,
+					MspaOptOutOptionMode:            1,
+					MspaServiceProviderMode:         2,
+				},
+				GPCSegment: sections.CommonUSGPCSegment{
+					SubsectionType: 1,
+					Gpc:            true,
+				},
+				SectionID: constants.SectionUSPOR,
+				Value:     "BZaGGGCVYA.YA",
+			},
+		},
+	}
+
+	for _, test := range testData {
+		result, err := NewUSPOR(test.gppString)
+		encodedString := string(test.expected.Encode(true))
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, result)
+		assert.Equal(t, constants.SectionUSPOR, result.GetID())
+		assert.Equal(t, test.gppString, result.GetValue())
+		assert.Equal(t, test.gppString, encodedString)
+	}
+}
@@ -0,0 +1,162 @@
+package uspri
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+)
+
+// Numbers of sensitive data categories and of known child age brackets in the section.
+const (
+	sensitiveDataCount = 8
+	knownChildCount    = 1
+)
+
+type USPRI struct {
+	SectionID   constants.SectionID
+	Value       string
+	CoreSegment sections.CommonUSProcessingCoreSegment
+	GPCSegment  sections.CommonUSGPCSegment
+}
+
+func NewUSPRI(encoded string) (USPRI, error) {
+	uspri := USPRI{}
+
+	coreBitStream, gpcBitStream, err := sections.CreateBitStreams(encoded, true)
+	if err != nil {
+		return uspri, err
+	}
+
+	coreSegment, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, coreBitStream)
+	if err != nil {
+		return uspri, err
+	}
+
+	gpcSegment := sections.CommonUSGPCSegment{
+		SubsectionType: 1,
+		Gpc:            false,
+	}
+
+	if gpcBitStream != nil {
+		gpcSegment, err = sections.NewCommonUSGPCSegment(gpcBitStream)
+		if err != nil {
+			return uspri, err
+		}
+	}
+
+	uspri = USPRI{
+		SectionID:   constants.SectionUSPRI,
+		Value:       encoded,
+		CoreSegment: coreSegment,
+		GPCSegment:  gpcSegment,
+	}
+
+	return uspri, nil
+}
+
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (uspri USPRI) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(uspri.Value, func(bs *util.BitStream) error {
+		_, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, bs)
+		return err
+	})
+}
+
+func (uspri USPRI) Encode(gpcIncluded bool) []byte {
+	bs := util.NewBitStreamForWrite()
+	uspri.CoreSegment.Encode(bs)
+	res := bs.Base64Encode()
+	if !gpcIncluded {
+		return res
+	}
+	bs.Reset()
+	res = append(res, '.')
+	uspri.GPCSegment.Encode(bs)
+	return append(res, bs.Base64Encode()...)
+}
+
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (uspri USPRI) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(uspri.CoreSegment, sensitiveDataCount, knownChildCount); err != nil {
+		return nil, err
+	}
+	if gpcIncluded {
+		if err := uspri.GPCSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return uspri.Encode(gpcIncluded), nil
+}
+
+func (uspri USPRI) GetID() constants.SectionID {
+	return uspri.SectionID
+}
+
+func (uspri USPRI) GetValue() string {
+	return uspri.Value
+}
+
+// SaleOptOut implements sections.USPrivacySection.
+func (uspri USPRI) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(uspri.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (uspri USPRI) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspri.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection. The section has no sharing opt-out, so it is
+// always OptOutNotApplicable.
+func (uspri USPRI) SharingOptOut() sections.OptOutState {
+	return sections.OptOutNotApplicable
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (uspri USPRI) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspri.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (uspri USPRI) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspri.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (uspri USPRI) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(uspri.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (uspri USPRI) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(uspri.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (uspri USPRI) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspri.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (uspri USPRI) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspri.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection.
+func (uspri USPRI) GPC() bool {
+	return uspri.GPCSegment.Gpc
+}
+
+func (uspri USPRI) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(uspri)
+}
+
+func (uspri *USPRI) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, uspri)
+}
+
+// Validate implements sections.Validator.
+func (uspri USPRI) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(uspri.CoreSegment, sensitiveDataCount, knownChildCount)
+}
@@ -0,0 +1,62 @@
+package uspri
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/stretchr/testify/assert"
+)
+
+type uspriTestData struct {
+	description string
+	gppString   string
+	expected    USPRI
+}
+
+func TestUSPRI(t *testing.T) {
+	testData := []uspriTestData{
+		{
+			description: "should populate USPRI segments correctly",
+			gppString:   "BmlhhqZA.YA",
+			/*
+				000001 10 01 10 10 01 0110000110000110 10 10 01 10 01 01 1
+			*/
+			expected: USPRI{
+				CoreSegment: sections.CommonUSProcessingCoreSegment{
+					Version:                         1,
+					ProcessingNotice:                2,
+					SaleOptOutNotice:                1,
+					TargetedAdvertisingOptOutNotice: 2,
+					SaleOptOut:                      2,
+					TargetedAdvertisingOptOut:       1,
+					SensitiveDataProcessing: []byte{
+						1, 2, 0, 1, 2, 0, 1, 2,
+					},
+					KnownChildSensitiveDataConsents: []byte{2},
+					AdditionalDataProcessingConsent: 2,
+					MspaCoveredTransaction:          1,
+					MspaOptOutOptionMode:            2,
+					MspaServiceProviderMode:         1,
+				},
+				GPCSegment: sections.CommonUSGPCSegment{
+					SubsectionType: 1,
+					Gpc:            true,
+				},
+				SectionID: constants.SectionUSPRI,
+				Value:     "BmlhhqZA.YA",
+			},
+		},
+	}
+
+	for _, test := range testData {
+		result, err := NewUSPRI(test.gppString)
+		encodedString := string(test.expected.Encode(true))
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, result)
+		assert.Equal(t, constants.SectionUSPRI, result.GetID())
+		assert.Equal(t, test.gppString, result.GetValue())
+		assert.Equal(t, test.gppString, encodedString)
+	}
+}
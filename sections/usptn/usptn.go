@@ -0,0 +1,162 @@
+package usptn
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+)
+
+// Numbers of sensitive data categories and of known child age brackets in the section.
+const (
+	sensitiveDataCount = 8
+	knownChildCount    = 1
+)
+
+type USPTN struct {
+	SectionID   constants.SectionID
+	Value       string
+	CoreSegment sections.CommonUSProcessingCoreSegment
+	GPCSegment  sections.CommonUSGPCSegment
+}
+
+func NewUSPTN(encoded string) (USPTN, error) {
+	usptn := USPTN{}
+
+	coreBitStream, gpcBitStream, err := sections.CreateBitStreams(encoded, true)
+	if err != nil {
+		return usptn, err
+	}
+
+	coreSegment, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, coreBitStream)
+	if err != nil {
+		return usptn, err
+	}
+
+	gpcSegment := sections.CommonUSGPCSegment{
+		SubsectionType: 1,
+		Gpc:            false,
+	}
+
+	if gpcBitStream != nil {
+		gpcSegment, err = sections.NewCommonUSGPCSegment(gpcBitStream)
+		if err != nil {
+			return usptn, err
+		}
+	}
+
+	usptn = USPTN{
+		SectionID:   constants.SectionUSPTN,
+		Value:       encoded,
+		CoreSegment: coreSegment,
+		GPCSegment:  gpcSegment,
+	}
+
+	return usptn, nil
+}
+
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (usptn USPTN) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(usptn.Value, func(bs *util.BitStream) error {
+		_, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, bs)
+		return err
+	})
+}
+
+func (usptn USPTN) Encode(gpcIncluded bool) []byte {
+	bs := util.NewBitStreamForWrite()
+	usptn.CoreSegment.Encode(bs)
+	res := bs.Base64Encode()
+	if !gpcIncluded {
+		return res
+	}
+	bs.Reset()
+	res = append(res, '.')
+	usptn.GPCSegment.Encode(bs)
+	return append(res, bs.Base64Encode()...)
+}
+
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (usptn USPTN) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(usptn.CoreSegment, sensitiveDataCount, knownChildCount); err != nil {
+		return nil, err
+	}
+	if gpcIncluded {
+		if err := usptn.GPCSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return usptn.Encode(gpcIncluded), nil
+}
+
+func (usptn USPTN) GetID() constants.SectionID {
+	return usptn.SectionID
+}
+
+func (usptn USPTN) GetValue() string {
+	return usptn.Value
+}
+
+// SaleOptOut implements sections.USPrivacySection.
+func (usptn USPTN) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(usptn.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (usptn USPTN) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(usptn.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection. The section has no sharing opt-out, so it is
+// always OptOutNotApplicable.
+func (usptn USPTN) SharingOptOut() sections.OptOutState {
+	return sections.OptOutNotApplicable
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (usptn USPTN) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(usptn.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (usptn USPTN) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(usptn.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (usptn USPTN) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(usptn.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (usptn USPTN) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(usptn.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (usptn USPTN) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(usptn.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (usptn USPTN) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(usptn.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection.
+func (usptn USPTN) GPC() bool {
+	return usptn.GPCSegment.Gpc
+}
+
+func (usptn USPTN) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(usptn)
+}
+
+func (usptn *USPTN) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, usptn)
+}
+
+// Validate implements sections.Validator.
+func (usptn USPTN) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(usptn.CoreSegment, sensitiveDataCount, knownChildCount)
+}
@@ -0,0 +1,62 @@
+package usptn
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/stretchr/testify/assert"
+)
+
+type usptnTestData struct {
+	description string
+	gppString   string
+	expected    USPTN
+}
+
+func TestUSPTN(t *testing.T) {
+	testData := []usptnTestData{
+		{
+			description: "should populate USPTN segments correctly",
+			gppString:   "BZaGGBWA.YA",
+			/*
+				000001 01 10 01 01 10 1000011000011000 00 01 01 01 10 01 1
+			*/
+			expected: USPTN{
+				CoreSegment: sections.CommonUSProcessingCoreSegment{
+					Version:                         1,
+					ProcessingNotice:                1,
+					SaleOptOutNotice:                2,
+					TargetedAdvertisingOptOutNotice: 1,
+					SaleOptOut:                      1,
+					TargetedAdvertisingOptOut:       2,
+					SensitiveDataProcessing: []byte{
+						2, 0, 1, 2, 0, 1, 2, 0,
+					},
+					KnownChildSensitiveDataConsents: []byte{0},
+					AdditionalDataProcessingConsent: 1,
+					MspaCoveredTransaction:          1,
+					MspaOptOutOptionMode:            1,
+					MspaServiceProviderMode:         2,
+				},
+				GPCSegment: sections.CommonUSGPCSegment{
+					SubsectionType: 1,
+					Gpc:            true,
+				},
+				SectionID: constants.SectionUSPTN,
+				Value:     "BZaGGBWA.YA",
+			},
+		},
+	}
+
+	for _, test := range testData {
+		result, err := NewUSPTN(test.gppString)
+		encodedString := string(test.expected.Encode(true))
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, result)
+		assert.Equal(t, constants.SectionUSPTN, result.GetID())
+		assert.Equal(t, test.gppString, result.GetValue())
+		assert.Equal(t, test.gppString, encodedString)
+	}
+}
@@ -0,0 +1,162 @@
+package usptx
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/util"
+)
+
+// Numbers of sensitive data categories and of known child age brackets in the section.
+const (
+	sensitiveDataCount = 8
+	knownChildCount    = 1
+)
+
+type USPTX struct {
+	SectionID   constants.SectionID
+	Value       string
+	CoreSegment sections.CommonUSProcessingCoreSegment
+	GPCSegment  sections.CommonUSGPCSegment
+}
+
+func NewUSPTX(encoded string) (USPTX, error) {
+	usptx := USPTX{}
+
+	coreBitStream, gpcBitStream, err := sections.CreateBitStreams(encoded, true)
+	if err != nil {
+		return usptx, err
+	}
+
+	coreSegment, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, coreBitStream)
+	if err != nil {
+		return usptx, err
+	}
+
+	gpcSegment := sections.CommonUSGPCSegment{
+		SubsectionType: 1,
+		Gpc:            false,
+	}
+
+	if gpcBitStream != nil {
+		gpcSegment, err = sections.NewCommonUSGPCSegment(gpcBitStream)
+		if err != nil {
+			return usptx, err
+		}
+	}
+
+	usptx = USPTX{
+		SectionID:   constants.SectionUSPTX,
+		Value:       encoded,
+		CoreSegment: coreSegment,
+		GPCSegment:  gpcSegment,
+	}
+
+	return usptx, nil
+}
+
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (usptx USPTX) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(usptx.Value, func(bs *util.BitStream) error {
+		_, err := sections.NewCommonUSProcessingCoreSegment(sensitiveDataCount, knownChildCount, bs)
+		return err
+	})
+}
+
+func (usptx USPTX) Encode(gpcIncluded bool) []byte {
+	bs := util.NewBitStreamForWrite()
+	usptx.CoreSegment.Encode(bs)
+	res := bs.Base64Encode()
+	if !gpcIncluded {
+		return res
+	}
+	bs.Reset()
+	res = append(res, '.')
+	usptx.GPCSegment.Encode(bs)
+	return append(res, bs.Base64Encode()...)
+}
+
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (usptx USPTX) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(usptx.CoreSegment, sensitiveDataCount, knownChildCount); err != nil {
+		return nil, err
+	}
+	if gpcIncluded {
+		if err := usptx.GPCSegment.Check(); err != nil {
+			return nil, err
+		}
+	}
+	return usptx.Encode(gpcIncluded), nil
+}
+
+func (usptx USPTX) GetID() constants.SectionID {
+	return usptx.SectionID
+}
+
+func (usptx USPTX) GetValue() string {
+	return usptx.Value
+}
+
+// SaleOptOut implements sections.USPrivacySection.
+func (usptx USPTX) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(usptx.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (usptx USPTX) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(usptx.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection. The section has no sharing opt-out, so it is
+// always OptOutNotApplicable.
+func (usptx USPTX) SharingOptOut() sections.OptOutState {
+	return sections.OptOutNotApplicable
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (usptx USPTX) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(usptx.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (usptx USPTX) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(usptx.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (usptx USPTX) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(usptx.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (usptx USPTX) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(usptx.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (usptx USPTX) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(usptx.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (usptx USPTX) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(usptx.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection.
+func (usptx USPTX) GPC() bool {
+	return usptx.GPCSegment.Gpc
+}
+
+func (usptx USPTX) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(usptx)
+}
+
+func (usptx *USPTX) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, usptx)
+}
+
+// Validate implements sections.Validator.
+func (usptx USPTX) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(usptx.CoreSegment, sensitiveDataCount, knownChildCount)
+}
@@ -0,0 +1,62 @@
+package usptx
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+	"github.com/stretchr/testify/assert"
+)
+
+type usptxTestData struct {
+	description string
+	gppString   string
+	expected    USPTX
+}
+
+func TestUSPTX(t *testing.T) {
+	testData := []usptxTestData{
+		{
+			description: "should populate USPTX segments correctly",
+			gppString:   "BmkYYWZA.QA",
+			/*
+				000001 10 01 10 10 01 0001100001100001 01 10 01 10 01 01 0
+			*/
+			expected: USPTX{
+				CoreSegment: sections.CommonUSProcessingCoreSegment{
+					Version:                         1,
+					ProcessingNotice:                2,
+					SaleOptOutNotice:                1,
+					TargetedAdvertisingOptOutNotice: 2,
+					SaleOptOut:                      2,
+					TargetedAdvertisingOptOut:       1,
+					SensitiveDataProcessing: []byte{
+						0, 1, 2, 0, 1, 2, 0, 1,
+					},
+					KnownChildSensitiveDataConsents: []byte{1},
+					AdditionalDataProcessingConsent: 2,
+					MspaCoveredTransaction:          1,
+					MspaOptOutOptionMode:            2,
+					MspaServiceProviderMode:         1,
+				},
+				GPCSegment: sections.CommonUSGPCSegment{
+					SubsectionType: 1,
+					Gpc:            false,
+				},
+				SectionID: constants.SectionUSPTX,
+				Value:     "BmkYYWZA.QA",
+			},
+		},
+	}
+
+	for _, test := range testData {
+		result, err := NewUSPTX(test.gppString)
+		encodedString := string(test.expected.Encode(true))
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, result)
+		assert.Equal(t, constants.SectionUSPTX, result.GetID())
+		assert.Equal(t, test.gppString, result.GetValue())
+		assert.Equal(t, test.gppString, encodedString)
+	}
+}
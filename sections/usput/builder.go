@@ -0,0 +1,98 @@
+package usput
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+)
+
+// USPUTBuilder builds a USPUT section. Every field starts as not applicable, except
+// MspaCoveredTransaction, which starts as MspaNo. Setting a field to a value outside its range, or a
+// category the section does not have, makes Build return an error.
+type USPUTBuilder struct {
+	section USPUT
+	err     error
+}
+
+// Builder returns a USPUTBuilder for version 1 of the section.
+func Builder() *USPUTBuilder {
+	return &USPUTBuilder{section: USPUT{
+		SectionID: constants.SectionUSPUT,
+		CoreSegment: USPUTCoreSegment{
+			Version:                 1,
+			SensitiveDataProcessing: make([]byte, 8),
+			MspaCoveredTransaction:  byte(sections.MspaNo),
+		},
+	}}
+}
+
+func (b *USPUTBuilder) SharingNotice(value sections.NoticeState) *USPUTBuilder {
+	sections.SetField(&b.err, "CoreSegment.SharingNotice", &b.section.CoreSegment.SharingNotice, byte(value))
+	return b
+}
+
+func (b *USPUTBuilder) SaleOptOutNotice(value sections.NoticeState) *USPUTBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOutNotice", &b.section.CoreSegment.SaleOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPUTBuilder) TargetedAdvertisingOptOutNotice(value sections.NoticeState) *USPUTBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOutNotice", &b.section.CoreSegment.TargetedAdvertisingOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPUTBuilder) SensitiveDataProcessingOptOutNotice(value sections.NoticeState) *USPUTBuilder {
+	sections.SetField(&b.err, "CoreSegment.SensitiveDataProcessingOptOutNotice", &b.section.CoreSegment.SensitiveDataProcessingOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPUTBuilder) SaleOptOut(value sections.OptOutState) *USPUTBuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOut", &b.section.CoreSegment.SaleOptOut, byte(value))
+	return b
+}
+
+func (b *USPUTBuilder) TargetedAdvertisingOptOut(value sections.OptOutState) *USPUTBuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOut", &b.section.CoreSegment.TargetedAdvertisingOptOut, byte(value))
+	return b
+}
+
+// SensitiveData sets the consent for a category of sensitive data, numbered from 1 to 8 in the order of
+// the section's specification.
+func (b *USPUTBuilder) SensitiveData(category int, state sections.ConsentState) *USPUTBuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.SensitiveDataProcessing", b.section.CoreSegment.SensitiveDataProcessing, category, byte(state))
+	return b
+}
+
+// KnownChildSensitiveData sets the consent for processing the sensitive data of a known child. The
+// section has a single age bracket, so index must be 1.
+func (b *USPUTBuilder) KnownChildSensitiveData(index int, state sections.ConsentState) *USPUTBuilder {
+	consents := []byte{b.section.CoreSegment.KnownChildSensitiveDataConsents}
+	sections.SetFieldElem(&b.err, "CoreSegment.KnownChildSensitiveDataConsents", consents, index, byte(state))
+	b.section.CoreSegment.KnownChildSensitiveDataConsents = consents[0]
+	return b
+}
+
+func (b *USPUTBuilder) MspaCoveredTransaction(value sections.MspaMode) *USPUTBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaCoveredTransaction", &b.section.CoreSegment.MspaCoveredTransaction, byte(value))
+	return b
+}
+
+func (b *USPUTBuilder) MspaOptOutOptionMode(value sections.MspaMode) *USPUTBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaOptOutOptionMode", &b.section.CoreSegment.MspaOptOutOptionMode, byte(value))
+	return b
+}
+
+func (b *USPUTBuilder) MspaServiceProviderMode(value sections.MspaMode) *USPUTBuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaServiceProviderMode", &b.section.CoreSegment.MspaServiceProviderMode, byte(value))
+	return b
+}
+
+// Build returns the section, with its Value set to its encoding, or the first error of the setters.
+func (b *USPUTBuilder) Build() (USPUT, error) {
+	if b.err != nil {
+		return USPUT{}, b.err
+	}
+	usput := b.section
+	usput.CoreSegment.SensitiveDataProcessing = append([]byte(nil), b.section.CoreSegment.SensitiveDataProcessing...)
+	usput.Value = string(usput.Encode(true))
+	return usput, nil
+}
@@ -0,0 +1,29 @@
+package usput
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/sections"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUSPUTBuilder(t *testing.T) {
+	usput, err := Builder().
+		SaleOptOut(sections.OptedOut).
+		SensitiveData(8, sections.NoConsent).
+		KnownChildSensitiveData(1, sections.NoConsent).
+		Build()
+
+	assert.Nil(t, err)
+	assert.Equal(t, byte(1), usput.CoreSegment.KnownChildSensitiveDataConsents)
+
+	parsed, err := NewUSPUT(usput.GetValue())
+	assert.Nil(t, err)
+	assert.Equal(t, usput, parsed)
+}
+
+func TestUSPUTBuilderKnownChildIndex(t *testing.T) {
+	_, err := Builder().KnownChildSensitiveData(2, sections.Consent).Build()
+
+	assert.EqualError(t, err, "invalid index 2 for field CoreSegment.KnownChildSensitiveDataConsents, should be 1 to 1: out of range")
+}
@@ -131,12 +131,29 @@ func NewUSPUT(encoded string) (USPUT, error) {
 	return usput, nil
 }
 
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (usput USPUT) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(usput.Value, func(bs *util.BitStream) error {
+		_, err := NewUPSUTCoreSegment(bs)
+		return err
+	})
+}
+
 func (usput USPUT) Encode(bool) []byte {
 	bs := util.NewBitStreamForWrite()
 	usput.CoreSegment.Encode(bs)
 	return bs.Base64Encode()
 }
 
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (usput USPUT) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(usput.CoreSegment, 8, 1); err != nil {
+		return nil, err
+	}
+	return usput.Encode(gpcIncluded), nil
+}
+
 func (usput USPUT) GetID() constants.SectionID {
 	return usput.SectionID
 }
@@ -144,3 +161,75 @@ func (usput USPUT) GetID() constants.SectionID {
 func (usput USPUT) GetValue() string {
 	return usput.Value
 }
+
+// SaleOptOut implements sections.USPrivacySection.
+func (usput USPUT) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(usput.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (usput USPUT) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(usput.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection. The section has no sharing opt-out, so it is
+// always OptOutNotApplicable.
+func (usput USPUT) SharingOptOut() sections.OptOutState {
+	return sections.OptOutNotApplicable
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (usput USPUT) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(usput.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (usput USPUT) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(usput.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (usput USPUT) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(usput.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (usput USPUT) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(usput.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (usput USPUT) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(usput.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (usput USPUT) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates([]byte{usput.CoreSegment.KnownChildSensitiveDataConsents})
+}
+
+// GPC implements sections.USPrivacySection. The section has no GPC segment, so it is always false.
+func (usput USPUT) GPC() bool {
+	return false
+}
+
+func (segment USPUTCoreSegment) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(segment)
+}
+
+func (segment *USPUTCoreSegment) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, segment)
+}
+
+func (usput USPUT) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(usput)
+}
+
+func (usput *USPUT) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, usput)
+}
+
+// Validate implements sections.Validator.
+func (usput USPUT) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(usput.CoreSegment, 8, 1)
+}
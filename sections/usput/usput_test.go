@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -55,3 +56,16 @@ func TestUSPUT(t *testing.T) {
 		assert.Equal(t, test.gppString, encodedString)
 	}
 }
+
+func TestUSPUTPrivacySignals(t *testing.T) {
+	usput, err := NewUSPUT("bSRYJllA")
+	assert.Nil(t, err)
+
+	var signals sections.USPrivacySection = usput
+	assert.Equal(t, sections.OptOutNotApplicable, signals.SaleOptOut())
+	assert.Equal(t, sections.OptedOut, signals.TargetedAdvertisingOptOut())
+	assert.Equal(t, sections.NoConsent, signals.SensitiveDataConsent(1))
+	assert.Equal(t, sections.Consent, signals.SensitiveDataConsent(2))
+	assert.Equal(t, []sections.ConsentState{sections.NoConsent}, signals.KnownChildSensitiveDataConsents())
+	assert.False(t, signals.GPC())
+}
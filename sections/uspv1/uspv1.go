@@ -0,0 +1,158 @@
+package uspv1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+)
+
+// Values of the notice, opt-out and LSPA fields in a US Privacy string.
+const (
+	Yes           byte = 'Y'
+	No            byte = 'N'
+	NotApplicable byte = '-'
+)
+
+// Length is the number of characters of a version 1 US Privacy string.
+const Length = 4
+
+// USPV1 is the legacy IAB CCPA US Privacy string. Unlike the other sections it is not base64 encoded,
+// but stored as 4 plain text characters, e.g. "1YNN".
+type USPV1 struct {
+	SectionID              constants.SectionID
+	Value                  string
+	Version                byte
+	Notice                 byte
+	OptOutSale             byte
+	LSPACoveredTransaction byte
+}
+
+func NewUSPV1(encoded string) (USPV1, error) {
+	uspv1 := USPV1{}
+
+	if len(encoded) != Length {
+		return uspv1, fmt.Errorf("invalid US Privacy string length %d, should be %d characters long", len(encoded), Length)
+	}
+
+	if encoded[0] != '1' {
+		return uspv1, fmt.Errorf("invalid US Privacy string version %q", encoded[0])
+	}
+
+	for i, name := range []string{"Notice", "OptOutSale", "LSPACoveredTransaction"} {
+		if err := validateFlag(encoded[i+1]); err != nil {
+			return uspv1, sections.ErrorHelper(name, err)
+		}
+	}
+
+	uspv1 = USPV1{
+		SectionID:              constants.SectionUSPV1,
+		Value:                  encoded,
+		Version:                encoded[0] - '0',
+		Notice:                 encoded[1],
+		OptOutSale:             encoded[2],
+		LSPACoveredTransaction: encoded[3],
+	}
+
+	return uspv1, nil
+}
+
+func validateFlag(b byte) error {
+	switch b {
+	case Yes, No, NotApplicable:
+		return nil
+	}
+	return fmt.Errorf("invalid character %q, should be one of 'Y', 'N' or '-'", b)
+}
+
+// Encode returns the plain text form of the US Privacy string. The GPC flag does not apply
+// and is ignored.
+func (uspv1 USPV1) Encode(bool) []byte {
+	return []byte{'0' + uspv1.Version, uspv1.Notice, uspv1.OptOutSale, uspv1.LSPACoveredTransaction}
+}
+
+// EncodeStrict is Encode, but returns a sections.EncodeError for a version other than 1 or a flag other
+// than Yes, No or NotApplicable.
+func (uspv1 USPV1) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckVersion("Version", uspv1.Version, 1); err != nil {
+		return nil, err
+	}
+	flags := []struct {
+		name  string
+		value byte
+	}{
+		{"Notice", uspv1.Notice},
+		{"OptOutSale", uspv1.OptOutSale},
+		{"LSPACoveredTransaction", uspv1.LSPACoveredTransaction},
+	}
+	for _, flag := range flags {
+		if err := validateFlag(flag.value); err != nil {
+			return nil, sections.NewEncodeError(flag.name, sections.ErrInvalidValue, "%s", err)
+		}
+	}
+	return uspv1.Encode(gpcIncluded), nil
+}
+
+func (uspv1 USPV1) GetID() constants.SectionID {
+	return uspv1.SectionID
+}
+
+func (uspv1 USPV1) GetValue() string {
+	return uspv1.Value
+}
+
+// uspv1JSON is the JSON form of USPV1, with the flags as characters rather than numbers.
+type uspv1JSON struct {
+	SectionID              constants.SectionID `json:"section_id"`
+	Section                string              `json:"section"`
+	Value                  string              `json:"value"`
+	Version                byte                `json:"version"`
+	Notice                 string              `json:"notice"`
+	OptOutSale             string              `json:"opt_out_sale"`
+	LSPACoveredTransaction string              `json:"lspa_covered_transaction"`
+}
+
+func (uspv1 USPV1) MarshalJSON() ([]byte, error) {
+	return json.Marshal(uspv1JSON{
+		SectionID:              uspv1.SectionID,
+		Section:                constants.SectionNamesByID[int(uspv1.SectionID)],
+		Value:                  uspv1.Value,
+		Version:                uspv1.Version,
+		Notice:                 string(uspv1.Notice),
+		OptOutSale:             string(uspv1.OptOutSale),
+		LSPACoveredTransaction: string(uspv1.LSPACoveredTransaction),
+	})
+}
+
+func (uspv1 *USPV1) UnmarshalJSON(data []byte) error {
+	var aux uspv1JSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	flags := make([]byte, 0, 3)
+	for _, flag := range []struct{ name, value string }{
+		{"Notice", aux.Notice},
+		{"OptOutSale", aux.OptOutSale},
+		{"LSPACoveredTransaction", aux.LSPACoveredTransaction},
+	} {
+		if len(flag.value) != 1 {
+			return sections.ErrorHelper(flag.name, fmt.Errorf("invalid value %q, should be one of 'Y', 'N' or '-'", flag.value))
+		}
+		if err := validateFlag(flag.value[0]); err != nil {
+			return sections.ErrorHelper(flag.name, err)
+		}
+		flags = append(flags, flag.value[0])
+	}
+
+	*uspv1 = USPV1{
+		SectionID:              aux.SectionID,
+		Value:                  aux.Value,
+		Version:                aux.Version,
+		Notice:                 flags[0],
+		OptOutSale:             flags[1],
+		LSPACoveredTransaction: flags[2],
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package uspv1
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+type uspv1TestData struct {
+	description string
+	gppString   string
+	expected    USPV1
+}
+
+func TestUSPV1(t *testing.T) {
+	testData := []uspv1TestData{
+		{
+			description: "should populate USPV1 fields correctly",
+			gppString:   "1YNN",
+			expected: USPV1{
+				SectionID:              constants.SectionUSPV1,
+				Value:                  "1YNN",
+				Version:                1,
+				Notice:                 Yes,
+				OptOutSale:             No,
+				LSPACoveredTransaction: No,
+			},
+		},
+		{
+			description: "should populate not applicable USPV1 fields correctly",
+			gppString:   "1-Y-",
+			expected: USPV1{
+				SectionID:              constants.SectionUSPV1,
+				Value:                  "1-Y-",
+				Version:                1,
+				Notice:                 NotApplicable,
+				OptOutSale:             Yes,
+				LSPACoveredTransaction: NotApplicable,
+			},
+		},
+	}
+
+	for _, test := range testData {
+		result, err := NewUSPV1(test.gppString)
+		encodedString := string(test.expected.Encode(true))
+
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, result)
+		assert.Equal(t, constants.SectionUSPV1, result.GetID())
+		assert.Equal(t, test.gppString, result.GetValue())
+		assert.Equal(t, test.gppString, encodedString)
+	}
+}
+
+func TestUSPV1Errors(t *testing.T) {
+	testData := map[string]struct {
+		gppString string
+		err       string
+	}{
+		"too-short":       {"1YN", "invalid US Privacy string length 3, should be 4 characters long"},
+		"too-long":        {"1YNNN", "invalid US Privacy string length 5, should be 4 characters long"},
+		"invalid-version": {"2YNN", "invalid US Privacy string version '2'"},
+		"invalid-flag":    {"1YxN", "unable to set field OptOutSale due to parse error: invalid character 'x', should be one of 'Y', 'N' or '-'"},
+		"lowercase-flag":  {"1yNN", "unable to set field Notice due to parse error: invalid character 'y', should be one of 'Y', 'N' or '-'"},
+	}
+
+	for name, test := range testData {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewUSPV1(test.gppString)
+			assert.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestUSPV1EncodeStrict(t *testing.T) {
+	uspv1 := USPV1{Version: 1, Notice: Yes, OptOutSale: No, LSPACoveredTransaction: NotApplicable}
+	encoded, err := uspv1.EncodeStrict(true)
+	assert.Nil(t, err)
+	assert.Equal(t, "1YN-", string(encoded))
+
+	uspv1.OptOutSale = 'X'
+	_, err = uspv1.EncodeStrict(true)
+	assert.EqualError(t, err, "unable to encode field OptOutSale: invalid character 'X', should be one of 'Y', 'N' or '-'")
+
+	uspv1.Version = 2
+	_, err = uspv1.EncodeStrict(true)
+	assert.EqualError(t, err, "unable to encode field Version: version 2 is not supported, should be 1")
+}
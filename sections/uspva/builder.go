@@ -0,0 +1,93 @@
+package uspva
+
+import (
+	"github.com/prebid/go-gpp/constants"
+	"github.com/prebid/go-gpp/sections"
+)
+
+// USPVABuilder builds a USPVA section. Every field starts as not applicable, except
+// MspaCoveredTransaction, which starts as MspaNo. Setting a field to a value outside its range, or a
+// category the section does not have, makes Build return an error.
+type USPVABuilder struct {
+	section USPVA
+	err     error
+}
+
+// Builder returns a USPVABuilder for version 1 of the section.
+func Builder() *USPVABuilder {
+	return &USPVABuilder{section: USPVA{
+		SectionID: constants.SectionUSPVA,
+		CoreSegment: sections.CommonUSCoreSegment{
+			Version:                         1,
+			SensitiveDataProcessing:         make([]byte, 8),
+			KnownChildSensitiveDataConsents: make([]byte, 1),
+			MspaCoveredTransaction:          byte(sections.MspaNo),
+		},
+	}}
+}
+
+func (b *USPVABuilder) SharingNotice(value sections.NoticeState) *USPVABuilder {
+	sections.SetField(&b.err, "CoreSegment.SharingNotice", &b.section.CoreSegment.SharingNotice, byte(value))
+	return b
+}
+
+func (b *USPVABuilder) SaleOptOutNotice(value sections.NoticeState) *USPVABuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOutNotice", &b.section.CoreSegment.SaleOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPVABuilder) TargetedAdvertisingOptOutNotice(value sections.NoticeState) *USPVABuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOutNotice", &b.section.CoreSegment.TargetedAdvertisingOptOutNotice, byte(value))
+	return b
+}
+
+func (b *USPVABuilder) SaleOptOut(value sections.OptOutState) *USPVABuilder {
+	sections.SetField(&b.err, "CoreSegment.SaleOptOut", &b.section.CoreSegment.SaleOptOut, byte(value))
+	return b
+}
+
+func (b *USPVABuilder) TargetedAdvertisingOptOut(value sections.OptOutState) *USPVABuilder {
+	sections.SetField(&b.err, "CoreSegment.TargetedAdvertisingOptOut", &b.section.CoreSegment.TargetedAdvertisingOptOut, byte(value))
+	return b
+}
+
+// SensitiveData sets the consent for a category of sensitive data, numbered from 1 to 8 in the order of
+// the section's specification.
+func (b *USPVABuilder) SensitiveData(category int, state sections.ConsentState) *USPVABuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.SensitiveDataProcessing", b.section.CoreSegment.SensitiveDataProcessing, category, byte(state))
+	return b
+}
+
+// KnownChildSensitiveData sets the consent for processing the sensitive data of a known child, for an
+// age bracket numbered from 1 to 1.
+func (b *USPVABuilder) KnownChildSensitiveData(index int, state sections.ConsentState) *USPVABuilder {
+	sections.SetFieldElem(&b.err, "CoreSegment.KnownChildSensitiveDataConsents", b.section.CoreSegment.KnownChildSensitiveDataConsents, index, byte(state))
+	return b
+}
+
+func (b *USPVABuilder) MspaCoveredTransaction(value sections.MspaMode) *USPVABuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaCoveredTransaction", &b.section.CoreSegment.MspaCoveredTransaction, byte(value))
+	return b
+}
+
+func (b *USPVABuilder) MspaOptOutOptionMode(value sections.MspaMode) *USPVABuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaOptOutOptionMode", &b.section.CoreSegment.MspaOptOutOptionMode, byte(value))
+	return b
+}
+
+func (b *USPVABuilder) MspaServiceProviderMode(value sections.MspaMode) *USPVABuilder {
+	sections.SetField(&b.err, "CoreSegment.MspaServiceProviderMode", &b.section.CoreSegment.MspaServiceProviderMode, byte(value))
+	return b
+}
+
+// Build returns the section, with its Value set to its encoding, or the first error of the setters.
+func (b *USPVABuilder) Build() (USPVA, error) {
+	if b.err != nil {
+		return USPVA{}, b.err
+	}
+	uspva := b.section
+	uspva.CoreSegment.SensitiveDataProcessing = append([]byte(nil), b.section.CoreSegment.SensitiveDataProcessing...)
+	uspva.CoreSegment.KnownChildSensitiveDataConsents = append([]byte(nil), b.section.CoreSegment.KnownChildSensitiveDataConsents...)
+	uspva.Value = string(uspva.Encode(true))
+	return uspva, nil
+}
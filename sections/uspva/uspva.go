@@ -38,12 +38,29 @@ func NewUSPVA(encoded string) (USPVA, error) {
 	return uspva, nil
 }
 
+// HasNonZeroPadding reports whether the encoded section has set bits after the fields of a segment.
+func (uspva USPVA) HasNonZeroPadding() bool {
+	return sections.HasNonZeroUSPadding(uspva.Value, func(bs *util.BitStream) error {
+		_, err := sections.NewCommonUSCoreSegment(8, 1, bs)
+		return err
+	})
+}
+
 func (uspva USPVA) Encode(bool) []byte {
 	bs := util.NewBitStreamForWrite()
 	uspva.CoreSegment.Encode(bs)
 	return bs.Base64Encode()
 }
 
+// EncodeStrict is Encode, but returns a sections.EncodeError instead of writing a field which does not fit
+// the section.
+func (uspva USPVA) EncodeStrict(gpcIncluded bool) ([]byte, error) {
+	if err := sections.CheckUSCoreSegment(uspva.CoreSegment, 8, 1); err != nil {
+		return nil, err
+	}
+	return uspva.Encode(gpcIncluded), nil
+}
+
 func (uspva USPVA) GetID() constants.SectionID {
 	return uspva.SectionID
 }
@@ -51,3 +68,67 @@ func (uspva USPVA) GetID() constants.SectionID {
 func (uspva USPVA) GetValue() string {
 	return uspva.Value
 }
+
+// SaleOptOut implements sections.USPrivacySection.
+func (uspva USPVA) SaleOptOut() sections.OptOutState {
+	return sections.OptOutState(uspva.CoreSegment.SaleOptOut)
+}
+
+// TargetedAdvertisingOptOut implements sections.USPrivacySection.
+func (uspva USPVA) TargetedAdvertisingOptOut() sections.OptOutState {
+	return sections.OptOutState(uspva.CoreSegment.TargetedAdvertisingOptOut)
+}
+
+// SharingOptOut implements sections.USPrivacySection. The section has no sharing opt-out, so it is
+// always OptOutNotApplicable.
+func (uspva USPVA) SharingOptOut() sections.OptOutState {
+	return sections.OptOutNotApplicable
+}
+
+// SaleOptOutNotice implements sections.USPrivacySection.
+func (uspva USPVA) SaleOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspva.CoreSegment.SaleOptOutNotice)
+}
+
+// TargetedAdvertisingOptOutNotice implements sections.USPrivacySection.
+func (uspva USPVA) TargetedAdvertisingOptOutNotice() sections.NoticeState {
+	return sections.NoticeState(uspva.CoreSegment.TargetedAdvertisingOptOutNotice)
+}
+
+// MspaServiceProviderMode implements sections.USPrivacySection.
+func (uspva USPVA) MspaServiceProviderMode() sections.MspaMode {
+	return sections.MspaMode(uspva.CoreSegment.MspaServiceProviderMode)
+}
+
+// SensitiveDataConsent implements sections.USPrivacySection.
+func (uspva USPVA) SensitiveDataConsent(category int) sections.ConsentState {
+	return sections.SensitiveDataConsent(uspva.CoreSegment.SensitiveDataProcessing, category)
+}
+
+// SensitiveDataConsents implements sections.USPrivacySection.
+func (uspva USPVA) SensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspva.CoreSegment.SensitiveDataProcessing)
+}
+
+// KnownChildSensitiveDataConsents implements sections.USPrivacySection.
+func (uspva USPVA) KnownChildSensitiveDataConsents() []sections.ConsentState {
+	return sections.ConsentStates(uspva.CoreSegment.KnownChildSensitiveDataConsents)
+}
+
+// GPC implements sections.USPrivacySection. The section has no GPC segment, so it is always false.
+func (uspva USPVA) GPC() bool {
+	return false
+}
+
+func (uspva USPVA) MarshalJSON() ([]byte, error) {
+	return sections.MarshalJSONFields(uspva)
+}
+
+func (uspva *USPVA) UnmarshalJSON(data []byte) error {
+	return sections.UnmarshalJSONFields(data, uspva)
+}
+
+// Validate implements sections.Validator.
+func (uspva USPVA) Validate() []sections.ValidationIssue {
+	return sections.ValidateUSCoreSegment(uspva.CoreSegment, 8, 1)
+}
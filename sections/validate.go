@@ -0,0 +1,139 @@
+package sections
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Severity is how serious a ValidationIssue is.
+type Severity int
+
+const (
+	// SeverityWarning marks a combination of values the GPP specification discourages, which parsers
+	// still accept.
+	SeverityWarning Severity = iota
+	// SeverityError marks a value the GPP specification does not allow.
+	SeverityError
+)
+
+func (s Severity) String() string {
+	if s == SeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// ValidationIssue is a problem found in the fields of a decoded section.
+type ValidationIssue struct {
+	Field    string
+	Severity Severity
+	Message  string
+}
+
+func (issue ValidationIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s", issue.Severity, issue.Field, issue.Message)
+}
+
+// Validator is implemented by the sections which can check their fields, see ValidateUSCoreSegment.
+type Validator interface {
+	Validate() []ValidationIssue
+}
+
+// optOutNotices pairs the opt-out fields of the US sections with the notice that must be given for them.
+var optOutNotices = []struct{ optOut, notice string }{
+	{"SaleOptOut", "SaleOptOutNotice"},
+	{"SharingOptOut", "SharingOptOutNotice"},
+	{"TargetedAdvertisingOptOut", "TargetedAdvertisingOptOutNotice"},
+}
+
+// ValidateUSCoreSegment checks the core segment of a US section against the value tables of the GPP
+// specification, given the number of sensitive data and known child fields the section has. It reports
+// as errors:
+//   - values of notice, opt-out, consent and MSPA fields outside 0 to 2, and a MspaCoveredTransaction of 0
+//   - arrays of the wrong length
+//   - an opt-out other than not applicable when its notice is not applicable
+//   - an opt-out declined although its notice was not provided
+//   - opt-outs other than not applicable, or an MspaOptOutOptionMode of yes, in service provider mode
+//
+// and as warnings a notice without its opt-out, and MSPA modes set for a transaction the MSPA does not
+// cover. The notice and opt-out errors are those of the IAB reference validators.
+func ValidateUSCoreSegment(segment interface{}, sensitiveDataFields int, knownChildDataFields int) []ValidationIssue {
+	v := reflect.ValueOf(segment)
+	t := v.Type()
+	fields := make(map[string]byte)
+	var issues []ValidationIssue
+	addIssue := func(field string, severity Severity, format string, args ...interface{}) {
+		issues = append(issues, ValidationIssue{
+			Field:    "CoreSegment." + field,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Name
+		field := v.Field(i)
+		switch {
+		case name == "Version":
+			continue
+		case field.Kind() == reflect.Slice:
+			size := sensitiveDataFields
+			if name == "KnownChildSensitiveDataConsents" {
+				size = knownChildDataFields
+			}
+			if field.Len() != size {
+				addIssue(name, SeverityError, "has %d values, should have %d", field.Len(), size)
+			}
+			for j := 0; j < field.Len(); j++ {
+				if value := field.Index(j).Uint(); value > 2 {
+					addIssue(fmt.Sprintf("%s[%d]", name, j+1), SeverityError, "invalid value %d", value)
+				}
+			}
+		case field.Kind() == reflect.Uint8:
+			value := byte(field.Uint())
+			fields[name] = value
+			min, max := fieldRange("CoreSegment." + name)
+			if value < min || value > max {
+				addIssue(name, SeverityError, "invalid value %d", value)
+			}
+		}
+	}
+
+	for _, pair := range optOutNotices {
+		optOut, notice := pair.optOut, pair.notice
+		optOutValue, ok := fields[optOut]
+		noticeValue, hasNotice := fields[notice]
+		if !ok || !hasNotice {
+			continue
+		}
+		switch {
+		case NoticeState(noticeValue) == NoticeNotApplicable && OptOutState(optOutValue) != OptOutNotApplicable:
+			addIssue(optOut, SeverityError, "is set although %s is not applicable", notice)
+		case NoticeState(noticeValue) != NoticeNotApplicable && OptOutState(optOutValue) == OptOutNotApplicable:
+			addIssue(optOut, SeverityWarning, "is not applicable although %s is set", notice)
+		case NoticeState(noticeValue) == NoticeNotProvided && OptOutState(optOutValue) == DidNotOptOut:
+			addIssue(optOut, SeverityError, "was declined although %s was not provided", notice)
+		}
+	}
+
+	if MspaMode(fields["MspaServiceProviderMode"]) == MspaYes {
+		for _, pair := range optOutNotices {
+			if value, ok := fields[pair.optOut]; ok && OptOutState(value) != OptOutNotApplicable {
+				addIssue(pair.optOut, SeverityError, "must be not applicable in MSPA service provider mode")
+			}
+		}
+		if MspaMode(fields["MspaOptOutOptionMode"]) == MspaYes {
+			addIssue("MspaOptOutOptionMode", SeverityError, "must not be yes in MSPA service provider mode")
+		}
+	}
+
+	if MspaMode(fields["MspaCoveredTransaction"]) == MspaNo {
+		for _, mode := range []string{"MspaOptOutOptionMode", "MspaServiceProviderMode"} {
+			if MspaMode(fields[mode]) != MspaNotApplicable {
+				addIssue(mode, SeverityWarning, "is set although the transaction is not covered by the MSPA")
+			}
+		}
+	}
+
+	return issues
+}
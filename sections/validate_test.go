@@ -0,0 +1,176 @@
+package sections_test
+
+import (
+	"testing"
+
+	"github.com/prebid/go-gpp/sections"
+	"github.com/prebid/go-gpp/sections/uspca"
+	"github.com/prebid/go-gpp/sections/uspco"
+	"github.com/prebid/go-gpp/sections/uspct"
+	"github.com/prebid/go-gpp/sections/uspde"
+	"github.com/prebid/go-gpp/sections/uspfl"
+	"github.com/prebid/go-gpp/sections/uspia"
+	"github.com/prebid/go-gpp/sections/uspin"
+	"github.com/prebid/go-gpp/sections/uspky"
+	"github.com/prebid/go-gpp/sections/uspmd"
+	"github.com/prebid/go-gpp/sections/uspmn"
+	"github.com/prebid/go-gpp/sections/uspmt"
+	"github.com/prebid/go-gpp/sections/uspnat"
+	"github.com/prebid/go-gpp/sections/uspne"
+	"github.com/prebid/go-gpp/sections/uspnh"
+	"github.com/prebid/go-gpp/sections/uspnj"
+	"github.com/prebid/go-gpp/sections/uspor"
+	"github.com/prebid/go-gpp/sections/uspri"
+	"github.com/prebid/go-gpp/sections/usptn"
+	"github.com/prebid/go-gpp/sections/usptx"
+	"github.com/prebid/go-gpp/sections/usput"
+	"github.com/prebid/go-gpp/sections/uspva"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateBuiltSections(t *testing.T) {
+	validators := []func() (sections.Validator, error){
+		func() (sections.Validator, error) { return uspnat.Builder().Build() },
+		func() (sections.Validator, error) { return uspca.Builder().Build() },
+		func() (sections.Validator, error) { return uspva.Builder().Build() },
+		func() (sections.Validator, error) { return uspco.Builder().Build() },
+		func() (sections.Validator, error) { return usput.Builder().Build() },
+		func() (sections.Validator, error) { return uspct.Builder().Build() },
+		func() (sections.Validator, error) { return uspfl.Builder().Build() },
+		func() (sections.Validator, error) { return uspmt.Builder().Build() },
+		func() (sections.Validator, error) { return uspor.Builder().Build() },
+		func() (sections.Validator, error) { return usptx.Builder().Build() },
+		func() (sections.Validator, error) { return uspde.Builder().Build() },
+		func() (sections.Validator, error) { return uspia.Builder().Build() },
+		func() (sections.Validator, error) { return uspne.Builder().Build() },
+		func() (sections.Validator, error) { return uspnh.Builder().Build() },
+		func() (sections.Validator, error) { return uspnj.Builder().Build() },
+		func() (sections.Validator, error) { return usptn.Builder().Build() },
+		func() (sections.Validator, error) { return uspmn.Builder().Build() },
+		func() (sections.Validator, error) { return uspmd.Builder().Build() },
+		func() (sections.Validator, error) { return uspin.Builder().Build() },
+		func() (sections.Validator, error) { return uspky.Builder().Build() },
+		func() (sections.Validator, error) { return uspri.Builder().Build() },
+	}
+
+	for _, build := range validators {
+		section, err := build()
+		assert.Nil(t, err)
+		assert.Empty(t, section.Validate(), "%T", section)
+	}
+}
+
+func TestValidateUSCoreSegment(t *testing.T) {
+	valid := func() sections.CommonUSCoreSegment {
+		return sections.CommonUSCoreSegment{
+			Version:                         1,
+			SharingNotice:                   1,
+			SaleOptOutNotice:                1,
+			TargetedAdvertisingOptOutNotice: 1,
+			SaleOptOut:                      2,
+			TargetedAdvertisingOptOut:       1,
+			SensitiveDataProcessing:         []byte{0, 1, 2},
+			KnownChildSensitiveDataConsents: []byte{1},
+			MspaCoveredTransaction:          1,
+			MspaOptOutOptionMode:            1,
+			MspaServiceProviderMode:         2,
+		}
+	}
+
+	testData := []struct {
+		description string
+		change      func(segment *sections.CommonUSCoreSegment)
+		expected    []sections.ValidationIssue
+	}{
+		{
+			description: "should accept a valid segment",
+			change:      func(segment *sections.CommonUSCoreSegment) {},
+		},
+		{
+			description: "should reject reserved values",
+			change: func(segment *sections.CommonUSCoreSegment) {
+				segment.SharingNotice = 3
+				segment.SensitiveDataProcessing[1] = 3
+				segment.MspaCoveredTransaction = 0
+			},
+			expected: []sections.ValidationIssue{
+				{Field: "CoreSegment.SharingNotice", Severity: sections.SeverityError, Message: "invalid value 3"},
+				{Field: "CoreSegment.SensitiveDataProcessing[2]", Severity: sections.SeverityError, Message: "invalid value 3"},
+				{Field: "CoreSegment.MspaCoveredTransaction", Severity: sections.SeverityError, Message: "invalid value 0"},
+			},
+		},
+		{
+			description: "should reject arrays of the wrong length",
+			change: func(segment *sections.CommonUSCoreSegment) {
+				segment.KnownChildSensitiveDataConsents = nil
+			},
+			expected: []sections.ValidationIssue{
+				{Field: "CoreSegment.KnownChildSensitiveDataConsents", Severity: sections.SeverityError, Message: "has 0 values, should have 1"},
+			},
+		},
+		{
+			// An opt-out can only be chosen once its notice applies.
+			description: "should reject an opt-out whose notice is not applicable",
+			change: func(segment *sections.CommonUSCoreSegment) {
+				segment.SaleOptOutNotice = 0
+			},
+			expected: []sections.ValidationIssue{
+				{Field: "CoreSegment.SaleOptOut", Severity: sections.SeverityError, Message: "is set although SaleOptOutNotice is not applicable"},
+			},
+		},
+		{
+			// Without the notice the user cannot have declined the opt-out, so it must be opted out.
+			description: "should reject an opt-out declined without its notice",
+			change: func(segment *sections.CommonUSCoreSegment) {
+				segment.TargetedAdvertisingOptOutNotice = 2
+				segment.TargetedAdvertisingOptOut = 2
+			},
+			expected: []sections.ValidationIssue{
+				{Field: "CoreSegment.TargetedAdvertisingOptOut", Severity: sections.SeverityError, Message: "was declined although TargetedAdvertisingOptOutNotice was not provided"},
+			},
+		},
+		{
+			description: "should warn about a notice without its opt-out",
+			change: func(segment *sections.CommonUSCoreSegment) {
+				segment.SaleOptOut = 0
+			},
+			expected: []sections.ValidationIssue{
+				{Field: "CoreSegment.SaleOptOut", Severity: sections.SeverityWarning, Message: "is not applicable although SaleOptOutNotice is set"},
+			},
+		},
+		{
+			description: "should reject opt-outs in service provider mode",
+			change: func(segment *sections.CommonUSCoreSegment) {
+				segment.SaleOptOutNotice = 0
+				segment.SaleOptOut = 0
+				segment.MspaServiceProviderMode = 1
+			},
+			expected: []sections.ValidationIssue{
+				{Field: "CoreSegment.TargetedAdvertisingOptOut", Severity: sections.SeverityError, Message: "must be not applicable in MSPA service provider mode"},
+				{Field: "CoreSegment.MspaOptOutOptionMode", Severity: sections.SeverityError, Message: "must not be yes in MSPA service provider mode"},
+			},
+		},
+		{
+			description: "should warn about MSPA modes for a transaction not covered",
+			change: func(segment *sections.CommonUSCoreSegment) {
+				segment.MspaCoveredTransaction = 2
+			},
+			expected: []sections.ValidationIssue{
+				{Field: "CoreSegment.MspaOptOutOptionMode", Severity: sections.SeverityWarning, Message: "is set although the transaction is not covered by the MSPA"},
+				{Field: "CoreSegment.MspaServiceProviderMode", Severity: sections.SeverityWarning, Message: "is set although the transaction is not covered by the MSPA"},
+			},
+		},
+	}
+
+	for _, test := range testData {
+		segment := valid()
+		test.change(&segment)
+		assert.Equal(t, test.expected, sections.ValidateUSCoreSegment(segment, 3, 1), test.description)
+	}
+}
+
+func TestValidationIssueString(t *testing.T) {
+	issue := sections.ValidationIssue{Field: "CoreSegment.SaleOptOut", Severity: sections.SeverityError, Message: "invalid value 3"}
+
+	assert.Equal(t, "error: CoreSegment.SaleOptOut: invalid value 3", issue.String())
+}
@@ -2,12 +2,12 @@ package util
 
 import (
 	"encoding/base64"
-	"encoding/binary"
 	"fmt"
+	"math"
 )
 
 type BitStream struct {
-	p uint16 // position
+	p uint64 // position
 	b []byte
 }
 
@@ -22,7 +22,7 @@ func NewBitStreamFromBase64(encoded string) (*BitStream, error) {
 	decoded := make([]byte, base64.RawURLEncoding.DecodedLen(len(buff)))
 	n, err := base64.RawURLEncoding.Decode(decoded, buff)
 	if err != nil {
-		return nil, err
+		return nil, &Base64Error{Err: err}
 	}
 	decoded = decoded[:n:n]
 
@@ -41,18 +41,97 @@ func padLastQuantum(encoded string) string {
 }
 
 // GetPosition reads out the position of the bit pointer in the bit stream
-func (bs *BitStream) GetPosition() uint16 {
+func (bs *BitStream) GetPosition() uint64 {
 	return bs.p
 }
 
 // SetPosition sets the position of the bit pointer in the bit stream
-func (bs *BitStream) SetPosition(pos uint16) {
+func (bs *BitStream) SetPosition(pos uint64) {
 	bs.p = pos
 }
 
 // Len returns the number of bytes in the BitStream
-func (bs *BitStream) Len() uint16 {
-	return uint16(len(bs.b))
+func (bs *BitStream) Len() uint64 {
+	return uint64(len(bs.b))
+}
+
+// HasNonZeroRemainder reports whether any bit after the position of the bit pointer is set. It does not
+// move the pointer.
+func (bs *BitStream) HasNonZeroRemainder() bool {
+	bitLen := uint64(len(bs.b)) * 8
+	for i := bs.p; i < bitLen; i++ {
+		if bs.b[i/8]&(0x80>>(i%8)) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadBits reads n bits from the bitstream as an unsigned integer, most significant bit first, advancing
+// the pointer. n must be 1 to 64.
+func (bs *BitStream) ReadBits(n int) (uint64, error) {
+	v, err := ParseBits(bs.b, bs.p, n)
+	if err == nil {
+		bs.p = bs.p + uint64(n)
+		return v, nil
+	}
+	return 0, err
+}
+
+// ParseBits parses n bits of data from the data array as an unsigned integer, most significant bit first,
+// starting at the given index. n must be 1 to 64.
+func ParseBits(data []byte, bitStartIndex uint64, n int) (uint64, error) {
+	if n < 1 || n > 64 {
+		return 0, fmt.Errorf("invalid width of %d bits, should be 1 to 64: %w", n, ErrInvalidWidth)
+	}
+	if err := checkOverflow(bitStartIndex, uint64(n)); err != nil {
+		return 0, err
+	}
+	// Compare against the bits left rather than rounding the end up to bytes, which would wrap around
+	// near the largest bit position.
+	bitLen := uint64(len(data)) * 8
+	if bitStartIndex > bitLen || uint64(n) > bitLen-bitStartIndex {
+		unit := "bits"
+		if n == 1 {
+			unit = "bit"
+		}
+		return 0, newReadError(bitStartIndex, "expected %d %s to start at bit %d, but the byte array was only %d bytes long", n, unit, bitStartIndex, len(data))
+	}
+
+	end := bitStartIndex + uint64(n)
+	var v uint64
+	for p := bitStartIndex; p < end; {
+		offset := p % 8
+		// Take the rest of the current byte, or as much of it as is still needed.
+		width := 8 - offset
+		if width > end-p {
+			width = end - p
+		}
+		v = v<<width | uint64(data[p/8]<<offset>>(8-width))
+		p += width
+	}
+	return v, nil
+}
+
+// checkOverflow returns an error wrapping ErrPositionOverflow if n bits starting at bitStartIndex would
+// end past the largest bit position, which would otherwise wrap around to the start of the data.
+func checkOverflow(bitStartIndex, n uint64) error {
+	if bitStartIndex > math.MaxUint64-n {
+		return fmt.Errorf("%d bits starting at bit %d: %w", n, bitStartIndex, ErrPositionOverflow)
+	}
+	return nil
+}
+
+// parseByte parses n bits, at most 8, with ParseBits.
+func parseByte(data []byte, bitStartIndex uint64, n int) (byte, error) {
+	v, err := ParseBits(data, bitStartIndex, n)
+	return byte(v), err
+}
+
+// parseUInt16 parses n bits, at most 16, with ParseBits.
+func parseUInt16(data []byte, bitStartIndex uint64, n int) (uint16, error) {
+	v, err := ParseBits(data, bitStartIndex, n)
+	return uint16(v), err
 }
 
 // ReadByte1 reads 1 bit from the bitstream, advancing the pointer
@@ -66,14 +145,8 @@ func (bs *BitStream) ReadByte1() (byte, error) {
 }
 
 // ParseByte1 parses 1 bit of data from the data array, starting at the given index
-func ParseByte1(data []byte, bitStartIndex uint16) (byte, error) {
-	startByte := bitStartIndex / 8
-	bitOffset := bitStartIndex % 8
-	if uint16(len(data)) < (startByte + 1) {
-		return 0, fmt.Errorf("expected 1 bit at bit %d, but the byte array was only %d bytes long", bitStartIndex, len(data))
-	}
-
-	return (data[startByte] & (0x80 >> bitOffset)) >> (7 - bitOffset), nil
+func ParseByte1(data []byte, bitStartIndex uint64) (byte, error) {
+	return parseByte(data, bitStartIndex, 1)
 }
 
 // ReadByte2 reads 2 bits fron the bitstream, advancing the pointer
@@ -87,23 +160,8 @@ func (bs *BitStream) ReadByte2() (byte, error) {
 }
 
 // ParseByte2 parses 2 bits of data from the data array, starting at the given index
-func ParseByte2(data []byte, bitStartIndex uint16) (byte, error) {
-	startByte := bitStartIndex / 8
-	bitStartOffset := bitStartIndex % 8
-	if bitStartOffset < 7 {
-		if uint16(len(data)) < (startByte + 1) {
-			return 0, fmt.Errorf("expected 2 bits to start at bit %d, but the byte array was only %d bytes long", bitStartIndex, len(data))
-		}
-		return (data[startByte] & (0xc0 >> bitStartOffset) >> (6 - bitStartOffset)), nil
-	}
-	if uint16(len(data)) < (startByte+2) && bitStartOffset > 6 {
-		return 0, fmt.Errorf("expected 2 bits to start at bit %d, but the byte array was only %d bytes long", bitStartIndex, len(data))
-	}
-
-	leftBits := (data[startByte] & (0xc0 >> bitStartOffset)) << 1
-	overflow := 1
-	rightBits := (data[startByte+1] & (0xc0 << overflow)) >> 7
-	return leftBits | rightBits, nil
+func ParseByte2(data []byte, bitStartIndex uint64) (byte, error) {
+	return parseByte(data, bitStartIndex, 2)
 }
 
 // ReadByte4 reads 4 bits from the bitstream, advancing the pointer
@@ -117,24 +175,8 @@ func (bs *BitStream) ReadByte4() (byte, error) {
 }
 
 // ParseByte4 parses 4 bits of data from the data array, starting at the given index
-func ParseByte4(data []byte, bitStartIndex uint16) (byte, error) {
-	startByte := bitStartIndex / 8
-	bitStartOffset := bitStartIndex % 8
-	if bitStartOffset < 5 {
-		if uint16(len(data)) < (startByte + 1) {
-			return 0, fmt.Errorf("expected 4 bits to start at bit %d, but the byte array was only %d bytes long", bitStartIndex, len(data))
-		}
-		return (data[startByte] & (0xf0 >> bitStartOffset)) >> (4 - bitStartOffset), nil
-	}
-	if uint16(len(data)) < (startByte+2) && bitStartOffset > 4 {
-		return 0, fmt.Errorf("expected 4 bits to start at bit %d, but the byte array was only %d bytes long (needs second byte)", bitStartIndex, len(data))
-	}
-
-	leftBits := (data[startByte] & (0xf0 >> bitStartOffset)) << (bitStartOffset - 4)
-	bitsConsumed := 8 - bitStartOffset
-	overflow := 4 - bitsConsumed
-	rightBits := (data[startByte+1] & (0xf0 << (4 - overflow))) >> (8 - overflow)
-	return leftBits | rightBits, nil
+func ParseByte4(data []byte, bitStartIndex uint64) (byte, error) {
+	return parseByte(data, bitStartIndex, 4)
 }
 
 // ReadByte6 reads 6 bits from the bitstream, advancing the pointer
@@ -148,25 +190,8 @@ func (bs *BitStream) ReadByte6() (byte, error) {
 }
 
 // ParseByte6 parses 6 bits of data from the data array, starting at the given index
-func ParseByte6(data []byte, bitStartIndex uint16) (byte, error) {
-	startByte := bitStartIndex / 8
-	bitStartOffset := bitStartIndex % 8
-	if bitStartOffset < 3 {
-		if uint16(len(data)) < (startByte + 1) {
-			return 0, fmt.Errorf("expected 6 bits to start at bit %d, but the byte array was only %d bytes long", bitStartIndex, len(data))
-		}
-		return data[startByte] >> (2 - bitStartIndex), nil
-	}
-	if uint16(len(data)) < (startByte + 2) {
-		return 0, fmt.Errorf("expected 6 bits to start at bit %d, but the byte array was only %d bytes long (needs second byte)", bitStartIndex, len(data))
-	}
-
-	leftBits := (data[startByte] & (0xfc >> bitStartOffset)) << (bitStartOffset - 2)
-	bitsConsumed := 8 - bitStartOffset
-	overflow := 6 - bitsConsumed
-	// If the overflow is negative, rightBits get shifted out of existence.
-	rightBits := (data[startByte+1] & (0xfc << (6 - overflow))) >> (8 - overflow)
-	return leftBits | rightBits, nil
+func ParseByte6(data []byte, bitStartIndex uint64) (byte, error) {
+	return parseByte(data, bitStartIndex, 6)
 }
 
 // ReadByte8 reads 8 bits from the bitstream, advancing the pointer
@@ -180,23 +205,8 @@ func (bs *BitStream) ReadByte8() (byte, error) {
 }
 
 // ParseByte8 parses 8 bits of data from the data array, starting at the given index
-func ParseByte8(data []byte, bitStartIndex uint16) (byte, error) {
-	startByte := bitStartIndex / 8
-	bitStartOffset := bitStartIndex % 8
-	if bitStartOffset == 0 {
-		if uint16(len(data)) < (startByte + 1) {
-			return 0, fmt.Errorf("expected 8 bits to start at bit %d, but the byte array was only %d bytes long", bitStartIndex, len(data))
-		}
-		return data[startByte], nil
-	}
-	if uint16(len(data)) < (startByte + 2) {
-		return 0, fmt.Errorf("expected 8 bits to start at bit %d, but the byte array was only %d bytes long", bitStartIndex, len(data))
-	}
-
-	leftBits := (data[startByte] & (0xff >> bitStartOffset)) << bitStartOffset
-	shiftComplement := 8 - bitStartOffset
-	rightBits := (data[startByte+1] & (0xff << shiftComplement)) >> shiftComplement
-	return leftBits | rightBits, nil
+func ParseByte8(data []byte, bitStartIndex uint64) (byte, error) {
+	return parseByte(data, bitStartIndex, 8)
 }
 
 // ReadUInt12 reads 12 bits from the bitstream, advancing the pointer
@@ -210,28 +220,8 @@ func (bs *BitStream) ReadUInt12() (uint16, error) {
 }
 
 // ParseUInt12 parses 12 bits of data from the data array, starting at the given index
-func ParseUInt12(data []byte, bitStartIndex uint16) (uint16, error) {
-	end := bitStartIndex + 12
-	endByte := end / 8
-	endOffset := end % 8
-
-	if endOffset > 0 {
-		endByte++
-	}
-	if uint16(len(data)) < endByte {
-		return 0, fmt.Errorf("expected a 12-bit int to start at bit %d, but the byte array was only %d bytes long",
-			bitStartIndex, len(data))
-	}
-
-	leftByte, err := ParseByte4(data, bitStartIndex)
-	if err != nil {
-		return 0, fmt.Errorf("error reading first 4 bits of a 12 bit integer: %s", err)
-	}
-	rightByte, err := ParseByte8(data, bitStartIndex+4)
-	if err != nil {
-		return 0, fmt.Errorf("error reading the last 8 bits of a 12 bit integer: %s", err)
-	}
-	return binary.BigEndian.Uint16([]byte{leftByte, rightByte}), nil
+func ParseUInt12(data []byte, bitStartIndex uint64) (uint16, error) {
+	return parseUInt16(data, bitStartIndex, 12)
 }
 
 // ReadUInt16 reads 16 bits from the bitstream, advancing the pointer
@@ -245,28 +235,8 @@ func (bs *BitStream) ReadUInt16() (uint16, error) {
 }
 
 // ParseUInt16  parses a 16-bit integer from the data array, starting at the given index
-func ParseUInt16(data []byte, bitStartIndex uint16) (uint16, error) {
-	startByte := bitStartIndex / 8
-	bitStartOffset := bitStartIndex % 8
-	if bitStartOffset == 0 {
-		if uint16(len(data)) < (startByte + 2) {
-			return 0, fmt.Errorf("expected a 16-bit int to start at bit %d, but the byte array was only %d bytes long", bitStartIndex, len(data))
-		}
-		return binary.BigEndian.Uint16(data[startByte : startByte+2]), nil
-	}
-	if uint16(len(data)) < (startByte + 3) {
-		return 0, fmt.Errorf("expected a 16-bit int to start at bit %d, but the byte array was only %d bytes long", bitStartIndex, len(data))
-	}
-
-	leftByte, err := ParseByte8(data, bitStartIndex)
-	if err != nil {
-		return 0, fmt.Errorf("error reading the first 8 bits of a 16 bit integer: %s", err)
-	}
-	rightByte, err := ParseByte8(data, bitStartIndex+8)
-	if err != nil {
-		return 0, fmt.Errorf("error reading the last 8 bits of a 16 bit integer: %s", err)
-	}
-	return binary.BigEndian.Uint16([]byte{leftByte, rightByte}), nil
+func ParseUInt16(data []byte, bitStartIndex uint64) (uint16, error) {
+	return parseUInt16(data, bitStartIndex, 16)
 }
 
 func (bs *BitStream) ReadTwoBitField(numFields int) ([]byte, error) {
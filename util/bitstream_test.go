@@ -14,14 +14,14 @@ var testData = []byte{0x04, 0xa2, 0x03, 0xb1, 0x00, 0x2b}
 
 type testDefinition struct {
 	data   []byte // The data to feed the function
-	offset uint16 // The bit offset in the byte slice to start
+	offset uint64 // The bit offset in the byte slice to start
 	value  uint64 // The value we expect the function to return (64 bit to allow for future functions that extract larger ints)
 	err    string // Expected error value
 }
 
 func TestReadByte1(t *testing.T) {
 	testSet := map[string]testDefinition{
-		"Bit out of bounds":       {testData, 80, 0, "expected 1 bit at bit 80, but the byte array was only 6 bytes long"},
+		"Bit out of bounds":       {testData, 80, 0, "expected 1 bit to start at bit 80, but the byte array was only 6 bytes long"},
 		"0 in first byte":         {testData, 2, 0, ""},  // testData 0 in first byte
 		"1 in first byte":         {testData, 5, 1, ""},  // testData 1 in first byte
 		"1 in last bit, 3rd byte": {testData, 23, 1, ""}, // testData 1 in last bit of third byte
@@ -75,7 +75,7 @@ func TestParseByte2(t *testing.T) {
 func TestReadByte4(t *testing.T) {
 	testSet := map[string]testDefinition{
 		"Bits overrun": {testData, 46, 0,
-			"expected 4 bits to start at bit 46, but the byte array was only 6 bytes long (needs second byte)"},
+			"expected 4 bits to start at bit 46, but the byte array was only 6 bytes long"},
 		"Bits out of bounds": {testData, 80, 0,
 			"expected 4 bits to start at bit 80, but the byte array was only 6 bytes long"},
 		"Spans 2 bytes":     {testData, 21, 7, ""},           // testData duplicate of Offset which involves flowing over to a second byte
@@ -106,7 +106,7 @@ func TestReadByte4(t *testing.T) {
 func TestReadByte6(t *testing.T) {
 	testSet := map[string]testDefinition{
 		"Bits overrun": {testData, 46, 0,
-			"expected 6 bits to start at bit 46, but the byte array was only 6 bytes long (needs second byte)"},
+			"expected 6 bits to start at bit 46, but the byte array was only 6 bytes long"},
 		"Bits out of bounds": {testData, 80, 0,
 			"expected 6 bits to start at bit 80, but the byte array was only 6 bytes long"},
 		"Spans 2 bytes":    {testData, 21, 29, ""},          // testData duplicate of Offset which involves flowing over to a second byte
@@ -114,7 +114,8 @@ func TestReadByte6(t *testing.T) {
 		"Spans 2 bytes 2":  {testData, 6, 10, ""},           // testData duplicate of Offset which involves flowing over to a second byte
 		"No offset":        {[]byte{0x10}, 0, 4, ""},        // No offset
 		"nibble aligned 2": {[]byte{0x92, 0x80}, 4, 10, ""}, // Offset which aligns with a nibble
-		"unaligned":        {[]byte{0x99}, 1, 76, ""},       // Offset which doesn't align with a nibble.
+		"unaligned":        {[]byte{0x99}, 1, 12, ""},       // Offset which doesn't align with a nibble.
+		"byte aligned":     {[]byte{0x99, 0xa4}, 8, 41, ""}, // Offset which aligns with the start of a later byte
 		"spans 2 bytes 3":  {[]byte{0x01, 0xe0}, 7, 60, ""}, // Offset which involves flowing over to a second byte
 	}
 
@@ -135,7 +136,7 @@ func TestReadByte6(t *testing.T) {
 
 type bitFieldTestDefinition struct {
 	data   []byte // The data to feed the function
-	offset uint16 // The bit offset in the byte slice to start
+	offset uint64 // The bit offset in the byte slice to start
 	fields int    // The number of 2-bit fields to read
 	value  []byte // The expected return value
 	err    string // Expected error value
@@ -196,11 +197,11 @@ func TestReadByte8(t *testing.T) {
 func TestReadUInt12(t *testing.T) {
 	testSet := map[string]testDefinition{
 		"Bytes overrun": {testData, 44, 0,
-			"expected a 12-bit int to start at bit 44, but the byte array was only 6 bytes long"},
+			"expected 12 bits to start at bit 44, but the byte array was only 6 bytes long"},
 		"Bits overrun": {testData, 40, 0,
-			"expected a 12-bit int to start at bit 40, but the byte array was only 6 bytes long"},
+			"expected 12 bits to start at bit 40, but the byte array was only 6 bytes long"},
 		"Bits out of bounds": {testData, 80, 0,
-			"expected a 12-bit int to start at bit 80, but the byte array was only 6 bytes long"},
+			"expected 12 bits to start at bit 80, but the byte array was only 6 bytes long"},
 		"Even offset, 2 bytes": {testData, 10, 2176, ""}, // Even Offset that does not align to a nibble, but fits 2 bytes
 		"Zero offset":          {testData, 16, 59, ""},   // Zero Offset
 		"Odd offset, 3 bytes":  {testData, 19, 472, ""},  // Odd Offset that overflows to 3rd byte
@@ -228,11 +229,11 @@ func TestReadUInt12(t *testing.T) {
 func TestReadUInt16(t *testing.T) {
 	testSet := map[string]testDefinition{
 		"Bytes overrun": {testData, 44, 0,
-			"expected a 16-bit int to start at bit 44, but the byte array was only 6 bytes long"},
+			"expected 16 bits to start at bit 44, but the byte array was only 6 bytes long"},
 		"Bits overrun": {testData, 40, 0,
-			"expected a 16-bit int to start at bit 40, but the byte array was only 6 bytes long"},
+			"expected 16 bits to start at bit 40, but the byte array was only 6 bytes long"},
 		"Bits out of bounds": {testData, 80, 0,
-			"expected a 16-bit int to start at bit 80, but the byte array was only 6 bytes long"},
+			"expected 16 bits to start at bit 80, but the byte array was only 6 bytes long"},
 		"Even offset":    {testData, 10, 34830, ""}, // Even offset that does not align to a nibble
 		"Zero offset":    {testData, 16, 945, ""},   // Zero offset
 		"Odd offset":     {testData, 19, 7560, ""},  // Odd offset
@@ -255,3 +256,92 @@ func TestReadUInt16(t *testing.T) {
 		})
 	}
 }
+
+func TestLargeBitStream(t *testing.T) {
+	// Positions past 65535 bits, the end of the first 8 KB, used to wrap around to the start.
+	data := make([]byte, 10000)
+	data[9000] = 0xab
+	data[9001] = 0xcd
+	bs := NewBitStream(data)
+	bs.SetPosition(9000 * 8)
+
+	i, err := bs.ReadUInt16()
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(0xabcd), i)
+	assert.Equal(t, uint64(9002*8), bs.GetPosition())
+	assert.Equal(t, uint64(10000), bs.Len())
+
+	bs.SetPosition(10000*8 - 4)
+	_, err = bs.ReadByte8()
+	assert.EqualError(t, err, "expected 8 bits to start at bit 79996, but the byte array was only 10000 bytes long")
+}
+
+func TestLargeBitStreamWrite(t *testing.T) {
+	bs := NewBitStreamForWrite()
+	for i := 0; i < 9000; i++ {
+		bs.WriteByte8(0)
+	}
+	bs.WriteUInt12(0xabc)
+
+	assert.Equal(t, uint64(9000*8+12), bs.GetPosition())
+	bs.SetPosition(9000 * 8)
+	i, err := bs.ReadUInt12()
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(0xabc), i)
+}
+
+func TestReadBits(t *testing.T) {
+	testSet := map[string]struct {
+		offset uint64
+		n      int
+		value  uint64
+		err    string
+	}{
+		"1 bit":                {5, 1, 1, ""},
+		"12 bits across bytes": {4, 12, 0x4a2, ""},
+		"18 bits":              {6, 18, 0xa203, ""},
+		"36 bits":              {4, 36, 0x4a203b100, ""},
+		"all 48 bits":          {0, 48, 0x04a203b1002b, ""},
+		"out of bounds":        {40, 12, 0, "expected 12 bits to start at bit 40, but the byte array was only 6 bytes long"},
+		"zero width":           {0, 0, 0, "invalid width of 0 bits, should be 1 to 64: invalid bit width"},
+		"too wide":             {0, 65, 0, "invalid width of 65 bits, should be 1 to 64: invalid bit width"},
+	}
+
+	for name, test := range testSet {
+		t.Run(name, func(t *testing.T) {
+			bs := BitStream{b: testData, p: test.offset}
+			v, err := bs.ReadBits(test.n)
+			if test.err == "" {
+				assert.Nil(t, err)
+				assert.Equal(t, test.value, v)
+				assert.Equal(t, test.offset+uint64(test.n), bs.p)
+			} else {
+				assert.EqualError(t, err, test.err)
+				assert.Equal(t, test.offset, bs.p)
+			}
+		})
+	}
+}
+
+func TestHasNonZeroRemainder(t *testing.T) {
+	testSet := map[string]struct {
+		data     []byte
+		position uint64
+		nonZero  bool
+	}{
+		"zero remainder":        {[]byte{0xf0, 0x00}, 4, false},
+		"set bit in same byte":  {[]byte{0xf1, 0x00}, 4, true},
+		"set bit in later byte": {[]byte{0xf0, 0x01}, 4, true},
+		"set bit at position":   {[]byte{0xf8, 0x00}, 4, true},
+		"at the end":            {[]byte{0xff}, 8, false},
+		"past the end":          {[]byte{0xff}, 20, false},
+	}
+
+	for name, test := range testSet {
+		t.Run(name, func(t *testing.T) {
+			bs := BitStream{b: test.data, p: test.position}
+			assert.Equal(t, test.nonZero, bs.HasNonZeroRemainder())
+			assert.Equal(t, test.position, bs.p)
+		})
+	}
+}
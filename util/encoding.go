@@ -2,12 +2,8 @@ package util
 
 import (
 	"encoding/base64"
-	"errors"
-)
-
-var (
-	fibEncodeNumOutOfRangeErr = errors.New("the number to be encoded is out of range")
-	fibEncodeInvalidRange     = errors.New("the range is invalid")
+	"encoding/binary"
+	"fmt"
 )
 
 func getByteSlice() []byte {
@@ -22,8 +18,8 @@ func NewBitStreamForWrite() *BitStream {
 // enlarge the underlying byte slice.
 // This function assumes bs.p always points to the end of the stream.
 // Do NOT attempt to modify the bs.p while applying this method.
-func (bs *BitStream) enlarge(n uint16) {
-	final := int(bs.p+n+7) / 8
+func (bs *BitStream) enlarge(n uint64) {
+	final := int((bs.p + n + 7) / 8)
 	// Under most circumstances, the value of final will hit this if condition.
 	if final <= cap(bs.b) {
 		bs.b = bs.b[:final]
@@ -49,12 +45,12 @@ func (bs *BitStream) enlarge(n uint16) {
 }
 
 // appendNBits appends n bits in b from left to right to the BitStream.
-func (bs *BitStream) appendNBits(b []byte, n uint16) {
+func (bs *BitStream) appendNBits(b []byte, n uint64) {
 	bs.enlarge(n)
 	var (
-		i         uint16
-		byteIndex uint16
-		offset    uint16
+		i         uint64
+		byteIndex uint64
+		offset    uint64
 	)
 	for i = 0; i < n; i++ {
 		byteIndex = bs.p / 8
@@ -77,45 +73,62 @@ func (bs *BitStream) Reset() {
 	bs.p = 0
 }
 
+// WriteBits writes the n bits of v into the BitStream, most significant bit first. n must be 1 to 64 and
+// v must fit in n bits.
+func (bs *BitStream) WriteBits(v uint64, n int) error {
+	if n < 1 || n > 64 {
+		return fmt.Errorf("invalid width of %d bits, should be 1 to 64: %w", n, ErrInvalidWidth)
+	}
+	if n < 64 && v>>uint(n) != 0 {
+		return fmt.Errorf("value %d does not fit in %d bits: %w", v, n, ErrValueOutOfRange)
+	}
+	bs.writeBits(v, n)
+	return nil
+}
+
+// writeBits writes the rightmost n bits of v into the BitStream, dropping any higher bits.
+func (bs *BitStream) writeBits(v uint64, n int) {
+	v <<= uint(64 - n)
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	bs.appendNBits(b, uint64(n))
+}
+
 // WriteByte1 writes the rightmost bit in b into the BitStream.
 func (bs *BitStream) WriteByte1(b byte) {
-	bs.appendNBits([]byte{b << 7}, 1)
+	bs.writeBits(uint64(b), 1)
 }
 
 // WriteByte2 writes the rightmost 2 bits in b into the BitStream.
 func (bs *BitStream) WriteByte2(b byte) {
-	bs.appendNBits([]byte{b << 6}, 2)
+	bs.writeBits(uint64(b), 2)
 }
 
 // WriteByte4 writes the rightmost 4 bits in b into the BitStream.
 func (bs *BitStream) WriteByte4(b byte) {
-	bs.appendNBits([]byte{b << 4}, 4)
+	bs.writeBits(uint64(b), 4)
 }
 
 // WriteByte6 writes the rightmost 6 bits in b into the BitStream.
 func (bs *BitStream) WriteByte6(b byte) {
-	bs.appendNBits([]byte{b << 2}, 6)
+	bs.writeBits(uint64(b), 6)
 }
 
 // WriteByte8 writes a full byte b into the BitStream.
 func (bs *BitStream) WriteByte8(b byte) {
-	bs.appendNBits([]byte{b}, 8)
+	bs.writeBits(uint64(b), 8)
 }
 
 // WriteUInt12 writes the rightmost 12 bits in b into the BitStream.
 // For instance, the input b is 0xff01, the effective part refers to the rightmost 12 bits,
 // which should be 0xf01.
 func (bs *BitStream) WriteUInt12(b uint16) {
-	first := byte(b >> 4)
-	second := byte(b << 4)
-	bs.appendNBits([]byte{first, second}, 12)
+	bs.writeBits(uint64(b), 12)
 }
 
 // WriteUInt16 writes a full uint16 (two bytes) into the BitStream.
 func (bs *BitStream) WriteUInt16(b uint16) {
-	first := byte(b >> 8)
-	second := byte(b)
-	bs.appendNBits([]byte{first, second}, 16)
+	bs.writeBits(uint64(b), 16)
 }
 
 // WriteTwoBitField encapsulates WriteByte2 to get convenience to some extent, when encoding GPP strings.
@@ -154,49 +167,34 @@ place an additional 1 in the end, since 11 could be an end-of-sequence indicator
 that a sequence of numbers can be encoded into a sequence of bits without the need to know the length of the
 bit sequences in advance.
 */
-func (bs *BitStream) WriteFibonacciInt(num uint16) error {
-	// The num should be [1,6765). Actually once the num is larger than or equal to 987,
-	// the efficiency of Fibonacci Encoding would be no better than 'WriteUint16'.
-	if num <= 0 || num >= fibLookup[fibLen-1]+fibLookup[fibLen-2] {
-		return fibEncodeNumOutOfRangeErr
+func (bs *BitStream) WriteFibonacciInt(num uint32) error {
+	if num == 0 {
+		return fmt.Errorf("0 cannot be written as an Integer(Fibonacci): %w", ErrValueOutOfRange)
 	}
-	// Binary Search to find the largest fibonacci number less than or equal to num.
-	lo, hi := 2, fibLen-1
-	for lo < hi {
-		mid := (lo + hi) / 2
-		// Since lo < hi and hi is at most equal to fibLen-1,
-		// the largest lo+hi is (fibLen-2 + fibLen-1),
-		// the largest mid should be fibLen-2 ((2*fibLen-3)/2 => [2*(fibLen-1)-1]/2 => fibLen-2),
-		// the mid+1 will never be larger than fibLen-1 and an index of mid+1 is safe here.
-		if num >= fibLookup[mid] && num < fibLookup[mid+1] {
-			lo = mid
-			break
-		}
-		if num < fibLookup[mid] {
-			hi = mid - 1
-		} else {
-			lo = mid + 1
-		}
+	// Find the largest fibonacci number less than or equal to num. As fibLookup ends with a number above
+	// math.MaxUint32, there always is one before the end.
+	largest := 2
+	for fibLookup[largest+1] <= uint64(num) {
+		largest++
 	}
-	// Calculate the fibonacci-encoded sequence.
-	var fibEncoded uint32 = 1
-	offset := 1
-	for i := lo; i >= 2; i-- {
-		if num >= fibLookup[i] {
-			num -= fibLookup[i]
-			fibEncoded |= 1 << offset
+	// Calculate the fibonacci-encoded sequence, from F(2) up to F(largest), followed by the final 1.
+	var fibEncoded uint64
+	remainder := uint64(num)
+	for i := largest; i >= 2; i-- {
+		if remainder >= fibLookup[i] {
+			remainder -= fibLookup[i]
+			fibEncoded |= 1 << uint(i-2)
 		}
-		offset++
 	}
-	encodedLength := lo
-	fibEncoded <<= 32 - encodedLength
-
-	bs.appendNBits([]byte{
-		byte(fibEncoded >> 24),
-		byte(fibEncoded >> 16),
-		byte(fibEncoded >> 8),
-		byte(fibEncoded),
-	}, uint16(encodedLength))
+	encodedLength := largest
+	fibEncoded |= 1 << uint(encodedLength-1)
+
+	// The bit for F(2) is the leftmost, so the code word is written reversed.
+	var reversed uint64
+	for i := 0; i < encodedLength; i++ {
+		reversed = reversed<<1 | (fibEncoded>>uint(i))&1
+	}
+	bs.writeBits(reversed, encodedLength)
 	return nil
 }
 
@@ -210,32 +208,47 @@ For example, an IntRange consisting of [1, 1], [3, 4] would be encoded into 0000
 There are two ranges, so the size is encoded into 000000000010. The first range [1, 1] refers to a single integer 1,
 so the indicator is 0, followed by Fibonacci encoded 1, while the next range [3, 4] refers to a range containing
 not only one integer, so the indicator is 1, followed by Fibonacci encoded 3 and 1 (4-3).
+
+The ranges must ascend without overlapping, otherwise an error wrapping ErrInvalidRange is returned and nothing
+is written.
 */
 func (bs *BitStream) WriteIntRange(intRange *IntRange) error {
-	var err error
+	if err := intRange.validate(); err != nil {
+		return err
+	}
 	bs.WriteUInt12(intRange.Size)
-	// Assume that the ranges are ordered.
 	var prevID uint16
 	for _, r := range intRange.Range {
-		if r.EndID < r.StartID || prevID >= r.StartID {
-			return fibEncodeInvalidRange
-		}
 		if r.StartID == r.EndID {
 			bs.WriteByte1(0)
-			err = bs.WriteFibonacciInt(r.StartID - prevID)
-			if err != nil {
-				return err
-			}
+			_ = bs.WriteFibonacciInt(uint32(r.StartID - prevID))
 		} else {
 			bs.WriteByte1(1)
-			err = bs.WriteFibonacciInt(r.StartID - prevID)
-			if err != nil {
-				return err
-			}
-			err = bs.WriteFibonacciInt(r.EndID - r.StartID)
-			if err != nil {
-				return err
-			}
+			_ = bs.WriteFibonacciInt(uint32(r.StartID - prevID))
+			_ = bs.WriteFibonacciInt(uint32(r.EndID - r.StartID))
+		}
+		prevID = r.EndID
+	}
+	return nil
+}
+
+// validate returns an error wrapping ErrInvalidRange unless the ranges ascend without overlapping, start
+// from ID 1 and number Size, which is what a Range(Fibonacci) can hold. Every offset is then at least 1,
+// so none can fail to be written.
+func (intRange *IntRange) validate() error {
+	if int(intRange.Size) != len(intRange.Range) {
+		return fmt.Errorf("size %d does not match the %d entries: %w", intRange.Size, len(intRange.Range), ErrInvalidRange)
+	}
+	if len(intRange.Range) > 1<<12-1 {
+		return fmt.Errorf("%d entries do not fit in 12 bits: %w", len(intRange.Range), ErrInvalidRange)
+	}
+	var prevID uint16
+	for i, r := range intRange.Range {
+		if r.EndID < r.StartID {
+			return fmt.Errorf("entry(%d) ends at %d before it starts at %d: %w", i, r.EndID, r.StartID, ErrInvalidRange)
+		}
+		if r.StartID <= prevID {
+			return fmt.Errorf("entry(%d) starts at %d, should be above %d: %w", i, r.StartID, prevID, ErrInvalidRange)
 		}
 		prevID = r.EndID
 	}
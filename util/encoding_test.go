@@ -1,8 +1,10 @@
 package util
 
 import (
-	"github.com/stretchr/testify/assert"
+	"errors"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func prepareNBits(data []byte, pointer, n int) (dataToWrite uint16) {
@@ -18,7 +20,7 @@ func TestEnlarge(t *testing.T) {
 	testCases := []struct {
 		name   string
 		bytes  []byte
-		n      uint16
+		n      uint64
 		expect int
 	}{
 		{
@@ -68,7 +70,7 @@ func TestEnlarge(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			bs := NewBitStream(tc.bytes)
-			bs.p = uint16(len(tc.bytes)) * 8
+			bs.p = uint64(len(tc.bytes)) * 8
 			bs.enlarge(tc.n * 8)
 			assert.Equal(t, tc.expect, cap(bs.b))
 		})
@@ -384,7 +386,7 @@ func TestArbitraryWrite(t *testing.T) {
 func TestWriteFibonacciInt(t *testing.T) {
 	var fibWriteTestData = []*struct {
 		name   string
-		values []uint16
+		values []uint32
 		result []byte
 		err    error
 	}{
@@ -395,13 +397,15 @@ func TestWriteFibonacciInt(t *testing.T) {
 		// 7 = 01011
 		// 12 = 101011
 		// 6764 = 0101 0101 0101 0101 011
-		{"write_int_1", []uint16{1}, []byte{0xc0}, nil},
-		{"write_int_2", []uint16{2}, []byte{0x60}, nil},
-		{"write_int_4", []uint16{4}, []byte{0xb0}, nil},
-		{"write_int_5", []uint16{5}, []byte{0x18}, nil},
-		{"write_int_7_12", []uint16{7, 12}, []byte{0x5d, 0x60}, nil},
-		{"out_of_range", []uint16{0, 6765}, nil, fibEncodeNumOutOfRangeErr},
-		{"write_int_6764", []uint16{6764}, []byte{0x55, 0x55, 0x60}, nil},
+		// 6765 = 0000 0000 0000 0000 0011
+		{"write_int_1", []uint32{1}, []byte{0xc0}, nil},
+		{"write_int_2", []uint32{2}, []byte{0x60}, nil},
+		{"write_int_4", []uint32{4}, []byte{0xb0}, nil},
+		{"write_int_5", []uint32{5}, []byte{0x18}, nil},
+		{"write_int_7_12", []uint32{7, 12}, []byte{0x5d, 0x60}, nil},
+		{"out_of_range", []uint32{0}, nil, ErrValueOutOfRange},
+		{"write_int_6764", []uint32{6764}, []byte{0x55, 0x55, 0x60}, nil},
+		{"write_int_6765", []uint32{6765}, []byte{0x00, 0x00, 0x30}, nil},
 	}
 
 	for _, test := range fibWriteTestData {
@@ -410,7 +414,11 @@ func TestWriteFibonacciInt(t *testing.T) {
 			var err error
 			for _, v := range test.values {
 				err = bs.WriteFibonacciInt(v)
-				assert.Equal(t, test.err, err)
+				if test.err == nil {
+					assert.Nil(t, err)
+				} else {
+					assert.True(t, errors.Is(err, test.err))
+				}
 			}
 			assert.Equal(t, test.result, bs.b)
 		})
@@ -439,22 +447,26 @@ func TestWriteIntRange(t *testing.T) {
 		{
 			"write_invalid_range_1",
 			[]IRange{{2, 1}, {4, 4}, {6, 9}},
-			[]byte{0b00000000, 0b00110000}, // Only the size will be successfully written
-			fibEncodeInvalidRange,
+			nil, // Nothing is written for an invalid range
+			ErrInvalidRange,
 		},
 		{
 			"write_invalid_range_2",
 			[]IRange{{2, 2}, {2, 4}, {6, 9}},
-			// 0000 0000 0011 for size, 0 for single-int-range indicator and 011 for fibonacci encoded 2
-			[]byte{0b00000000, 0b00110011},
-			fibEncodeInvalidRange,
+			nil,
+			ErrInvalidRange,
 		},
 		{
-			"write_out_of_range",
-			[]IRange{{2, 5}, {6, 8}, {13, 16}, {28, 9999}},
-			// error encountered when try writing the last range
-			[]byte{0b00000000, 0b01001011, 0b00111110, 0b11100011, 0b00111101, 0b01100000},
-			fibEncodeNumOutOfRangeErr,
+			"write_overlapping_range",
+			[]IRange{{2, 5}, {6, 8}, {7, 16}},
+			nil,
+			ErrInvalidRange,
+		},
+		{
+			"write_zero_id",
+			[]IRange{{0, 5}},
+			nil,
+			ErrInvalidRange,
 		},
 	}
 
@@ -464,7 +476,57 @@ func TestWriteIntRange(t *testing.T) {
 			intRange := IntRange{Range: tc.r, Size: uint16(len(tc.r))}
 			err := bs.WriteIntRange(&intRange)
 			assert.Equal(t, tc.result, bs.b)
-			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				assert.Nil(t, err)
+			} else {
+				assert.True(t, errors.Is(err, tc.err))
+			}
 		})
 	}
 }
+
+func TestWriteIntRangeLargeOffsets(t *testing.T) {
+	intRange := &IntRange{Size: 3, Range: []IRange{{2, 5}, {28, 9999}, {65535, 65535}}, Max: 65535}
+
+	bs := NewBitStreamForWrite()
+	assert.Nil(t, bs.WriteIntRange(intRange))
+
+	bs.SetPosition(0)
+	result, err := bs.ReadFibonacciRange()
+	assert.Nil(t, err)
+	assert.Equal(t, intRange, result)
+}
+
+func TestWriteIntRangeSizeMismatch(t *testing.T) {
+	bs := NewBitStreamForWrite()
+	err := bs.WriteIntRange(&IntRange{Size: 2, Range: []IRange{{1, 1}}})
+
+	assert.True(t, errors.Is(err, ErrInvalidRange))
+	assert.EqualError(t, err, "size 2 does not match the 1 entries: the range is invalid")
+	assert.Equal(t, uint64(0), bs.GetPosition())
+}
+
+func TestWriteBits(t *testing.T) {
+	bs := NewBitStreamForWrite()
+	assert.Nil(t, bs.WriteBits(0x4, 4))
+	assert.Nil(t, bs.WriteBits(0x4a203b100, 36))
+	assert.Nil(t, bs.WriteBits(0x2b, 8))
+	assert.Equal(t, []byte{0x44, 0xa2, 0x03, 0xb1, 0x00, 0x2b}, bs.b)
+
+	bs = NewBitStreamForWrite()
+	assert.Nil(t, bs.WriteBits(0xfedcba9876543210, 64))
+	assert.Nil(t, bs.WriteBits(1, 1))
+	assert.Equal(t, []byte{0xfe, 0xdc, 0xba, 0x98, 0x76, 0x54, 0x32, 0x10, 0x80}, bs.b)
+	bs.SetPosition(0)
+	v, err := bs.ReadBits(64)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0xfedcba9876543210), v)
+
+	bs = NewBitStreamForWrite()
+	err = bs.WriteBits(8, 3)
+	assert.True(t, errors.Is(err, ErrValueOutOfRange))
+	assert.EqualError(t, err, "value 8 does not fit in 3 bits: value out of range")
+	assert.True(t, errors.Is(bs.WriteBits(0, 0), ErrInvalidWidth))
+	assert.True(t, errors.Is(bs.WriteBits(0, 65), ErrInvalidWidth))
+	assert.Equal(t, uint64(0), bs.GetPosition())
+}
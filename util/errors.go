@@ -0,0 +1,63 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrUnexpectedEOF is matched by errors returned when a read runs past the end of a BitStream.
+	ErrUnexpectedEOF = errors.New("unexpected end of bit stream")
+	// ErrInvalidBase64 is matched by errors returned when a string is not valid base64-url.
+	ErrInvalidBase64 = errors.New("invalid base64 encoding")
+	// ErrPositionOverflow is matched by errors returned when a read would end past the largest bit position.
+	ErrPositionOverflow = errors.New("bit position overflow")
+	// ErrInvalidWidth is matched by errors returned for a read or write of fewer than 1 or more than 64 bits.
+	ErrInvalidWidth = errors.New("invalid bit width")
+	// ErrValueOutOfRange is matched by errors returned when a value does not fit in the bits written, or
+	// an Integer(Fibonacci) or ID read does not fit in its type.
+	ErrValueOutOfRange = errors.New("value out of range")
+	// ErrInvalidCharacter is matched by errors returned for a String(6-bit letter) character which is not a
+	// letter from A to Z.
+	ErrInvalidCharacter = errors.New("invalid character")
+	// ErrInvalidRange is matched by errors returned for a Range(Fibonacci) to write whose entries descend or
+	// overlap.
+	ErrInvalidRange = errors.New("the range is invalid")
+)
+
+// ReadError reports a read past the end of a BitStream. BitOffset is the position, relative to the start
+// of the stream, at which the failed read started. It matches ErrUnexpectedEOF with errors.Is.
+type ReadError struct {
+	BitOffset uint64
+	msg       string
+}
+
+func newReadError(bitOffset uint64, format string, a ...interface{}) *ReadError {
+	return &ReadError{BitOffset: bitOffset, msg: fmt.Sprintf(format, a...)}
+}
+
+func (e *ReadError) Error() string {
+	return e.msg
+}
+
+func (e *ReadError) Unwrap() error {
+	return ErrUnexpectedEOF
+}
+
+// Base64Error reports a string which could not be base64-url decoded. It matches ErrInvalidBase64 with
+// errors.Is, and unwraps to the error returned by the encoding/base64 package.
+type Base64Error struct {
+	Err error
+}
+
+func (e *Base64Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Base64Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Base64Error) Is(target error) bool {
+	return target == ErrInvalidBase64
+}
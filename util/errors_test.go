@@ -0,0 +1,62 @@
+package util
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadError(t *testing.T) {
+	bs := NewBitStream([]byte{0x04, 0xa2})
+	bs.SetPosition(12)
+
+	_, err := bs.ReadUInt12()
+
+	var readErr *ReadError
+	assert.True(t, errors.As(err, &readErr))
+	assert.Equal(t, uint64(12), readErr.BitOffset)
+	assert.True(t, errors.Is(err, ErrUnexpectedEOF))
+	assert.EqualError(t, err, "expected 12 bits to start at bit 12, but the byte array was only 2 bytes long")
+}
+
+func TestReadErrorWrapped(t *testing.T) {
+	bs := NewBitStream([]byte{0x00})
+
+	_, err := bs.ReadFibonacciRange()
+
+	var readErr *ReadError
+	assert.True(t, errors.As(err, &readErr))
+	assert.True(t, errors.Is(err, ErrUnexpectedEOF))
+}
+
+func TestBase64Error(t *testing.T) {
+	_, err := NewBitStreamFromBase64("AB*D")
+
+	assert.True(t, errors.Is(err, ErrInvalidBase64))
+	assert.False(t, errors.Is(err, ErrUnexpectedEOF))
+	assert.EqualError(t, err, "illegal base64 data at input byte 2")
+}
+
+func TestPositionOverflow(t *testing.T) {
+	bs := NewBitStream([]byte{0x04, 0xa2})
+	bs.SetPosition(math.MaxUint64 - 7)
+
+	_, err := bs.ReadUInt16()
+
+	assert.True(t, errors.Is(err, ErrPositionOverflow))
+	assert.False(t, errors.Is(err, ErrUnexpectedEOF))
+	assert.Equal(t, uint64(math.MaxUint64-7), bs.GetPosition())
+}
+
+func TestReadNearLargestPosition(t *testing.T) {
+	bs := NewBitStream([]byte{0x04, 0xa2})
+	bs.SetPosition(math.MaxUint64 - 10)
+
+	_, err := bs.ReadBits(5)
+
+	assert.True(t, errors.Is(err, ErrUnexpectedEOF))
+	assert.False(t, errors.Is(err, ErrPositionOverflow))
+	assert.Equal(t, uint64(math.MaxUint64-10), bs.GetPosition())
+}
@@ -1,97 +1,102 @@
 package util
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
-// Preload the smaller, more common, fibonacci values to speed up lookups.
-var fibLookup = [fibLen]uint16{0, 1, 1, 2, 3, 5, 8, 13, 21, 34, 55, 89, 144, 233, 377, 610, 987, 1597, 2584, 4181}
+// fibLookup holds the Fibonacci numbers up to the first one above math.MaxUint32, computed once so reads
+// and writes of an Integer(Fibonacci) only ever look numbers up.
+var fibLookup = fibonacciNumbers()
 
-const fibLen int = 20
-
-// fibonacci returns the ith fibonacci number.
-func fibonacci(i int) uint16 {
-	if i < fibLen {
-		return fibLookup[i]
+// fibonacciNumbers returns the Fibonacci numbers from F(0) up to the first one above math.MaxUint32.
+func fibonacciNumbers() []uint64 {
+	fib := []uint64{0, 1}
+	for fib[len(fib)-1] <= math.MaxUint32 {
+		fib = append(fib, fib[len(fib)-1]+fib[len(fib)-2])
 	}
-	return fibonacci(i-2) + fibonacci(i-1)
+	return fib
 }
 
 // ReadFibonacciInt parses 1 fibonacci encoded int from the data array, starting at the given index
-// Note that 0 cannot be fibonacci encoded, and thus will only be seen if there is an error.
-func (bs *BitStream) ReadFibonacciInt() (uint16, error) {
-
-	lastBit, err := bs.ReadByte1()
-	if err != nil {
-		return 0, fmt.Errorf("error reading bit 1 of Integer(Fibonacci): %s", err)
-	}
-	nextBit, err := bs.ReadByte1()
-	if err != nil {
-		return 0, fmt.Errorf("error reading bit 2 of Integer(Fibonacci): %s", err)
-	}
-
-	// First bit encodes "1" if set. (fib(2)=1)
-	result := uint16(lastBit)
+// Note that 0 cannot be fibonacci encoded, and thus will only be seen if there is an error. An error
+// wrapping ErrValueOutOfRange is returned for an int above math.MaxUint32.
+func (bs *BitStream) ReadFibonacciInt() (uint32, error) {
+	var result uint64
+	var lastBit byte
 
-	// At the start of each loop, lastBit has been processed, nextBit has not. if both lastBit and
-	// nextBit are set, we discard nextBit and return the result
-	for i := 3; (lastBit == 0) || (nextBit == 0); i++ {
-		lastBit = nextBit
-		nextBit, err = bs.ReadByte1()
+	// The bit read at index i counts for F(i), starting from F(2)=1. Two set bits in a row end the int, and
+	// the second of them is not part of the value.
+	for i := 2; ; i++ {
+		bit, err := bs.ReadByte1()
 		if err != nil {
-			return 0, fmt.Errorf("error reading bit %d of Integer(Fibonacci): %s", i, err)
+			return 0, fmt.Errorf("error reading bit %d of Integer(Fibonacci): %w", i-1, err)
 		}
-		if lastBit == 1 {
-			result = result + fibonacci(i)
+		if bit == 1 && lastBit == 1 {
+			return uint32(result), nil
 		}
-
+		if bit == 1 {
+			if i >= len(fibLookup) || result+fibLookup[i] > math.MaxUint32 {
+				return 0, fmt.Errorf("error reading bit %d of Integer(Fibonacci): the int is larger than %d: %w", i-1, uint32(math.MaxUint32), ErrValueOutOfRange)
+			}
+			result += fibLookup[i]
+		}
+		lastBit = bit
 	}
-
-	return result, nil
 }
 
-// ReadFibonacciRange
+// ReadFibonacciRange parses a Range(Fibonacci). Each entry is an offset from the largest ID before it, so
+// the IDs always ascend; an error wrapping ErrValueOutOfRange is returned for an entry which would end above
+// the largest ID, 65535, rather than wrapping around.
 func (bs *BitStream) ReadFibonacciRange() (*IntRange, error) {
 	numEntries, err := bs.ReadUInt12()
 	if err != nil {
-		return nil, fmt.Errorf("error reading size of Range(Fibonacci): %s", err)
+		return nil, fmt.Errorf("error reading size of Range(Fibonacci): %w", err)
 	}
 	var maxValue uint16
-	var offset uint16
 
 	ranges := make([]IRange, numEntries)
 	for i := range ranges {
 		bit, err := bs.ReadByte1()
 		if err != nil {
-			return nil, fmt.Errorf("error reading the boolean bit of a Range(Fibonacci) entry(%d): %s", i, err)
+			return nil, fmt.Errorf("error reading the boolean bit of a Range(Fibonacci) entry(%d): %w", i, err)
 		}
-		if bit == 0 {
-			offset, err := bs.ReadFibonacciInt()
-			if err != nil {
-				return nil, fmt.Errorf("error reading an int offset value in a Range(Fibonacci) entry(%d): %s", i, err)
-			}
-			entry := offset + maxValue
-			ranges[i].StartID = entry
-			ranges[i].EndID = entry
-			if entry > maxValue {
-				maxValue = entry
-			}
-		} else {
-			// first entry is an offset from the previous entry
-			offset, err = bs.ReadFibonacciInt()
-			ranges[i].StartID = maxValue + offset
-			if err != nil {
-				return nil, fmt.Errorf("error reading first int offset value in a Range(Fibonacci) entry(%d): %s", i, err)
+		// The first, or only, int of an entry is an offset from the previous entry.
+		offset, err := bs.ReadFibonacciInt()
+		if err != nil {
+			if bit == 0 {
+				return nil, fmt.Errorf("error reading an int offset value in a Range(Fibonacci) entry(%d): %w", i, err)
 			}
+			return nil, fmt.Errorf("error reading first int offset value in a Range(Fibonacci) entry(%d): %w", i, err)
+		}
+		start, err := addID(maxValue, offset, i)
+		if err != nil {
+			return nil, err
+		}
+		ranges[i].StartID = start
+		ranges[i].EndID = start
+
+		if bit == 1 {
 			// Second entry in a Fibonacci range is an offset from the first.
 			offset, err = bs.ReadFibonacciInt()
 			if err != nil {
-				return nil, fmt.Errorf("error reading second int offset value in a Range(Fibonacci) entry(%d): %s", i, err)
+				return nil, fmt.Errorf("error reading second int offset value in a Range(Fibonacci) entry(%d): %w", i, err)
 			}
-			ranges[i].EndID = ranges[i].StartID + offset
-			if ranges[i].EndID > maxValue {
-				maxValue = ranges[i].EndID
+			if ranges[i].EndID, err = addID(start, offset, i); err != nil {
+				return nil, err
 			}
 		}
+		maxValue = ranges[i].EndID
 	}
 
 	return &IntRange{Size: numEntries, Range: ranges, Max: maxValue}, nil
 }
+
+// addID returns id plus offset, or an error if that is above the largest ID.
+func addID(id uint16, offset uint32, entry int) (uint16, error) {
+	sum := uint64(id) + uint64(offset)
+	if sum > math.MaxUint16 {
+		return 0, fmt.Errorf("Range(Fibonacci) entry(%d) reaches ID %d, above %d: %w", entry, sum, math.MaxUint16, ErrValueOutOfRange)
+	}
+	return uint16(sum), nil
+}
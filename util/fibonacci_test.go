@@ -1,6 +1,8 @@
 package util
 
 import (
+	"errors"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -32,7 +34,7 @@ var fibtestData = []testDefinition{
 func TestReadFibonnaciInt(t *testing.T) {
 	bs := BitStream{b: testData, p: 47}
 	i, err := bs.ReadFibonacciInt()
-	assert.Equal(t, "error reading bit 2 of Integer(Fibonacci): expected 1 bit at bit 48, but the byte array was only 6 bytes long", err.Error())
+	assert.Equal(t, "error reading bit 2 of Integer(Fibonacci): expected 1 bit to start at bit 48, but the byte array was only 6 bytes long", err.Error())
 
 	for _, test := range fibtestData {
 		bs = BitStream{b: test.data, p: test.offset}
@@ -68,3 +70,48 @@ func TestReadFibonacciRange(t *testing.T) {
 	}}
 	assert.Equal(t, expected, ir)
 }
+
+func TestFibonacciIntRoundTrip(t *testing.T) {
+	for _, v := range []uint32{1, 2, 3, 6765, 57152, 1 << 20, 2971215073, math.MaxUint32 - 1, math.MaxUint32} {
+		bs := NewBitStreamForWrite()
+		bs.WriteByte2(1)
+		assert.Nil(t, bs.WriteFibonacciInt(v), "writing %d", v)
+		bs.WriteByte1(0)
+
+		bs.SetPosition(2)
+		i, err := bs.ReadFibonacciInt()
+		assert.Nil(t, err, "reading %d", v)
+		assert.Equal(t, v, i)
+	}
+}
+
+func TestReadFibonacciIntOverflow(t *testing.T) {
+	// Every other bit from F(3) set, so the int only ends after adding F(47) takes it above math.MaxUint32.
+	bs := NewBitStreamForWrite()
+	for i := 2; i <= 48; i++ {
+		bs.WriteByte1(byte(i % 2))
+	}
+	bs.WriteByte1(1)
+
+	bs.SetPosition(0)
+	_, err := bs.ReadFibonacciInt()
+
+	assert.True(t, errors.Is(err, ErrValueOutOfRange))
+	assert.EqualError(t, err, "error reading bit 46 of Integer(Fibonacci): the int is larger than 4294967295: value out of range")
+}
+
+func TestReadFibonacciRangeOverflow(t *testing.T) {
+	bs := NewBitStreamForWrite()
+	bs.WriteUInt12(2)
+	bs.WriteByte1(0)
+	assert.Nil(t, bs.WriteFibonacciInt(65000))
+	bs.WriteByte1(1)
+	assert.Nil(t, bs.WriteFibonacciInt(500))
+	assert.Nil(t, bs.WriteFibonacciInt(100))
+
+	bs.SetPosition(0)
+	_, err := bs.ReadFibonacciRange()
+
+	assert.True(t, errors.Is(err, ErrValueOutOfRange))
+	assert.EqualError(t, err, "Range(Fibonacci) entry(1) reaches ID 65600, above 65535: value out of range")
+}
@@ -0,0 +1,65 @@
+package util
+
+import (
+	"fmt"
+	"time"
+)
+
+// String6Letters is the number of letters a 6-bit character of a String(6-bit letter) field can hold, 'A'
+// as 0 up to 'Z' as 25.
+const String6Letters = 26
+
+// ReadDatetime reads a Datetime, which is encoded as 36 bits of deciseconds since the unix epoch.
+func (bs *BitStream) ReadDatetime() (time.Time, error) {
+	deciseconds, err := bs.ReadBits(36)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error reading Datetime: %w", err)
+	}
+	return time.Unix(int64(deciseconds/10), int64(deciseconds%10)*int64(100*time.Millisecond)).UTC(), nil
+}
+
+// WriteDatetime writes t as a Datetime, truncated to deciseconds. Only the rightmost 36 bits of the
+// deciseconds are written, so a time before the unix epoch or after 2187 does not read back the same.
+func (bs *BitStream) WriteDatetime(t time.Time) {
+	deciseconds := t.UnixNano() / int64(100*time.Millisecond)
+	bs.writeBits(uint64(deciseconds), 36)
+}
+
+// ReadString6 reads a String(6-bit letter) of numChars letters, each encoded in 6 bits with 'A' as 0 up to
+// 'Z' as 25. A character above 25 is not a letter, and an error wrapping ErrInvalidCharacter is returned.
+func (bs *BitStream) ReadString6(numChars int) (string, error) {
+	result := make([]byte, 0, numChars)
+
+	for i := 0; i < numChars; i++ {
+		b, err := bs.ReadByte6()
+		if err != nil {
+			return "", fmt.Errorf("error reading character %d of String: %w", i, err)
+		}
+		if b >= String6Letters {
+			return "", fmt.Errorf("error reading character %d of String: value %d, should be 0 to %d for A to Z: %w", i, b, String6Letters-1, ErrInvalidCharacter)
+		}
+		result = append(result, 'A'+b)
+	}
+
+	return string(result), nil
+}
+
+// WriteString6Unchecked writes s as a String(6-bit letter) without checking it. Only letters from A to Z
+// can be written; any other character is written as the rightmost 6 bits of its offset from 'A', so
+// callers which must not write such a character check s with ValidateString6 first.
+func (bs *BitStream) WriteString6Unchecked(s string) {
+	for i := 0; i < len(s); i++ {
+		bs.WriteByte6(s[i] - 'A')
+	}
+}
+
+// ValidateString6 returns an error wrapping ErrInvalidCharacter if s holds a character other than a
+// letter from A to Z.
+func ValidateString6(s string) error {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 'A' || s[i] > 'Z' {
+			return fmt.Errorf("character %q at %d, should be A to Z: %w", s[i], i, ErrInvalidCharacter)
+		}
+	}
+	return nil
+}
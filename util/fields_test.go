@@ -0,0 +1,73 @@
+package util
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatetime(t *testing.T) {
+	created := time.Date(2022, time.April, 20, 22, 0, 0, 300000000, time.UTC)
+
+	bs := NewBitStreamForWrite()
+	bs.WriteByte2(3)
+	bs.WriteDatetime(created)
+	assert.Equal(t, uint64(38), bs.GetPosition())
+
+	bs.SetPosition(2)
+	result, err := bs.ReadDatetime()
+	assert.Nil(t, err)
+	assert.Equal(t, created, result)
+
+	_, err = NewBitStream([]byte{0, 0, 0, 0}).ReadDatetime()
+	assert.True(t, errors.Is(err, ErrUnexpectedEOF))
+	assert.EqualError(t, err, "error reading Datetime: expected 36 bits to start at bit 0, but the byte array was only 4 bytes long")
+}
+
+func TestDatetimeTruncatesToDeciseconds(t *testing.T) {
+	bs := NewBitStreamForWrite()
+	bs.WriteDatetime(time.Date(2023, time.March, 30, 12, 0, 0, 123456789, time.UTC))
+
+	bs.SetPosition(0)
+	result, err := bs.ReadDatetime()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2023, time.March, 30, 12, 0, 0, 100000000, time.UTC), result)
+}
+
+func TestString6(t *testing.T) {
+	bs := NewBitStreamForWrite()
+	bs.WriteString6Unchecked("ENZ")
+	assert.Equal(t, []byte{0x10, 0xd6, 0x40}, bs.b)
+
+	bs.SetPosition(0)
+	result, err := bs.ReadString6(3)
+	assert.Nil(t, err)
+	assert.Equal(t, "ENZ", result)
+
+	_, err = bs.ReadString6(2)
+	assert.True(t, errors.Is(err, ErrUnexpectedEOF))
+}
+
+func TestReadString6InvalidCharacter(t *testing.T) {
+	bs := NewBitStreamForWrite()
+	bs.WriteByte6(4)
+	bs.WriteByte6(26)
+	bs.SetPosition(0)
+
+	result, err := bs.ReadString6(2)
+
+	assert.Empty(t, result)
+	assert.True(t, errors.Is(err, ErrInvalidCharacter))
+	assert.EqualError(t, err, "error reading character 1 of String: value 26, should be 0 to 25 for A to Z: invalid character")
+}
+
+func TestValidateString6(t *testing.T) {
+	assert.Nil(t, ValidateString6("ABCXYZ"))
+	assert.Nil(t, ValidateString6(""))
+
+	err := ValidateString6("Fr")
+	assert.True(t, errors.Is(err, ErrInvalidCharacter))
+	assert.EqualError(t, err, "character 'r' at 1, should be A to Z: invalid character")
+}
@@ -0,0 +1,112 @@
+package util
+
+import "sort"
+
+// IDSet is a set of IDs, such as the vendors of a vendor list, which are numbered from 1. It is read from
+// and written as a Bitfield, an OptimizedRange or an OptimizedIntRange. The zero value is an empty set.
+//
+// The set is held as runs of consecutive IDs, so a Range(Int) or Range(Fibonacci) of many long, overlapping
+// entries is read without listing their IDs one by one.
+type IDSet struct {
+	ranges []IRange // sorted, neither overlapping nor adjacent
+}
+
+// NewIDSet returns the set of the given IDs. Duplicates are removed and 0, which is not a valid ID, is
+// ignored.
+func NewIDSet(ids ...uint16) IDSet {
+	ranges := make([]IRange, 0, len(ids))
+	for _, id := range ids {
+		ranges = append(ranges, IRange{StartID: id, EndID: id})
+	}
+	return newIDSetFromRanges(ranges)
+}
+
+// newIDSetFromRanges returns the set of the IDs of the ranges, which may overlap and come in any order. A
+// range ending before it starts holds no IDs. The ranges are sorted in place.
+func newIDSetFromRanges(ranges []IRange) IDSet {
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].StartID < ranges[j].StartID })
+
+	var set IDSet
+	for _, r := range ranges {
+		if r.StartID == 0 {
+			r.StartID = 1
+		}
+		if r.EndID < r.StartID {
+			continue
+		}
+		if n := len(set.ranges); n > 0 && uint32(r.StartID) <= uint32(set.ranges[n-1].EndID)+1 {
+			if r.EndID > set.ranges[n-1].EndID {
+				set.ranges[n-1].EndID = r.EndID
+			}
+			continue
+		}
+		set.ranges = append(set.ranges, r)
+	}
+	return set
+}
+
+// add adds an ID greater than all those in the set.
+func (s *IDSet) add(id uint16) {
+	if n := len(s.ranges); n > 0 && id == s.ranges[n-1].EndID+1 {
+		s.ranges[n-1].EndID = id
+		return
+	}
+	s.ranges = append(s.ranges, IRange{StartID: id, EndID: id})
+}
+
+// Contains reports whether id is in the set.
+func (s IDSet) Contains(id uint16) bool {
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].EndID >= id })
+	return i < len(s.ranges) && s.ranges[i].StartID <= id
+}
+
+// Len returns the number of IDs in the set.
+func (s IDSet) Len() int {
+	n := 0
+	for _, r := range s.ranges {
+		n += int(r.EndID) - int(r.StartID) + 1
+	}
+	return n
+}
+
+// Max returns the largest ID in the set, or 0 if it is empty.
+func (s IDSet) Max() uint16 {
+	if len(s.ranges) == 0 {
+		return 0
+	}
+	return s.ranges[len(s.ranges)-1].EndID
+}
+
+// UpTo returns the IDs of the set which are not greater than maxID.
+func (s IDSet) UpTo(maxID uint16) IDSet {
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].EndID > maxID })
+	var upTo IDSet
+	upTo.ranges = append(upTo.ranges, s.ranges[:i]...)
+	if i < len(s.ranges) && s.ranges[i].StartID <= maxID {
+		upTo.ranges = append(upTo.ranges, IRange{StartID: s.ranges[i].StartID, EndID: maxID})
+	}
+	return upTo
+}
+
+// IDs returns the IDs in the set in ascending order.
+func (s IDSet) IDs() []uint16 {
+	ids := make([]uint16, 0, s.Len())
+	for _, r := range s.ranges {
+		for id := uint32(r.StartID); id <= uint32(r.EndID); id++ {
+			ids = append(ids, uint16(id))
+		}
+	}
+	return ids
+}
+
+// IntRange returns the set as an IntRange, with each run of consecutive IDs as a single entry.
+func (s IDSet) IntRange() *IntRange {
+	intRange := &IntRange{Range: append([]IRange{}, s.ranges...), Max: s.Max()}
+	intRange.Size = uint16(len(intRange.Range))
+	return intRange
+}
+
+// IDSet returns the IDs of all the entries of the range.
+func (ir *IntRange) IDSet() IDSet {
+	return newIDSetFromRanges(append([]IRange{}, ir.Range...))
+}
@@ -0,0 +1,82 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDSet(t *testing.T) {
+	set := NewIDSet(7, 3, 0, 4, 3, 5, 12)
+
+	assert.Equal(t, []uint16{3, 4, 5, 7, 12}, set.IDs())
+	assert.Equal(t, 5, set.Len())
+	assert.Equal(t, uint16(12), set.Max())
+	assert.True(t, set.Contains(4))
+	assert.True(t, set.Contains(12))
+	assert.False(t, set.Contains(6))
+	assert.False(t, set.Contains(0))
+	assert.False(t, set.Contains(13))
+
+	assert.Equal(t, &IntRange{Size: 3, Range: []IRange{{3, 5}, {7, 7}, {12, 12}}, Max: 12}, set.IntRange())
+	assert.Equal(t, set, set.IntRange().IDSet())
+}
+
+func TestIDSetEmpty(t *testing.T) {
+	var set IDSet
+
+	assert.Equal(t, 0, set.Len())
+	assert.Equal(t, uint16(0), set.Max())
+	assert.False(t, set.Contains(1))
+	assert.Empty(t, set.IDs())
+	assert.Equal(t, 0, NewIDSet(0).Len())
+	assert.Equal(t, &IntRange{Range: []IRange{}}, set.IntRange())
+}
+
+func TestIDSetIDsIsACopy(t *testing.T) {
+	set := NewIDSet(1, 2)
+
+	set.IDs()[0] = 9
+
+	assert.Equal(t, []uint16{1, 2}, set.IDs())
+}
+
+func TestIntRangeIDSetToMaxID(t *testing.T) {
+	intRange := &IntRange{Size: 1, Range: []IRange{{65534, 65535}}, Max: 65535}
+
+	assert.Equal(t, []uint16{65534, 65535}, intRange.IDSet().IDs())
+}
+
+func TestIntRangeIDSetMergesRanges(t *testing.T) {
+	intRange := &IntRange{Size: 5, Range: []IRange{{10, 20}, {1, 5}, {6, 9}, {15, 30}, {40, 39}}, Max: 40}
+
+	set := intRange.IDSet()
+
+	assert.Equal(t, 30, set.Len())
+	assert.Equal(t, &IntRange{Size: 1, Range: []IRange{{1, 30}}, Max: 30}, set.IntRange())
+	assert.True(t, set.Contains(30))
+	assert.False(t, set.Contains(31))
+}
+
+func TestIntRangeIDSetOverlappingRanges(t *testing.T) {
+	// Every entry covers all the IDs, which listed one by one would be hundreds of millions of IDs.
+	intRange := &IntRange{Size: 4095, Max: 65535}
+	for i := 0; i < 4095; i++ {
+		intRange.Range = append(intRange.Range, IRange{StartID: 1, EndID: 65535})
+	}
+
+	set := intRange.IDSet()
+
+	assert.Equal(t, 65535, set.Len())
+	assert.Equal(t, uint16(65535), set.Max())
+	assert.Equal(t, &IntRange{Size: 1, Range: []IRange{{1, 65535}}, Max: 65535}, set.IntRange())
+}
+
+func TestIDSetUpTo(t *testing.T) {
+	set := NewIDSet(1, 2, 3, 7, 8, 9, 20)
+
+	assert.Equal(t, NewIDSet(1, 2, 3, 7, 8), set.UpTo(8))
+	assert.Equal(t, NewIDSet(1, 2, 3), set.UpTo(6))
+	assert.Equal(t, set, set.UpTo(65535))
+	assert.Equal(t, IDSet{}, set.UpTo(0))
+}
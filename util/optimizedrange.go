@@ -0,0 +1,125 @@
+package util
+
+import "fmt"
+
+// ReadBitfield reads a Bitfield of numBits bits, where the bit at index i is set if ID i+1 is in the set.
+func (bs *BitStream) ReadBitfield(numBits int) (IDSet, error) {
+	var set IDSet
+	for i := 0; i < numBits; i++ {
+		b, err := bs.ReadByte1()
+		if err != nil {
+			return IDSet{}, fmt.Errorf("error reading bit %d of Bitfield: %w", i, err)
+		}
+		if b == 1 {
+			set.add(uint16(i + 1))
+		}
+	}
+	return set, nil
+}
+
+// WriteBitfield writes set as a Bitfield of numBits bits. An error wrapping ErrValueOutOfRange is returned,
+// and nothing written, if the set holds an ID above numBits.
+func (bs *BitStream) WriteBitfield(set IDSet, numBits int) error {
+	if int(set.Max()) > numBits {
+		return fmt.Errorf("ID %d does not fit in a Bitfield of %d bits: %w", set.Max(), numBits, ErrValueOutOfRange)
+	}
+	next := 0
+	for i := 1; i <= numBits; i++ {
+		if next < len(set.ranges) && int(set.ranges[next].EndID) < i {
+			next++
+		}
+		if next < len(set.ranges) && int(set.ranges[next].StartID) <= i {
+			bs.WriteByte1(1)
+		} else {
+			bs.WriteByte1(0)
+		}
+	}
+	return nil
+}
+
+// ReadOptimizedRange reads an OptimizedRange, the vendor list encoding of the TCF sections: a 16-bit maximum
+// ID and a flag choosing between a Bitfield of that many bits and a Range(Int).
+func (bs *BitStream) ReadOptimizedRange() (IDSet, error) {
+	return bs.readOptimized("OptimizedRange", bs.ReadIntRange)
+}
+
+// WriteOptimizedRange writes set as an OptimizedRange, as a Range(Int) if that takes fewer bits than a
+// Bitfield.
+func (bs *BitStream) WriteOptimizedRange(set IDSet) {
+	bs.writeOptimized(set, func(bs *BitStream, intRange *IntRange) error {
+		bs.WriteFixedIntRange(intRange)
+		return nil
+	})
+}
+
+// ReadOptimizedIntRange reads an OptimizedIntRange, which is an OptimizedRange with a Range(Fibonacci) in
+// place of the Range(Int).
+func (bs *BitStream) ReadOptimizedIntRange() (IDSet, error) {
+	return bs.readOptimized("OptimizedIntRange", bs.ReadFibonacciRange)
+}
+
+// WriteOptimizedIntRange writes set as an OptimizedIntRange, as a Range(Fibonacci) if that takes fewer bits
+// than a Bitfield.
+func (bs *BitStream) WriteOptimizedIntRange(set IDSet) {
+	bs.writeOptimized(set, func(bs *BitStream, intRange *IntRange) error {
+		return bs.WriteIntRange(intRange)
+	})
+}
+
+// ReadOptimizedRangeHeader reads the 16-bit maximum ID and the encoding flag which start an OptimizedRange,
+// for callers which keep the encoding the range was read with. isRange tells whether a Range(Int) follows
+// rather than a Bitfield of maxID bits.
+func (bs *BitStream) ReadOptimizedRangeHeader() (maxID uint16, isRange bool, err error) {
+	return bs.readOptimizedHeader("OptimizedRange")
+}
+
+// WriteOptimizedRangeHeader writes the maximum ID and the encoding flag which start an OptimizedRange.
+func (bs *BitStream) WriteOptimizedRangeHeader(maxID uint16, isRange bool) {
+	bs.WriteUInt16(maxID)
+	if isRange {
+		bs.WriteByte1(1)
+	} else {
+		bs.WriteByte1(0)
+	}
+}
+
+func (bs *BitStream) readOptimizedHeader(name string) (uint16, bool, error) {
+	maxID, err := bs.ReadUInt16()
+	if err != nil {
+		return 0, false, fmt.Errorf("error reading max ID of %s: %w", name, err)
+	}
+	isRange, err := bs.ReadByte1()
+	if err != nil {
+		return 0, false, fmt.Errorf("error reading encoding type of %s: %w", name, err)
+	}
+	return maxID, isRange == 1, nil
+}
+
+func (bs *BitStream) readOptimized(name string, readRange func() (*IntRange, error)) (IDSet, error) {
+	maxID, isRange, err := bs.readOptimizedHeader(name)
+	if err != nil {
+		return IDSet{}, err
+	}
+	if !isRange {
+		return bs.ReadBitfield(int(maxID))
+	}
+	intRange, err := readRange()
+	if err != nil {
+		return IDSet{}, err
+	}
+	return intRange.IDSet(), nil
+}
+
+// writeOptimized writes the maximum ID of set and then the set as either a Bitfield or the range written by
+// writeRange, whichever is smaller. A Bitfield is written if both take the same number of bits.
+func (bs *BitStream) writeOptimized(set IDSet, writeRange func(*BitStream, *IntRange) error) {
+	rangeBits := NewBitStreamForWrite()
+	if err := writeRange(rangeBits, set.IntRange()); err == nil && rangeBits.GetPosition() < uint64(set.Max()) {
+		bs.WriteOptimizedRangeHeader(set.Max(), true)
+		bs.appendNBits(rangeBits.b, rangeBits.GetPosition())
+		return
+	}
+	bs.WriteOptimizedRangeHeader(set.Max(), false)
+	// The Bitfield holds exactly the IDs up to the maximum, so it cannot fail.
+	_ = bs.WriteBitfield(set, int(set.Max()))
+}
@@ -0,0 +1,124 @@
+package util
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitfield(t *testing.T) {
+	bs := NewBitStreamForWrite()
+	assert.Nil(t, bs.WriteBitfield(NewIDSet(1, 3, 10), 10))
+	assert.Equal(t, []byte{0xa0, 0x40}, bs.b)
+
+	bs.SetPosition(0)
+	set, err := bs.ReadBitfield(10)
+	assert.Nil(t, err)
+	assert.Equal(t, NewIDSet(1, 3, 10), set)
+
+	_, err = bs.ReadBitfield(10)
+	assert.True(t, errors.Is(err, ErrUnexpectedEOF))
+
+	bs = NewBitStreamForWrite()
+	err = bs.WriteBitfield(NewIDSet(11), 10)
+	assert.True(t, errors.Is(err, ErrValueOutOfRange))
+	assert.Equal(t, uint64(0), bs.GetPosition())
+}
+
+func TestOptimizedRange(t *testing.T) {
+	testData := []struct {
+		description string
+		set         IDSet
+		isRange     bool
+		bits        uint64
+	}{
+		{
+			description: "should write an empty set as an empty bitfield",
+			set:         IDSet{},
+			bits:        17,
+		},
+		{
+			description: "should prefer a bitfield for dense sets",
+			set:         NewIDSet(1, 2, 4, 6, 7, 9),
+			bits:        17 + 9,
+		},
+		{
+			description: "should prefer a range for sparse sets",
+			set:         NewIDSet(5, 800),
+			isRange:     true,
+			bits:        17 + 12 + 2*17,
+		},
+		{
+			description: "should merge consecutive IDs into a range entry",
+			set:         NewIDSet(100, 101, 102, 103),
+			isRange:     true,
+			bits:        17 + 12 + 33,
+		},
+	}
+
+	for _, test := range testData {
+		bs := NewBitStreamForWrite()
+		bs.WriteOptimizedRange(test.set)
+		assert.Equal(t, test.bits, bs.GetPosition(), test.description)
+
+		bs.SetPosition(16)
+		isRange, _ := bs.ReadByte1()
+		assert.Equal(t, test.isRange, isRange == 1, test.description)
+
+		bs.SetPosition(0)
+		set, err := bs.ReadOptimizedRange()
+		assert.Nil(t, err, test.description)
+		assert.Equal(t, test.set.IDs(), set.IDs(), test.description)
+	}
+}
+
+func TestOptimizedIntRange(t *testing.T) {
+	set := NewIDSet(3, 4, 5, 900)
+
+	bs := NewBitStreamForWrite()
+	bs.WriteOptimizedIntRange(set)
+	bs.SetPosition(16)
+	isRange, _ := bs.ReadByte1()
+	assert.Equal(t, byte(1), isRange)
+
+	bs.SetPosition(0)
+	result, err := bs.ReadOptimizedIntRange()
+	assert.Nil(t, err)
+	assert.Equal(t, set, result)
+
+	dense := NewIDSet(1, 2, 3, 5, 6, 8)
+	bs = NewBitStreamForWrite()
+	bs.WriteOptimizedIntRange(dense)
+	assert.Equal(t, uint64(16+1+8), bs.GetPosition())
+	bs.SetPosition(0)
+	result, err = bs.ReadOptimizedIntRange()
+	assert.Nil(t, err)
+	assert.Equal(t, dense, result)
+}
+
+func TestReadOptimizedRangeOverlapping(t *testing.T) {
+	intRange := &IntRange{Size: 4095, Max: 65535}
+	for i := 0; i < 4095; i++ {
+		intRange.Range = append(intRange.Range, IRange{StartID: 1, EndID: 65535})
+	}
+	bs := NewBitStreamForWrite()
+	bs.WriteOptimizedRangeHeader(65535, true)
+	bs.WriteFixedIntRange(intRange)
+
+	bs.SetPosition(0)
+	set, err := bs.ReadOptimizedRange()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 65535, set.Len())
+	assert.True(t, set.Contains(1))
+	assert.True(t, set.Contains(65535))
+}
+
+func TestReadOptimizedRangeErrors(t *testing.T) {
+	_, err := NewBitStream([]byte{0x00}).ReadOptimizedRange()
+	assert.EqualError(t, err, "error reading max ID of OptimizedRange: expected 16 bits to start at bit 0, but the byte array was only 1 bytes long")
+
+	_, err = NewBitStream([]byte{0x00, 0x08}).ReadOptimizedIntRange()
+	assert.EqualError(t, err, "error reading encoding type of OptimizedIntRange: expected 1 bit to start at bit 16, but the byte array was only 2 bytes long")
+}
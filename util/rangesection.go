@@ -6,7 +6,7 @@ import "fmt"
 func (bs *BitStream) ReadIntRange() (*IntRange, error) {
 	numEntries, err := bs.ReadUInt12()
 	if err != nil {
-		return nil, fmt.Errorf("error reading size of Range(Int): %s", err)
+		return nil, fmt.Errorf("error reading size of Range(Int): %w", err)
 	}
 	var maxValue uint16
 
@@ -14,12 +14,12 @@ func (bs *BitStream) ReadIntRange() (*IntRange, error) {
 	for i := range ranges {
 		bit, err := bs.ReadByte1()
 		if err != nil {
-			return nil, fmt.Errorf("error reading the boolean bit of a Range(Int) entry: %s", err)
+			return nil, fmt.Errorf("error reading the boolean bit of a Range(Int) entry: %w", err)
 		}
 		if bit == 0 {
 			entry, err := bs.ReadUInt16()
 			if err != nil {
-				return nil, fmt.Errorf("error reading an int value in a Range(Int) entry: %s", err)
+				return nil, fmt.Errorf("error reading an int value in a Range(Int) entry: %w", err)
 			}
 			ranges[i].StartID = entry
 			ranges[i].EndID = entry
@@ -29,11 +29,11 @@ func (bs *BitStream) ReadIntRange() (*IntRange, error) {
 		} else {
 			ranges[i].StartID, err = bs.ReadUInt16()
 			if err != nil {
-				return nil, fmt.Errorf("error reading first int value in a Range(Int) entry: %s", err)
+				return nil, fmt.Errorf("error reading first int value in a Range(Int) entry: %w", err)
 			}
 			ranges[i].EndID, err = bs.ReadUInt16()
 			if err != nil {
-				return nil, fmt.Errorf("error reading second int value in a Range(Int) entry: %s", err)
+				return nil, fmt.Errorf("error reading second int value in a Range(Int) entry: %w", err)
 			}
 			if ranges[i].EndID > maxValue {
 				maxValue = ranges[i].EndID
@@ -73,3 +73,20 @@ func (ir *IntRange) IsSet(id uint16) bool {
 func (r IRange) Contains(id uint16) bool {
 	return r.StartID <= id && r.EndID >= id
 }
+
+// WriteFixedIntRange writes an IntRange object into the BitStream as a Range(Int), the counterpart of
+// ReadIntRange. Unlike WriteIntRange, which writes Fibonacci encoded offsets, every ID is written
+// as a 16-bit integer.
+func (bs *BitStream) WriteFixedIntRange(intRange *IntRange) {
+	bs.WriteUInt12(intRange.Size)
+	for _, r := range intRange.Range {
+		if r.StartID == r.EndID {
+			bs.WriteByte1(0)
+			bs.WriteUInt16(r.StartID)
+		} else {
+			bs.WriteByte1(1)
+			bs.WriteUInt16(r.StartID)
+			bs.WriteUInt16(r.EndID)
+		}
+	}
+}
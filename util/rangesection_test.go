@@ -35,3 +35,22 @@ func TestReadIntRange(t *testing.T) {
 	}}
 	assert.Equal(t, expected, ir)
 }
+
+func TestWriteFixedIntRange(t *testing.T) {
+	intRange := &IntRange{Size: 5, Max: 57257, Range: []IRange{
+		{StartID: 7, EndID: 7},
+		{StartID: 16, EndID: 22},
+		{StartID: 24, EndID: 25},
+		{StartID: 82, EndID: 82},
+		{StartID: 57234, EndID: 57257},
+	}}
+
+	bs := NewBitStreamForWrite()
+	bs.WriteFixedIntRange(intRange)
+	assert.Equal(t, "AFAAPABAAFoAMAAyAFLvyW_UgA", string(bs.Base64Encode()))
+
+	bs.SetPosition(0)
+	ir, err := bs.ReadIntRange()
+	assert.Nil(t, err, "Unexpected error: %v", err)
+	assert.Equal(t, intRange, ir)
+}